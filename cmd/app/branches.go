@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/internal/report"
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var presenceMarks = map[int]string{utils.BranchMissing: "✗", utils.BranchSame: "✓", utils.BranchEquivalent: "≈"}
+
+// newBranchesCmd returns the branches subcommand, which shows which changes are on
+// which of several branches, matching cherry-picks by patch ID.
+func newBranchesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "branches [repo]",
+		Short: "Show which commits are on which branches",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	list := cmd.Flags().String("branches", "", "comma-separated branches to compare (required)")
+	author := cmd.Flags().String("author", "", "comma-separated authors (default: everyone)")
+	since := cmd.Flags().String("since", "", "first day to include (YYYY-MM-DD or RFC 3339)")
+	xlsx := cmd.Flags().Bool("xlsx", false, "also write the matrix to <repo>_branches.xlsx")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		branches := report.SplitAuthors(*list)
+		if len(branches) < 2 {
+			return fmt.Errorf("--branches needs at least two branches, e.g. --branches main,release/1.x")
+		}
+
+		svc := git.NewCLIGitService()
+		dir, err := utils.ResolveDir(repoArg(args))
+		if err != nil {
+			return err
+		}
+		if !svc.IsGitRepo(dir) {
+			return notRepoError(dir)
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		loc, _ := cfg.Location()
+		from, err := report.ParseDate(*since, false, loc)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %v", err)
+		}
+
+		perBranch := make([][]models.CommitInfo, len(branches))
+		for i, b := range branches {
+			if perBranch[i], err = svc.BranchCommits(dir, b, report.SplitAuthors(*author), from); err != nil {
+				return fmt.Errorf("failed to read %s: %v", b, err)
+			}
+			perBranch[i] = utils.ConvertTimezone(perBranch[i], loc)
+		}
+		rows := utils.BuildBranchMatrix(perBranch)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "COMMIT\t%s\tMESSAGE\n", strings.Join(branches, "\t"))
+		for _, r := range rows {
+			marks := make([]string, len(branches))
+			for i := range branches {
+				marks[i] = presenceMarks[r.Presence(i)]
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", shortHash(r.Commit.Hash), strings.Join(marks, "\t"), r.Commit.Message)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Println("\n✓ same commit   ≈ same patch, different commit (cherry-pick)   ✗ missing")
+
+		if *xlsx {
+			path := utils.BranchMatrixPath(utils.ExportDir(dir), svc.GetRepositoryName(dir))
+			if err := utils.ExportBranchMatrix(branches, rows, path); err != nil {
+				return withExitCode(exitExportFailed, err)
+			}
+			fmt.Println("\n" + path)
+		}
+		return nil
+	}
+	return cmd
+}
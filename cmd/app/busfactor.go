@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func newBusFactorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bus-factor [repo]",
+		Short: "Measure how concentrated authorship is per directory",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	q := addQueryFlags(cmd)
+	threshold := cmd.Flags().Float64("threshold", utils.DefaultBusFactorThreshold, "share of commits the key authors must cover")
+	risky := cmd.Flags().Bool("risky", false, "only list directories with a bus factor of 1")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		if *threshold <= 0 || *threshold >= 1 {
+			return fmt.Errorf("--threshold must be between 0 and 1")
+		}
+
+		g, err := q.gather(repoArg(args))
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DIRECTORY\tCOMMITS\tAUTHORS\tTOP AUTHOR\tTOP SHARE\tBUS FACTOR")
+		atRisk := 0
+		for _, o := range utils.BusFactors(g.Commits, *threshold) {
+			if o.BusFactor == 1 {
+				atRisk++
+			} else if *risky {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%.0f%%\t%d\n", o.Directory, o.Commits, o.Authors, o.TopAuthor, o.TopShare*100, o.BusFactor)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("\n%d directories with a bus factor of 1\n", atRisk)
+		return nil
+	}
+	return cmd
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/leeozaka/gommits/internal/report"
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// newCompareCmd returns the compare subcommand, which compares the two authors given in
+// --author and writes the comparison workbook.
+func newCompareCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare [repo]",
+		Short: "Compare two authors over the same range",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	q := addQueryFlags(cmd)
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		authors := report.SplitAuthors(q.author)
+		if len(authors) != 2 {
+			return fmt.Errorf("--author must name exactly two authors, e.g. --author alice,bob")
+		}
+
+		g, err := q.gather(repoArg(args))
+		if err != nil {
+			return err
+		}
+		stats := utils.ComputeAuthorStats(g.Commits)
+		left, ok := utils.FindAuthor(stats, authors[0])
+		if !ok {
+			return withExitCode(exitNoCommits, fmt.Errorf("no commits by %s", authors[0]))
+		}
+		right, ok := utils.FindAuthor(stats, authors[1])
+		if !ok || right.Email == left.Email {
+			return withExitCode(exitNoCommits, fmt.Errorf("no commits by %s", authors[1]))
+		}
+		cmp := utils.CompareAuthors(g.Commits, left, right)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "\t%s\t%s\n", left.Name, right.Name)
+		fmt.Fprintf(w, "Commits\t%d\t%d\n", left.Commits, right.Commits)
+		fmt.Fprintf(w, "Files touched\t%d\t%d\n", left.Files, right.Files)
+		fmt.Fprintf(w, "Lines changed\t%d\t%d\n", left.LinesChanged(), right.LinesChanged())
+		fmt.Fprintf(w, "Avg commit size\t%.1f\t%.1f\n", left.AvgCommitSize(), right.AvgCommitSize())
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("\n%d of %d files touched by both (%.0f%% overlap)\n", cmp.SharedFiles(), len(cmp.Files), cmp.Overlap()*100)
+
+		path := utils.ComparisonPath(utils.ExportDir(g.dir), g.repoName())
+		if err := utils.ExportComparison(cmp, path); err != nil {
+			return withExitCode(exitExportFailed, err)
+		}
+		fmt.Println("\n" + path)
+		return nil
+	}
+	return cmd
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/spf13/cobra"
+)
+
+func newCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish",
+		Short: "Print the shell completion script",
+		Long: "Print the completion script for your shell. Besides subcommands it completes\n" +
+			"flags, repository arguments from the recently analyzed repositories and\n" +
+			"--parent, --branches, --from and --to from the repository's branches and tags.\n\n" +
+			"  bash:  source <(gommits completion bash)\n" +
+			"  zsh:   gommits completion zsh > \"${fpath[1]}/_gommits\"\n" +
+			"  fish:  gommits completion fish > ~/.config/fish/completions/gommits.fish",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				return root.GenZshCompletion(os.Stdout)
+			case "fish":
+				return root.GenFishCompletion(os.Stdout, true)
+			}
+			return fmt.Errorf("unsupported shell %q", args[0])
+		},
+	}
+}
+
+// refFlags are the flags whose values are refs of the repository, and how to list them.
+var refFlags = map[string]func(dir string) ([]string, error){
+	"parent":   git.Branches,
+	"branches": git.Branches,
+	"from":     git.ListTags,
+	"to":       git.ListTags,
+}
+
+// completeRepo completes the repository argument of a headless subcommand from the
+// recently analyzed repositories, falling back to directories.
+func completeRepo(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	recent, _ := config.LoadRecentRepos()
+	var matches []string
+	for _, r := range recent {
+		if strings.HasPrefix(r, toComplete) {
+			matches = append(matches, r)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+	return matches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerRefCompletion completes the values of cmd's ref flags, a comma-separated
+// list for --branches, from the repository argument or the current directory.
+func registerRefCompletion(cmd *cobra.Command) {
+	for name, list := range refFlags {
+		if cmd.Flags().Lookup(name) == nil {
+			continue
+		}
+		_ = cmd.RegisterFlagCompletionFunc(name, func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			refs, err := list(dir)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			done := toComplete[:strings.LastIndex(toComplete, ",")+1]
+			completions := make([]string, 0, len(refs))
+			for _, r := range refs {
+				completions = append(completions, done+r)
+			}
+			return completions, cobra.ShellCompDirectiveNoFileComp
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/schedule"
+	"github.com/spf13/cobra"
+)
+
+func newDaemonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Generate and deliver the scheduled reports",
+		Args:  cobra.NoArgs,
+	}
+	runNow := cmd.Flags().String("run", "", "run the named schedule once and exit")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		logger := log.New(os.Stderr, "gommits daemon: ", log.LstdFlags)
+		d, err := schedule.NewDaemon(git.NewCLIGitService(), cfg, logger)
+		if err != nil {
+			return err
+		}
+		if *runNow != "" {
+			return d.RunNow(*runNow)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := d.Run(ctx); err != nil && ctx.Err() == nil {
+			return err
+		}
+		return nil
+	}
+	return cmd
+}
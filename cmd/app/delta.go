@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/report"
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// newDeltaCmd returns the delta subcommand, which exports only the commits that a
+// previous export does not contain.
+func newDeltaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delta [repo]",
+		Short: "Export only the commits a previous export lacks",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	q := addQueryFlags(cmd)
+	previous := cmd.Flags().StringArray("previous", nil, "previous gommits export to compare with (.json or .xlsx, required; repeat to combine several)")
+	dryRun := cmd.Flags().BoolP("dry-run", "n", false, "only list the new commits, without exporting them")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		if len(*previous) == 0 {
+			return fmt.Errorf("--previous is required")
+		}
+
+		g, err := q.gather(repoArg(args))
+		if err != nil {
+			return err
+		}
+		// Each delta only holds its own commits, so a chain of them is combined.
+		seen := make(map[string]bool)
+		for _, path := range *previous {
+			hashes, err := utils.ExportedHashes(path, g.cfg.Excel.Password)
+			if err != nil {
+				return err
+			}
+			maps.Copy(seen, hashes)
+		}
+		fresh := utils.NewCommits(g.Commits, seen)
+		for _, c := range fresh {
+			fmt.Printf("%s  %s  %s\n", shortHash(c.Hash), c.Author, c.Message)
+		}
+		fmt.Printf("\n%d new commits since %s\n", len(fresh), strings.Join(*previous, ", "))
+		if *dryRun || len(fresh) == 0 {
+			return nil
+		}
+
+		if fresh, err = report.Enrich(g.svc, g.cfg, g.dir, fresh); err != nil {
+			fmt.Fprintln(os.Stderr, "warning:", err)
+		}
+		artifacts, err := utils.ExportCommits(fresh, g.dir, g.repoName()+utils.DeltaSuffix, g.cfg, nil)
+		if err != nil {
+			return withExitCode(exitExportFailed, err)
+		}
+		return deliver(g, fresh, artifacts)
+	}
+	return cmd
+}
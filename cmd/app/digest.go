@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// newDigestCmd returns the digest subcommand, which renders one digest for the last
+// --days across every repository given.
+func newDigestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "digest [repo...]",
+		Short: "Write a weekly digest across repositories",
+		Args:  cobra.ArbitraryArgs,
+	}
+	q := addQueryFlags(cmd)
+	days := cmd.Flags().Int("days", 7, "number of days to cover, counted back from now")
+	format := cmd.Flags().String("format", "md", "output format: md or html")
+	out := cmd.Flags().String("out", "", "output file, or - for stdout (default: <repo>_digest_<date>.<format> in the first repository)")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		if *format != "md" && *format != "html" {
+			return fmt.Errorf("unknown format %q: use md or html", *format)
+		}
+		if *days <= 0 {
+			return fmt.Errorf("--days must be positive")
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		to := time.Now()
+		if loc, _ := cfg.Location(); loc != nil {
+			to = to.In(loc)
+		}
+		from := to.AddDate(0, 0, -*days)
+		if q.since == "" {
+			q.since = from.Format(time.RFC3339)
+		}
+
+		repos := args
+		if len(repos) == 0 {
+			repos = []string{"."}
+		}
+		var sources []utils.DigestSource
+		var first *gathered
+		for _, repo := range repos {
+			g, err := q.gather(repo)
+			if err != nil {
+				return fmt.Errorf("%s: %w", repo, err)
+			}
+			if first == nil {
+				first = g
+			}
+			sources = append(sources, utils.DigestSource{Repository: g.repoName(), Commits: g.Commits})
+		}
+
+		content, err := utils.RenderDigest(utils.BuildDigest(sources, from, to), *format)
+		if err != nil {
+			return err
+		}
+		if *out == "-" {
+			_, err := fmt.Print(content)
+			return err
+		}
+		path := *out
+		if path == "" {
+			name := first.repoName()
+			if len(repos) > 1 {
+				name = "team"
+			}
+			path = utils.DigestPath(utils.ExportDir(first.dir), name, to, *format)
+		}
+		if path, err = filepath.Abs(path); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return withExitCode(exitExportFailed, fmt.Errorf("failed to write digest: %v", err))
+		}
+		fmt.Println(path)
+		return nil
+	}
+	return cmd
+}
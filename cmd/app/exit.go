@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/leeozaka/gommits/internal/git"
+)
+
+// Exit codes of the headless subcommands, documented in the README so wrapper
+// scripts can branch on them. Any other failure exits with 1.
+const (
+	exitNotRepo      = 2
+	exitNoCommits    = 3
+	exitExportFailed = 4
+)
+
+// exitError makes main exit with code rather than 1.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// withExitCode tags err with code; a nil err stays nil.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+func notRepoError(dir string) error {
+	return fmt.Errorf("%s: %w", dir, git.ErrNotARepo)
+}
+
+// exitCode is the code main exits with after err.
+func exitCode(err error) int {
+	var exit *exitError
+	switch {
+	case errors.As(err, &exit):
+		return exit.code
+	case errors.Is(err, git.ErrNotARepo):
+		return exitNotRepo
+	case errors.Is(err, git.ErrEmptyRepo):
+		return exitNoCommits
+	}
+	return 1
+}
+
+// quiet is set when the running headless subcommand was given -q.
+var quiet bool
+
+// silenceStdout sends everything printed to stdout to the null device, leaving
+// errors and warnings on stderr.
+func silenceStdout() error {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	os.Stdout = devNull
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/delivery"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/internal/report"
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// newExportCmd returns the export subcommand, which writes the selected commits the way
+// the TUI's export does.
+func newExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [repo]",
+		Short: "Write the selected commits to the configured export formats",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	q := addQueryFlags(cmd)
+	formats := cmd.Flags().String("format", "", "comma-separated formats to write (default: export.formats from the config)")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		g, err := q.gather(repoArg(args))
+		if err != nil {
+			return err
+		}
+		if len(g.Commits) == 0 {
+			return withExitCode(exitNoCommits, fmt.Errorf("no commits found"))
+		}
+		if *formats != "" {
+			g.cfg.Export.Formats = report.SplitAuthors(*formats)
+		}
+
+		commits, err := report.Enrich(g.svc, g.cfg, g.dir, g.Commits)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "warning:", err)
+		}
+		artifacts, err := utils.ExportCommits(commits, g.dir, g.repoName(), g.cfg, nil)
+		if err != nil {
+			return withExitCode(exitExportFailed, err)
+		}
+		return deliver(g, commits, artifacts)
+	}
+	return cmd
+}
+
+// deliver prints the paths of the written artifacts and hands them to the configured
+// uploads and notifications, which are listed on stderr so stdout stays one path per
+// line.
+func deliver(g *gathered, commits []models.CommitInfo, artifacts []string) error {
+	for _, a := range artifacts {
+		fmt.Println(a)
+	}
+	delivered, err := delivery.Deliver(g.cfg, delivery.Report{
+		RepoName:  g.repoName(),
+		Branch:    g.Branch,
+		Commits:   commits,
+		Artifacts: artifacts,
+	})
+	if len(delivered) > 0 {
+		fmt.Fprintln(os.Stderr, "delivered to", strings.Join(delivered, ", "))
+	}
+	if err != nil {
+		return withExitCode(exitExportFailed, fmt.Errorf("failed to deliver: %v", err))
+	}
+	return nil
+}
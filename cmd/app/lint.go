@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func newLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [repo]",
+		Short: "Score commit subjects against the lint rules",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	q := addQueryFlags(cmd)
+	xlsx := cmd.Flags().Bool("xlsx", false, "also write the compliance workbook to <repo>_lint.xlsx")
+	strict := cmd.Flags().Bool("strict", false, "fail when any commit breaks a rule")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		g, err := q.gather(repoArg(args))
+		if err != nil {
+			return err
+		}
+		linter, err := utils.NewLinter(g.cfg.Lint)
+		if err != nil {
+			return err
+		}
+
+		results := make([]utils.LintResult, len(g.Commits))
+		failed := 0
+		for i, c := range g.Commits {
+			results[i] = linter.Lint(c)
+			if len(results[i].Violations) == 0 {
+				continue
+			}
+			failed++
+			fmt.Printf("%s  %s\n         %s\n", shortHash(c.Hash), c.Message, strings.Join(results[i].Violations, ", "))
+		}
+		if failed > 0 {
+			fmt.Println()
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "AUTHOR\tCOMMITS\tCOMPLIANT\tSCORE")
+		for _, a := range utils.LintByAuthor(g.Commits, results) {
+			fmt.Fprintf(w, "%s\t%d\t%.0f%%\t%d\n", a.Author, a.Commits, a.ComplianceRate()*100, a.Score)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if *xlsx {
+			path := utils.LintPath(utils.ExportDir(g.dir), g.repoName())
+			if err := utils.ExportLintReport(g.Commits, path, g.cfg.Lint); err != nil {
+				return withExitCode(exitExportFailed, err)
+			}
+			fmt.Println("\n" + path)
+		}
+		if *strict && failed > 0 {
+			return fmt.Errorf("%d of %d commits failed lint", failed, len(g.Commits))
+		}
+		return nil
+	}
+	return cmd
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
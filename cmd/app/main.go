@@ -1,9 +1,124 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/httpretry"
 	"github.com/leeozaka/gommits/internal/ui"
+	"github.com/spf13/cobra"
 )
 
 func main() {
-	ui.StartUI()
+	cmd, err := newRootCmd().ExecuteC()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd.CommandPath(), err)
+		if advice := git.Advice(err); advice != "" {
+			fmt.Fprintln(os.Stderr, "hint:", advice)
+		}
+		os.Exit(exitCode(err))
+	}
+}
+
+// headless are the subcommands that run without the TUI, for scripts, CI and cron.
+var headless = []func() *cobra.Command{
+	newExportCmd,
+	newStatsCmd,
+	newServeCmd,
+	newDaemonCmd,
+	newReleaseNotesCmd,
+	newLintCmd,
+	newBusFactorCmd,
+	newTimesheetCmd,
+	newSprintCmd,
+	newStandupCmd,
+	newDigestCmd,
+	newCompareCmd,
+	newPeriodsCmd,
+	newBranchesCmd,
+	newDeltaCmd,
+}
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "gommits",
+		Short: "Browse, report on and export the commits of a Git repository",
+		Long: "Without a subcommand gommits starts the interactive TUI. The other subcommands run\n" +
+			"headless, for scripts, CI and cron; run them with --help for their flags. They all\n" +
+			"take -q (or --quiet) to print nothing but errors, and exit with:\n\n" +
+			"  0  success\n" +
+			"  1  any other error\n" +
+			"  2  not a git repository\n" +
+			"  3  no commits found\n" +
+			"  4  an export or report could not be written",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	tuiCmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Start the interactive TUI (the default)",
+		Args:  cobra.NoArgs,
+	}
+	for _, cmd := range []*cobra.Command{root, tuiCmd} {
+		opts := &ui.Options{}
+		cmd.Flags().BoolVar(&opts.Plain, "plain", false, "disable colors, borders and animations (also set by NO_COLOR)")
+		cmd.Flags().BoolVar(&opts.Accessible, "accessible", false, "screen-reader friendly output: plain, linear and labeled")
+		cmd.RunE = func(*cobra.Command, []string) error {
+			opts.Plain = opts.Plain || os.Getenv("NO_COLOR") != ""
+			ui.StartUI(*opts)
+			return nil
+		}
+	}
+	root.AddCommand(tuiCmd, newCompletionCmd())
+
+	for _, newCmd := range headless {
+		cmd := newCmd()
+		cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "print nothing but errors")
+		cmd.ValidArgsFunction = completeRepo
+		registerRefCompletion(cmd)
+		run := cmd.RunE
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			if quiet {
+				if err := silenceStdout(); err != nil {
+					return err
+				}
+			}
+			httpretry.OnRetry = reportRetry
+			return run(cmd, args)
+		}
+		root.AddCommand(cmd)
+	}
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return fmt.Errorf("%v\nRun '%s --help' for usage.", err, cmd.CommandPath())
+	})
+	root.SetArgs(longFlags(root, os.Args[1:]))
+	return root
+}
+
+// longFlags rewrites the single-dash long flags the headless subcommands took
+// before they moved to cobra (-author, -since=...) to their double-dash form, so
+// existing scripts and cron entries keep working.
+func longFlags(root *cobra.Command, args []string) []string {
+	cmd, _, err := root.Find(args)
+	if err != nil || cmd == root {
+		return args
+	}
+	out := make([]string, len(args))
+	copy(out, args)
+	for i, a := range out {
+		if a == "--" {
+			break
+		}
+		if len(a) < 3 || a[0] != '-' || a[1] == '-' {
+			continue
+		}
+		name, _, _ := strings.Cut(a[1:], "=")
+		if len(name) > 1 && cmd.Flags().Lookup(name) != nil {
+			out[i] = "-" + a
+		}
+	}
+	return out
 }
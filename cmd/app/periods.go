@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/report"
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// newPeriodsCmd returns the periods subcommand, which compares the current period with
+// the one before it. --since/--until pick a custom current period, compared with the
+// same number of days before it.
+func newPeriodsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "periods [repo]",
+		Short: "Compare this period with the one before it",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	q := addQueryFlags(cmd)
+	period := cmd.Flags().String("period", utils.PeriodMonth, "calendar period: week, month or quarter")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		loc, err := cfg.Location()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if loc != nil {
+			now = now.In(loc)
+		}
+
+		var current, previous utils.Period
+		if q.since != "" {
+			if current.From, err = report.ParseDate(q.since, false, loc); err != nil {
+				return fmt.Errorf("invalid --since: %v", err)
+			}
+			current.To = now
+			if q.until != "" {
+				until, err := report.ParseDate(q.until, true, loc)
+				if err != nil {
+					return fmt.Errorf("invalid --until: %v", err)
+				}
+				current.To = until.Add(time.Nanosecond)
+			}
+			if !current.From.Before(current.To) {
+				return fmt.Errorf("--since must be before --until")
+			}
+			previous = current.Preceding()
+		} else if current, previous, err = utils.CalendarPeriods(*period, now); err != nil {
+			return err
+		}
+		q.since = previous.From.Format(time.RFC3339)
+		q.until = current.To.Add(-time.Second).Format(time.RFC3339)
+
+		g, err := q.gather(repoArg(args))
+		if err != nil {
+			return err
+		}
+		prev := utils.StatsForPeriod(g.Commits, previous)
+		cur := utils.StatsForPeriod(g.Commits, current)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "\t%s\t%s\tCHANGE\n", previous, current)
+		fmt.Fprintf(w, "Commits\t%d\t%d\t%s\n", prev.Commits, cur.Commits, utils.FormatDelta(prev.Commits, cur.Commits))
+		fmt.Fprintf(w, "Churn (lines)\t%d\t%d\t%s\n", prev.Churn(), cur.Churn(), utils.FormatDelta(prev.Churn(), cur.Churn()))
+		fmt.Fprintf(w, "  Additions\t%d\t%d\t%s\n", prev.Additions, cur.Additions, utils.FormatDelta(prev.Additions, cur.Additions))
+		fmt.Fprintf(w, "  Deletions\t%d\t%d\t%s\n", prev.Deletions, cur.Deletions, utils.FormatDelta(prev.Deletions, cur.Deletions))
+		fmt.Fprintf(w, "Active days\t%d\t%d\t%s\n", prev.ActiveDays, cur.ActiveDays, utils.FormatDelta(prev.ActiveDays, cur.ActiveDays))
+		fmt.Fprintf(w, "Authors\t%d\t%d\t%s\n", prev.Authors, cur.Authors, utils.FormatDelta(prev.Authors, cur.Authors))
+		return w.Flush()
+	}
+	return cmd
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/report"
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// queryFlags are the commit selection flags shared by report subcommands.
+type queryFlags struct {
+	author   string
+	since    string
+	until    string
+	all      bool
+	missing  bool
+	unmerged bool
+	parent   string
+	max      int
+}
+
+// addQueryFlags declares the query flags on cmd.
+func addQueryFlags(cmd *cobra.Command) *queryFlags {
+	q := &queryFlags{}
+	fs := cmd.Flags()
+	fs.StringVar(&q.author, "author", "", "comma-separated authors (default: everyone)")
+	fs.StringVar(&q.since, "since", "", "first day to include (YYYY-MM-DD or RFC 3339)")
+	fs.StringVar(&q.until, "until", "", "last day to include (YYYY-MM-DD or RFC 3339)")
+	fs.BoolVar(&q.all, "all", false, "include all branches instead of the current one")
+	fs.BoolVar(&q.missing, "missing", false, "list commits on the parent branch that the current branch lacks")
+	fs.BoolVar(&q.unmerged, "unmerged", false, "list commits on any branch that have not landed on the parent branch")
+	fs.StringVar(&q.parent, "parent", "", "parent branch to exclude (default: detected)")
+	fs.IntVar(&q.max, "max", 0, "maximum number of commits (0 for no limit)")
+	return q
+}
+
+// gathered is a resolved repository together with the commits a query selected.
+type gathered struct {
+	svc git.GitService
+	cfg config.Config
+	dir string
+	report.Result
+}
+
+// gather resolves repo, loads the config and returns the selected commits converted
+// to the configured timezone.
+func (q *queryFlags) gather(repo string) (*gathered, error) {
+	svc := git.NewCLIGitService()
+	dir, err := utils.ResolveDir(repo)
+	if err != nil {
+		return nil, err
+	}
+	if !svc.IsGitRepo(dir) {
+		return nil, notRepoError(dir)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	loc, _ := cfg.Location()
+
+	rq := report.Query{
+		Dir:               dir,
+		Author:            q.author,
+		MaxCommits:        q.max,
+		CurrentBranchOnly: !q.all,
+		Missing:           q.missing,
+		Unmerged:          q.unmerged,
+		ParentBranch:      q.parent,
+	}
+	if rq.ParentBranch == "" {
+		rq.ParentBranch = svc.DetectDefaultBranch(dir)
+	}
+	if rq.Since, err = report.ParseDate(q.since, false, loc); err != nil {
+		return nil, fmt.Errorf("invalid --since: %v", err)
+	}
+	if rq.Until, err = report.ParseDate(q.until, true, loc); err != nil {
+		return nil, fmt.Errorf("invalid --until: %v", err)
+	}
+
+	rq.OnProgress = progressLine()
+	res, err := report.Gather(svc, rq)
+	if rq.OnProgress != nil {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+	if err != nil {
+		return nil, err
+	}
+	res.Commits = utils.ConvertTimezone(res.Commits, loc)
+	return &gathered{svc: svc, cfg: cfg, dir: dir, Result: res}, nil
+}
+
+func (g *gathered) repoName() string {
+	return g.svc.GetRepositoryName(g.dir)
+}
+
+// progressLine reports a gather on stderr, rewriting one line at most ten times a
+// second. It is nil when stderr is not a terminal or -q was given.
+func progressLine() func(git.Progress) {
+	if info, err := os.Stderr.Stat(); quiet || err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+	var last time.Time
+	return func(p git.Progress) {
+		if time.Since(last) < 100*time.Millisecond && p.Commits < p.Total {
+			return
+		}
+		last = time.Now()
+		if p.Total > 0 {
+			fmt.Fprintf(os.Stderr, "\rgathering: %d of %d commits, %d files", p.Commits, p.Total, p.Files)
+		} else {
+			fmt.Fprintf(os.Stderr, "\rgathering: %d commits, %d files", p.Commits, p.Files)
+		}
+	}
+}
+
+// reportRetry says on stderr that an API or delivery request failed and is being
+// retried, so a run waiting out a flaky connection does not look stuck.
+func reportRetry(_, _ int, err error) {
+	fmt.Fprintln(os.Stderr, "retrying after", err)
+}
+
+// repoArg is the repository argument of a headless subcommand, empty for the
+// current directory.
+func repoArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
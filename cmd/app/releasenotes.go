@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/report"
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func newReleaseNotesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release-notes [repo]",
+		Short: "Write the changes between two tags as Markdown or HTML",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	from := cmd.Flags().String("from", "", "older tag (default: the tag before --to)")
+	to := cmd.Flags().String("to", "", "newer tag or revision (default: the newest tag)")
+	format := cmd.Flags().String("format", "md", "output format: md or html")
+	out := cmd.Flags().String("out", "", "output file, or - for stdout (default: <repo>_release_<from>_<to>.<format>)")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		if *format != "md" && *format != "html" {
+			return fmt.Errorf("unknown format %q: use md or html", *format)
+		}
+
+		repo, err := utils.ResolveDir(repoArg(args))
+		if err != nil {
+			return err
+		}
+		svc := git.NewCLIGitService()
+		if !svc.IsGitRepo(repo) {
+			return notRepoError(repo)
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		if *to == "" || *from == "" {
+			tags, err := svc.ListTags(repo)
+			if err != nil {
+				return fmt.Errorf("failed to list tags: %v", err)
+			}
+			if *to == "" {
+				if len(tags) == 0 {
+					return fmt.Errorf("no tags found; pass --to")
+				}
+				*to = tags[0]
+			}
+			// Tags are newest first, so the previous release follows --to in the list.
+			if *from == "" {
+				if i := slices.Index(tags, *to); i >= 0 && i+1 < len(tags) {
+					*from = tags[i+1]
+				}
+			}
+		}
+
+		commits, err := svc.GatherRange(repo, *from, *to)
+		if err != nil {
+			return fmt.Errorf("failed to gather %s..%s: %v", *from, *to, err)
+		}
+		loc, _ := cfg.Location()
+		commits = utils.ConvertTimezone(commits, loc)
+		if commits, err = report.Enrich(svc, cfg, repo, commits); err != nil {
+			fmt.Fprintln(os.Stderr, "warning:", err)
+		}
+
+		repoName := svc.GetRepositoryName(repo)
+		notes := utils.BuildReleaseNotes(commits, repoName, *from, *to, time.Now())
+		content := notes.Markdown()
+		if *format == "html" {
+			if content, err = notes.HTML(); err != nil {
+				return err
+			}
+		}
+
+		if *out == "-" {
+			_, err := fmt.Print(content)
+			return err
+		}
+		path := *out
+		if path == "" {
+			path = utils.ReleaseNotesPath(utils.ExportDir(repo), repoName, *from, *to, *format)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return withExitCode(exitExportFailed, fmt.Errorf("failed to write release notes: %v", err))
+		}
+		fmt.Println(path)
+		return nil
+	}
+	return cmd
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/server"
+	"github.com/spf13/cobra"
+)
+
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the commit queries as a REST API",
+		Args:  cobra.NoArgs,
+	}
+	addr := cmd.Flags().String("addr", "127.0.0.1:8080", "address to listen on")
+	root := cmd.Flags().String("root", ".", "directory that repository paths are resolved against")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		srv, err := server.New(git.NewCLIGitService(), cfg, *root)
+		if err != nil {
+			return err
+		}
+
+		httpServer := &http.Server{
+			Addr:              *addr,
+			Handler:           srv.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		fmt.Printf("gommits: serving repositories under %s on http://%s\n", *root, *addr)
+		return httpServer.ListenAndServe()
+	}
+	return cmd
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// newSprintCmd returns the sprint subcommand, which gathers the last sprint for the
+// configured team and writes the sprint workbook in one step.
+func newSprintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sprint [repo]",
+		Short: "Write the sprint report for the configured team",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	q := addQueryFlags(cmd)
+	days := cmd.Flags().Int("days", 0, "sprint length in days (default: sprint.days, 14)")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		if *days <= 0 {
+			*days = cfg.Sprint.Days
+		}
+		if *days <= 0 {
+			return fmt.Errorf("--days must be positive")
+		}
+		if q.author == "" {
+			q.author = strings.Join(cfg.Sprint.Authors, ",")
+		}
+		to := time.Now()
+		if loc, _ := cfg.Location(); loc != nil {
+			to = to.In(loc)
+		}
+		from := to.AddDate(0, 0, -(*days - 1))
+		if q.since == "" {
+			q.since = from.Format(time.DateOnly)
+		}
+
+		g, err := q.gather(repoArg(args))
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "AUTHOR\tCOMMITS\tACTIVE DAYS")
+		for _, a := range utils.SprintByAuthor(g.Commits) {
+			fmt.Fprintf(w, "%s\t%d\t%d\n", a.Name, len(a.Commits), len(a.Days))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		path := utils.SprintPath(utils.ExportDir(g.dir), g.repoName())
+		if err := utils.ExportSprintReport(g.Commits, path, from, to); err != nil {
+			return withExitCode(exitExportFailed, err)
+		}
+		fmt.Println("\n" + path)
+		return nil
+	}
+	return cmd
+}
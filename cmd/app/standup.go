@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// newStandupCmd returns the standup subcommand, which prints the configured author's
+// commits since the previous working day.
+func newStandupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "standup [repo]",
+		Short: "Print your commits since the previous working day",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	q := addQueryFlags(cmd)
+	// Work in progress usually lives on feature branches.
+	q.all = true
+	cmd.Flags().Lookup("all").DefValue = "true"
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if loc, _ := cfg.Location(); loc != nil {
+			now = now.In(loc)
+		}
+		if q.since == "" {
+			q.since = utils.StandupSince(now).Format(time.DateOnly)
+		}
+		if q.author == "" {
+			q.author = cfg.Standup.Author
+		}
+		if q.author == "" {
+			dir := repoArg(args)
+			if dir == "" {
+				dir = "."
+			}
+			if q.author, err = git.NewCLIGitService().UserEmail(dir); err != nil || q.author == "" {
+				return fmt.Errorf("no author: pass --author, set standup.author or git user.email")
+			}
+		}
+
+		g, err := q.gather(repoArg(args))
+		if err != nil {
+			return err
+		}
+		fmt.Print(utils.RenderStandup(g.Commits, now))
+		return nil
+	}
+	return cmd
+}
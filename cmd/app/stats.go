@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// newStatsCmd returns the stats subcommand, which prints the stats screen's per-author
+// table.
+func newStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats [repo]",
+		Short: "Print commit, file and line counts per author",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	q := addQueryFlags(cmd)
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		g, err := q.gather(repoArg(args))
+		if err != nil {
+			return err
+		}
+		if len(g.Commits) == 0 {
+			return withExitCode(exitNoCommits, fmt.Errorf("no commits found"))
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "AUTHOR\tCOMMITS\tFILES\tADDED\tDELETED\tAVG SIZE")
+		rows := append(utils.ComputeAuthorStats(g.Commits), utils.RangeStats(g.Commits))
+		for _, st := range rows {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%.1f\n", st.Name, st.Commits, st.Files, st.Additions, st.Deletions, st.AvgCommitSize())
+		}
+		return w.Flush()
+	}
+	return cmd
+}
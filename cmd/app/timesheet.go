@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func newTimesheetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timesheet [repo]",
+		Short: "Estimate hours worked per author and day",
+		Args:  cobra.MaximumNArgs(1),
+	}
+	q := addQueryFlags(cmd)
+	xlsx := cmd.Flags().Bool("xlsx", false, "also write the worklog to <repo>_timesheet.xlsx")
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		g, err := q.gather(repoArg(args))
+		if err != nil {
+			return err
+		}
+
+		days := utils.BuildTimesheet(g.Commits, g.cfg.Timesheet)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DATE\tAUTHOR\tCOMMITS\tSESSIONS\tSTART\tEND\tHOURS")
+		for _, d := range days {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%.2f\n", d.Date, d.Author, len(d.Commits), d.Sessions,
+				d.Start.Format("15:04"), d.End.Format("15:04"), d.Hours)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("\n%.2f hours over %d author days\n", utils.TotalHours(days), len(days))
+		if amount := billedAmount(utils.Billing(days, g.cfg.Timesheet)); amount > 0 {
+			fmt.Printf("%.2f %s billable\n", amount, g.cfg.Timesheet.Currency)
+		}
+
+		if *xlsx {
+			path := utils.TimesheetPath(utils.ExportDir(g.dir), g.repoName())
+			if err := utils.ExportTimesheet(g.Commits, path, g.cfg); err != nil {
+				return withExitCode(exitExportFailed, err)
+			}
+			fmt.Println("\n" + path)
+		}
+		return nil
+	}
+	return cmd
+}
+
+func billedAmount(lines []utils.BillingLine) float64 {
+	total := 0.0
+	for _, l := range lines {
+		total += l.Amount()
+	}
+	return total
+}
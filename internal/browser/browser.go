@@ -0,0 +1,34 @@
+package browser
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// commands lists URL openers per platform, tried in order.
+var commands = map[string][][]string{
+	"darwin":  {{"open"}},
+	"windows": {{"rundll32", "url.dll,FileProtocolHandler"}},
+	"linux": {
+		{"xdg-open"},
+		{"wslview"}, // WSL
+	},
+}
+
+// Open shows url in the default web browser, or a local file in the application
+// registered for it, without waiting for it to close.
+func Open(url string) error {
+	for _, args := range commands[runtime.GOOS] {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(args[0], append(args[1:], url)...)
+		if err := cmd.Start(); err != nil {
+			continue
+		}
+		go cmd.Wait()
+		return nil
+	}
+	return fmt.Errorf("no browser opener found for %s", runtime.GOOS)
+}
@@ -0,0 +1,560 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// EnvConfigPath overrides the config file location when set.
+	EnvConfigPath = "GOMMITS_CONFIG"
+	// EnvExcelPassword overrides excel.password so it can be kept out of the file.
+	EnvExcelPassword = "GOMMITS_EXCEL_PASSWORD"
+	// EnvGraphClientSecret overrides upload.sharepoint.clientSecret.
+	EnvGraphClientSecret = "GOMMITS_GRAPH_CLIENT_SECRET"
+	// EnvSlackWebhook overrides notify.slack.webhookUrl.
+	EnvSlackWebhook = "GOMMITS_SLACK_WEBHOOK"
+	// EnvWebhookSecret overrides notify.webhook.secret.
+	EnvWebhookSecret = "GOMMITS_WEBHOOK_SECRET"
+	configDirName    = "gommits"
+	configFileName   = "config.json"
+)
+
+type Config struct {
+	// Timezone converts every commit timestamp before display and export: "UTC",
+	// "Local" or an IANA name such as "America/Sao_Paulo". Empty keeps each author's offset.
+	Timezone string `json:"timezone"`
+
+	Excel  ExcelConfig  `json:"excel"`
+	Export ExportConfig `json:"export"`
+	Upload UploadConfig `json:"upload"`
+	Notify NotifyConfig `json:"notify"`
+
+	Integrations IntegrationsConfig `json:"integrations"`
+
+	Lint LintConfig `json:"lint"`
+
+	Timesheet TimesheetConfig `json:"timesheet"`
+
+	Sprint SprintConfig `json:"sprint"`
+
+	Standup StandupConfig `json:"standup"`
+
+	UI UIConfig `json:"ui"`
+
+	// Schedules are the reports produced by `gommits daemon`.
+	Schedules []ScheduleConfig `json:"schedules"`
+
+	// Presets are saved queries the TUI runs from its preset screen.
+	Presets []PresetConfig `json:"presets"`
+}
+
+// LintConfig sets the rules commit messages are scored against.
+type LintConfig struct {
+	// MaxSubjectLength flags longer subjects; 0 disables the rule.
+	MaxSubjectLength int `json:"maxSubjectLength"`
+	// Imperative flags subjects that start with "Added", "Fixes", "Updating" and the like.
+	Imperative bool `json:"imperative"`
+	// RequireTicket flags subjects without a ticket reference matching TicketPattern.
+	RequireTicket bool `json:"requireTicket"`
+	// TicketPattern is a regular expression; empty accepts AB#123, PROJ-123 and #123.
+	TicketPattern string `json:"ticketPattern"`
+}
+
+// TimesheetConfig tunes how commit times are turned into estimated hours worked.
+type TimesheetConfig struct {
+	// SessionGapMinutes splits a day into work sessions wherever commits are further apart.
+	SessionGapMinutes int `json:"sessionGapMinutes"`
+	// FirstCommitMinutes is credited before the first commit of each session, for the
+	// work that led up to it.
+	FirstCommitMinutes int `json:"firstCommitMinutes"`
+	// MinHoursPerDay and MaxHoursPerDay clamp each day with commits; 0 disables a bound.
+	MinHoursPerDay float64 `json:"minHoursPerDay"`
+	MaxHoursPerDay float64 `json:"maxHoursPerDay"`
+	// RoundMinutes rounds each day up to this increment; 0 disables rounding.
+	RoundMinutes int `json:"roundMinutes"`
+
+	// Rates maps an author name or email (case-insensitive) to an hourly rate; authors
+	// not listed bill at DefaultRate.
+	Rates       map[string]float64 `json:"rates,omitempty"`
+	DefaultRate float64            `json:"defaultRate"`
+	// Currency labels the amounts on the invoice sheet, e.g. "USD".
+	Currency string `json:"currency,omitempty"`
+	// ProjectDepth is how many path segments identify a project on the invoice (default 1).
+	ProjectDepth int `json:"projectDepth"`
+}
+
+// SprintConfig is the preset used by `gommits sprint`.
+type SprintConfig struct {
+	// Days is the sprint length, counted back from today.
+	Days int `json:"days"`
+	// Authors is the team reported on when --author is not given; empty means everyone.
+	Authors []string `json:"authors,omitempty"`
+}
+
+// StandupConfig is used by `gommits standup`.
+type StandupConfig struct {
+	// Author whose commits are listed; empty uses the repository's git user.email.
+	Author string `json:"author"`
+}
+
+// UIConfig tunes the interactive interface.
+type UIConfig struct {
+	// Pager opens diffs with the P key, e.g. "delta" or "less -R"; empty follows git's
+	// own choice of $GIT_PAGER, core.pager, $PAGER and less.
+	Pager string `json:"pager"`
+	// Hyperlinks makes commit hashes clickable links to the code host (OSC 8) in
+	// terminals that support them.
+	Hyperlinks bool `json:"hyperlinks"`
+	// Vim adds j/k, h/l, gg/G and Ctrl+D/Ctrl+U navigation to the list and diff screens.
+	Vim bool `json:"vim"`
+	// Keys rebinds the back, export and quit actions, e.g. {"back": ["alt+b"]}. Keys
+	// use Bubble Tea names such as "enter", "esc", "ctrl+b" or a single character;
+	// Ctrl+C always quits.
+	Keys map[string][]string `json:"keys,omitempty"`
+	// Locale is the language of the TUI: en or pt-BR. Empty follows $LC_ALL, $LC_MESSAGES
+	// or $LANG.
+	Locale string `json:"locale,omitempty"`
+	// Theme picks the built-in palette: "dark" (the default), "light" or "high-contrast".
+	Theme string `json:"theme,omitempty"`
+	// Colors overrides single colors of the theme, e.g. {"primary": "#005FAF"}.
+	Colors Theme `json:"colors,omitempty"`
+}
+
+// Theme is the palette of the TUI. Colors are "#RRGGBB" hex strings; empty fields
+// keep the color of the selected preset.
+type Theme struct {
+	Primary   string            `json:"primary,omitempty"` // titles, keys and the selection cursor
+	Text      string            `json:"text,omitempty"`    // text on banners, toasts and badges
+	Info      string            `json:"info,omitempty"`    // status banner background
+	Success   string            `json:"success,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	Muted     string            `json:"muted,omitempty"` // hints and secondary details
+	Hash      string            `json:"hash,omitempty"`
+	Match     string            `json:"match,omitempty"` // search match background
+	MatchText string            `json:"matchText,omitempty"`
+	Added     string            `json:"added,omitempty"`
+	Removed   string            `json:"removed,omitempty"`
+	Hunk      string            `json:"hunk,omitempty"`
+	File      string            `json:"file,omitempty"`
+	Syntax    string            `json:"syntax,omitempty"`  // chroma style for code in diffs, e.g. "monokai"
+	Heatmap   []string          `json:"heatmap,omitempty"` // calendar levels, no commits to the busiest day
+	Badges    map[string]string `json:"badges,omitempty"`  // conventional commit type backgrounds
+}
+
+// IntegrationsConfig enables optional lookups against code hosts and issue trackers.
+type IntegrationsConfig struct {
+	GitHub      GitHubConfig      `json:"github"`
+	Bitbucket   BitbucketConfig   `json:"bitbucket"`
+	AzureBoards AzureBoardsConfig `json:"azureBoards"`
+	Jira        JiraConfig        `json:"jira"`
+	// Retries is how many times an API request that failed on the network or with a
+	// 429 or 5xx is retried, with exponential backoff or after the 429's Retry-After.
+	// Unset means DefaultRetries and 0 disables retries.
+	Retries *int `json:"retries"`
+}
+
+// GitHubConfig applies to repositories whose origin remote is on github.com or Host.
+type GitHubConfig struct {
+	// Enrich attaches the pull request, review state and checks status to each commit.
+	Enrich bool `json:"enrich"`
+	// Token falls back to GITHUB_TOKEN; without one the API allows 60 requests an hour.
+	Token string `json:"token"`
+	// Host and APIURL point at GitHub Enterprise Server, e.g. "github.example.com"
+	// and "https://github.example.com/api/v3".
+	Host   string `json:"host"`
+	APIURL string `json:"apiUrl"`
+}
+
+// BitbucketConfig applies to repositories on bitbucket.org, or on a Bitbucket Server /
+// Data Center instance when Host is set.
+type BitbucketConfig struct {
+	// Enrich attaches the pull request, review state and build status to each commit.
+	Enrich bool `json:"enrich"`
+	// Username with Token (an app password) selects basic auth; Token alone is sent
+	// as a bearer access token. Token falls back to BITBUCKET_TOKEN.
+	Username string `json:"username"`
+	Token    string `json:"token"`
+	// Host and BaseURL point at Bitbucket Server, e.g. "bitbucket.example.com" and
+	// "https://bitbucket.example.com"; BaseURL defaults to https://<Host>.
+	Host    string `json:"host"`
+	BaseURL string `json:"baseUrl"`
+}
+
+// AzureBoardsConfig resolves AB#1234 work item references in commit messages.
+type AzureBoardsConfig struct {
+	// Enrich queries Azure Boards for each referenced work item's title and state.
+	Enrich bool `json:"enrich"`
+	// Organization is inferred from Azure Repos remotes when empty.
+	Organization string `json:"organization"`
+	// Token is a personal access token with Work Items (Read) scope; falls back to
+	// AZURE_DEVOPS_EXT_PAT.
+	Token string `json:"token"`
+	// BaseURL defaults to https://dev.azure.com/<Organization>; set it for Azure
+	// DevOps Server, e.g. "https://tfs.example.com/DefaultCollection".
+	BaseURL string `json:"baseUrl"`
+}
+
+// JiraConfig controls how issue keys such as PROJ-123 are read from commit messages.
+type JiraConfig struct {
+	// Projects restricts extraction to these project keys. When empty any key-shaped
+	// token counts, except common false positives like UTF-8 or SHA-256.
+	Projects []string `json:"projects"`
+	// BaseURL is the Jira site, e.g. "https://acme.atlassian.net"; it enables issue links.
+	BaseURL string `json:"baseUrl"`
+	// Enrich fetches each issue's summary and status from BaseURL.
+	Enrich bool `json:"enrich"`
+	// Email with Token (an API token) selects basic auth as Jira Cloud expects; Token
+	// alone is sent as a Data Center personal access token. Token falls back to JIRA_API_TOKEN.
+	Email string `json:"email"`
+	Token string `json:"token"`
+}
+
+// ScheduleConfig is one recurring report: which commits to gather, how to export
+// them and where to deliver the result.
+type ScheduleConfig struct {
+	Name string `json:"name"`
+	// Cron is a five-field expression evaluated in Timezone (local time when empty),
+	// e.g. "0 17 * * 5" for Fridays at 17:00. @hourly, @daily, @weekly and @monthly also work.
+	Cron   string `json:"cron"`
+	Repo   string `json:"repo"`
+	Author string `json:"author"`
+	// Days limits the report to commits from the last N days; 0 includes everything.
+	Days int `json:"days"`
+	// AllBranches includes commits already merged into ParentBranch.
+	AllBranches bool `json:"allBranches"`
+	// ParentBranch defaults to the repository's detected default branch.
+	ParentBranch string `json:"parentBranch"`
+	// Formats overrides export.formats for this schedule.
+	Formats []string `json:"formats"`
+	// Upload and Notify replace the global destinations for this schedule when set.
+	Upload *UploadConfig `json:"upload"`
+	Notify *NotifyConfig `json:"notify"`
+}
+
+// PresetConfig is a named query, e.g. "weekly-report-alice", run in one keystroke.
+type PresetConfig struct {
+	Name   string `json:"name"`
+	Repo   string `json:"repo"`
+	Author string `json:"author"`
+	// ParentBranch defaults to the repository's detected default branch.
+	ParentBranch string `json:"parentBranch"`
+	// Formats overrides export.formats for exports of this preset's results.
+	Formats []string `json:"formats"`
+}
+
+// NotifyConfig lists channels told about each export once uploads have finished.
+type NotifyConfig struct {
+	Slack   SlackConfig   `json:"slack"`
+	Webhook WebhookConfig `json:"webhook"`
+}
+
+// WebhookConfig POSTs the JSON export to an arbitrary endpoint after each run.
+type WebhookConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	// Secret signs the body with HMAC-SHA256 in the X-Gommits-Signature header.
+	Secret string `json:"secret"`
+	// Retries is how many times a failed delivery is retried; unset means
+	// DefaultRetries and 0 disables retries.
+	Retries *int `json:"retries"`
+}
+
+func (c WebhookConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+type SlackConfig struct {
+	// WebhookURL is a Slack incoming-webhook URL; empty disables the notification.
+	WebhookURL string `json:"webhookUrl"`
+	// Channel overrides the webhook's default channel where the webhook allows it.
+	Channel string `json:"channel"`
+	// LinkURL is appended as an "Open report" link, e.g. the SharePoint folder.
+	LinkURL string `json:"linkUrl"`
+	// Retries is how many times a failed notification is retried; unset means
+	// DefaultRetries and 0 disables retries.
+	Retries *int `json:"retries"`
+}
+
+func (c SlackConfig) Enabled() bool {
+	return c.WebhookURL != ""
+}
+
+// UploadConfig lists destinations that receive artifacts after every export.
+type UploadConfig struct {
+	SharePoint SharePointConfig `json:"sharepoint"`
+	S3         S3Config         `json:"s3"`
+	// Retries is how many times a failed upload request is retried; unset means
+	// DefaultRetries and 0 disables retries.
+	Retries *int `json:"retries"`
+}
+
+// SharePointConfig uploads through Microsoft Graph with an app registration that has
+// Files.ReadWrite.All (or Sites.Selected) application permission.
+type SharePointConfig struct {
+	TenantID     string `json:"tenantId"`
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+	// DriveID identifies the SharePoint document library or OneDrive.
+	DriveID string `json:"driveId"`
+	// Folder is the path inside the drive, e.g. "Reports/Weekly".
+	Folder string `json:"folder"`
+}
+
+func (c SharePointConfig) Enabled() bool {
+	return c.DriveID != "" && c.TenantID != "" && c.ClientID != ""
+}
+
+type ExportConfig struct {
+	// Formats lists the artifacts to write: any of "xlsx", "csv", "json", "template",
+	// "changelog", "lint", "authors", "leaderboard", "timesheet", "digest".
+	Formats []string `json:"formats"`
+	// Template is the Go text/template file rendered by the "template" format.
+	Template string `json:"template"`
+	// Plugins maps extra format names to external commands. Formats not listed here
+	// are also looked up on PATH as gommits-export-<format>.
+	Plugins map[string]PluginConfig `json:"plugins"`
+	// Zip bundles every artifact of a run into one timestamped archive.
+	Zip bool `json:"zip"`
+	// Columns picks and orders the Excel/CSV columns: hash, author, email, date,
+	// message, files, additions, deletions, lines, branch, period. Empty uses the default set.
+	Columns []string `json:"columns"`
+	// GroupBy is "day", "week" or "month": it adds a period column after the date
+	// and is the results screen's initial grouping. Empty leaves commits ungrouped.
+	GroupBy string `json:"groupBy"`
+	// DateFormat is "iso8601" (default), "date", "datetime", "rfc2822", "git" for
+	// git's raw string, or any Go time layout such as "02/01/2006 15:04".
+	DateFormat string `json:"dateFormat"`
+
+	Changelog ChangelogConfig `json:"changelog"`
+	// AuthorsFile, relative to the repository, is where the "authors" format writes
+	// (e.g. "AUTHORS"). Empty writes <repo>_AUTHORS.txt instead.
+	AuthorsFile string `json:"authorsFile"`
+	// DigestFormat is "md" (default) or "html" for the "digest" format.
+	DigestFormat string `json:"digestFormat"`
+}
+
+// ChangelogConfig shapes the section written by the "changelog" format.
+type ChangelogConfig struct {
+	// Title heads the section, e.g. "v1.4.0"; defaults to "Unreleased".
+	Title string `json:"title"`
+	// Types lists the conventional types to include, in order; "other" adds
+	// non-conventional commits. Defaults to feat, fix, perf and revert.
+	Types []string `json:"types"`
+	// File, relative to the repository, receives the section above its newest release
+	// (e.g. "CHANGELOG.md"). Empty writes <repo>_CHANGELOG.md instead.
+	File string `json:"file"`
+}
+
+type ExcelConfig struct {
+	// LargeCommitFiles highlights commits touching more files than this; 0 disables.
+	LargeCommitFiles int `json:"largeCommitFiles"`
+	// LargeCommitLines highlights commits changing more lines than this; 0 disables.
+	LargeCommitLines int `json:"largeCommitLines"`
+
+	// Locale selects the language of headers and labels: en, pt-BR, es or de.
+	Locale string `json:"locale"`
+
+	// Password encrypts the workbook so it cannot be opened without it; empty disables.
+	Password string `json:"password"`
+	// ProtectSheets additionally locks every sheet against edits using Password.
+	ProtectSheets bool `json:"protectSheets"`
+
+	// Append keeps the commits already in an existing workbook and adds only new ones,
+	// so one file can hold a rolling history.
+	Append bool `json:"append"`
+
+	// Sheets adds analysis sheets after Summary: "activity" (weekday × hour),
+	// "hotspots" (most frequently changed files), "busfactor" (directory ownership)
+	// and "directories" (commits and lines per directory and author).
+	Sheets []string `json:"sheets"`
+	// DirectoryDepth is how many path segments the "directories" sheet groups by; 0 means 2.
+	DirectoryDepth int `json:"directoryDepth"`
+
+	Theme ExcelTheme `json:"theme"`
+}
+
+// PluginConfig describes an exec-based exporter: Command receives the JSON export on
+// stdin and writes the artifact to stdout, saved as <repo>_commits.<Extension>.
+type PluginConfig struct {
+	Command   []string `json:"command"`
+	Extension string   `json:"extension"`
+}
+
+// S3Config targets AWS S3 or any S3-compatible store. Empty credentials fall back to
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, AWS_REGION and AWS_ENDPOINT_URL.
+type S3Config struct {
+	// Endpoint defaults to https://s3.<region>.amazonaws.com.
+	Endpoint string `json:"endpoint"`
+	Region   string `json:"region"`
+	Bucket   string `json:"bucket"`
+	// Prefix is prepended to object keys, e.g. "reports/gommits".
+	Prefix string `json:"prefix"`
+	// PathStyle addresses objects as endpoint/bucket/key, as MinIO and most
+	// self-hosted servers expect.
+	PathStyle       bool   `json:"pathStyle"`
+	AccessKeyID     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+	SessionToken    string `json:"sessionToken"`
+}
+
+func (c S3Config) Enabled() bool {
+	return c.Bucket != ""
+}
+
+// ExcelTheme controls the look of the commits workbook. Colors are "#RRGGBB".
+type ExcelTheme struct {
+	HeaderFill      string  `json:"headerFill"`
+	HeaderFontColor string  `json:"headerFontColor"`
+	HeaderFontSize  float64 `json:"headerFontSize"`
+	// FontFamily applies to every cell; empty keeps Excel's default.
+	FontFamily string `json:"fontFamily"`
+	// TableStyle is one of Excel's built-in table styles, e.g. "TableStyleMedium2".
+	TableStyle string `json:"tableStyle"`
+}
+
+func Default() Config {
+	return Config{
+		Excel: ExcelConfig{
+			LargeCommitFiles: 50,
+			LargeCommitLines: 1000,
+			Locale:           "en",
+			Theme: ExcelTheme{
+				HeaderFill:      "#4472C4",
+				HeaderFontColor: "#FFFFFF",
+				HeaderFontSize:  12,
+				TableStyle:      "TableStyleMedium2",
+			},
+		},
+		Export: ExportConfig{
+			Formats:    []string{"xlsx"},
+			DateFormat: "iso8601",
+		},
+		Lint: LintConfig{
+			MaxSubjectLength: 72,
+			Imperative:       true,
+		},
+		Timesheet: TimesheetConfig{
+			SessionGapMinutes:  120,
+			FirstCommitMinutes: 30,
+			MinHoursPerDay:     0.5,
+			MaxHoursPerDay:     10,
+			RoundMinutes:       15,
+			ProjectDepth:       1,
+		},
+		Sprint: SprintConfig{Days: 14},
+		UI:     UIConfig{Hyperlinks: true},
+	}
+}
+
+// DefaultRetries is how many times a failed network request is retried when the
+// config does not say.
+const DefaultRetries = 2
+
+// RetryCount resolves an optional retries setting: unset or negative means
+// DefaultRetries, 0 disables retries.
+func RetryCount(retries *int) int {
+	if retries == nil || *retries < 0 {
+		return DefaultRetries
+	}
+	return *retries
+}
+
+// Path returns the config file location: $GOMMITS_CONFIG if set, otherwise
+// <user config dir>/gommits/config.json.
+func Path() (string, error) {
+	if p := os.Getenv(EnvConfigPath); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configDirName, configFileName), nil
+}
+
+// Load reads the config file on top of Default. A missing file is not an error.
+func Load() (Config, error) {
+	cfg := Default()
+
+	path, err := Path()
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		applyEnv(&cfg)
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Default(), fmt.Errorf("invalid config %s: %v", path, err)
+	}
+	if _, err := cfg.Location(); err != nil {
+		return Default(), fmt.Errorf("invalid config %s: %v", path, err)
+	}
+	applyEnv(&cfg)
+	return cfg, nil
+}
+
+// Location resolves Timezone; it returns nil when timestamps should keep their own offset.
+func (c Config) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", c.Timezone)
+	}
+	return loc, nil
+}
+
+func applyEnv(cfg *Config) {
+	if p := os.Getenv(EnvExcelPassword); p != "" {
+		cfg.Excel.Password = p
+	}
+	if s := os.Getenv(EnvGraphClientSecret); s != "" {
+		cfg.Upload.SharePoint.ClientSecret = s
+	}
+	if u := os.Getenv(EnvSlackWebhook); u != "" {
+		cfg.Notify.Slack.WebhookURL = u
+	}
+	if s := os.Getenv(EnvWebhookSecret); s != "" {
+		cfg.Notify.Webhook.Secret = s
+	}
+
+	if cfg.Integrations.GitHub.Token == "" {
+		cfg.Integrations.GitHub.Token = os.Getenv("GITHUB_TOKEN")
+	}
+	if cfg.Integrations.Bitbucket.Token == "" {
+		cfg.Integrations.Bitbucket.Token = os.Getenv("BITBUCKET_TOKEN")
+	}
+	if cfg.Integrations.Jira.Token == "" {
+		cfg.Integrations.Jira.Token = os.Getenv("JIRA_API_TOKEN")
+	}
+	if cfg.Integrations.AzureBoards.Token == "" {
+		cfg.Integrations.AzureBoards.Token = os.Getenv("AZURE_DEVOPS_EXT_PAT")
+	}
+
+	s3 := &cfg.Upload.S3
+	for field, env := range map[*string]string{
+		&s3.AccessKeyID:     "AWS_ACCESS_KEY_ID",
+		&s3.SecretAccessKey: "AWS_SECRET_ACCESS_KEY",
+		&s3.SessionToken:    "AWS_SESSION_TOKEN",
+		&s3.Region:          "AWS_REGION",
+		&s3.Endpoint:        "AWS_ENDPOINT_URL",
+	} {
+		if *field == "" {
+			*field = os.Getenv(env)
+		}
+	}
+}
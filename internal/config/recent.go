@@ -0,0 +1,74 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	recentFileName = "recent.json"
+	// MaxRecentRepos is how many repositories the TUI remembers.
+	MaxRecentRepos = 10
+)
+
+// RecentReposPath returns where the recently analyzed repositories are kept, next
+// to the config file.
+func RecentReposPath() (string, error) {
+	path, err := Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), recentFileName), nil
+}
+
+// LoadRecentRepos returns the recently analyzed repositories, most recent first.
+// A missing file is not an error.
+func LoadRecentRepos() ([]string, error) {
+	path, err := RecentReposPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var repos []string
+	if err := json.Unmarshal(data, &repos); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return repos, nil
+}
+
+// SaveRecentRepos replaces the recently analyzed repositories.
+func SaveRecentRepos(repos []string) error {
+	path, err := RecentReposPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(repos, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", filepath.Dir(path), err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// AddRecentRepo moves dir to the front of repos, keeping at most MaxRecentRepos.
+func AddRecentRepo(repos []string, dir string) []string {
+	updated := []string{dir}
+	for _, r := range repos {
+		if r != dir && len(updated) < MaxRecentRepos {
+			updated = append(updated, r)
+		}
+	}
+	return updated
+}
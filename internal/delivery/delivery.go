@@ -0,0 +1,59 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/httpretry"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+const httpTimeout = 2 * time.Minute
+
+// Report describes one finished export run.
+type Report struct {
+	RepoName  string
+	Branch    string
+	Commits   []models.CommitInfo
+	Artifacts []string
+}
+
+// Deliver uploads the report's artifacts to every configured destination, then sends
+// notifications, retrying requests that fail on the network or with a 429 or 5xx.
+// It returns the names of the destinations that succeeded and stops at the first
+// failure.
+func Deliver(cfg config.Config, report Report) ([]string, error) {
+	client := &http.Client{Timeout: httpTimeout}
+	uploader := httpretry.New(client, config.RetryCount(cfg.Upload.Retries))
+
+	var delivered []string
+	if sp := cfg.Upload.SharePoint; sp.Enabled() {
+		if err := uploadSharePoint(uploader, sp, report.Artifacts); err != nil {
+			return delivered, fmt.Errorf("SharePoint upload: %v", err)
+		}
+		delivered = append(delivered, "SharePoint")
+	}
+	if s3 := cfg.Upload.S3; s3.Enabled() {
+		if err := uploadS3(uploader, s3, report.Artifacts); err != nil {
+			return delivered, fmt.Errorf("S3 upload: %v", err)
+		}
+		delivered = append(delivered, "S3")
+	}
+
+	uploads := append([]string(nil), delivered...)
+	if slack := cfg.Notify.Slack; slack.Enabled() {
+		if err := notifySlack(httpretry.New(client, config.RetryCount(slack.Retries)), slack, report, uploads); err != nil {
+			return delivered, fmt.Errorf("Slack notification: %v", err)
+		}
+		delivered = append(delivered, "Slack")
+	}
+	if hook := cfg.Notify.Webhook; hook.Enabled() {
+		if err := postWebhook(httpretry.New(client, config.RetryCount(hook.Retries)), hook, report); err != nil {
+			return delivered, fmt.Errorf("webhook: %v", err)
+		}
+		delivered = append(delivered, "webhook")
+	}
+	return delivered, nil
+}
@@ -0,0 +1,167 @@
+package delivery
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/httpretry"
+)
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	amzDateLayout   = "20060102T150405Z"
+	amzScopeLayout  = "20060102"
+	s3ServiceName   = "s3"
+	defaultS3Region = "us-east-1"
+)
+
+// uploadS3 PUTs each artifact to <bucket>/<prefix>/<name> on any S3-compatible endpoint.
+func uploadS3(client *httpretry.Client, cfg config.S3Config, artifacts []string) error {
+	for _, artifact := range artifacts {
+		if err := s3Put(client, cfg, artifact, time.Now().UTC()); err != nil {
+			return fmt.Errorf("%s: %v", filepath.Base(artifact), err)
+		}
+	}
+	return nil
+}
+
+func s3Put(client *httpretry.Client, cfg config.S3Config, artifact string, now time.Time) error {
+	data, err := os.ReadFile(artifact)
+	if err != nil {
+		return err
+	}
+
+	objectURL, err := s3ObjectURL(cfg, path.Join(cfg.Prefix, filepath.Base(artifact)))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, objectURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if ct := mime.TypeByExtension(filepath.Ext(artifact)); ct != "" {
+		req.Header.Set("Content-Type", ct)
+	}
+
+	sum := sha256.Sum256(data)
+	signS3Request(req, hex.EncodeToString(sum[:]), cfg, now)
+	return doExpect(client, req, http.StatusOK)
+}
+
+// s3ObjectURL builds a virtual-hosted URL (bucket.host/key) or, with PathStyle, the
+// host/bucket/key form most self-hosted S3 servers such as MinIO require.
+func s3ObjectURL(cfg config.S3Config, key string) (string, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", s3Region(cfg))
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid S3 endpoint: %v", err)
+	}
+
+	key = strings.TrimPrefix(key, "/")
+	if cfg.PathStyle {
+		u.Path = "/" + cfg.Bucket + "/" + key
+	} else {
+		u.Host = cfg.Bucket + "." + u.Host
+		u.Path = "/" + key
+	}
+	return u.String(), nil
+}
+
+func s3Region(cfg config.S3Config) string {
+	if cfg.Region != "" {
+		return cfg.Region
+	}
+	return defaultS3Region
+}
+
+// signS3Request adds AWS Signature Version 4 headers, signing host and every header
+// already set on req.
+func signS3Request(req *http.Request, payloadHash string, cfg config.S3Config, now time.Time) {
+	amzDate := now.Format(amzDateLayout)
+	scopeDate := now.Format(amzScopeLayout)
+	region := s3Region(cfg)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", cfg.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{scopeDate, region, s3ServiceName, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{sigV4Algorithm, amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+cfg.SecretAccessKey), scopeDate)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, s3ServiceName)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// s3EscapePath URI-encodes each path segment per SigV4 (RFC 3986 unreserved set kept).
+func s3EscapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		var b strings.Builder
+		for _, c := range []byte(s) {
+			if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || strings.IndexByte("-_.~", c) >= 0 {
+				b.WriteByte(c)
+			} else {
+				fmt.Fprintf(&b, "%%%02X", c)
+			}
+		}
+		segments[i] = b.String()
+	}
+	return strings.Join(segments, "/")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
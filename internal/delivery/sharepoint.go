@@ -0,0 +1,160 @@
+package delivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/httpretry"
+)
+
+const (
+	graphBaseURL  = "https://graph.microsoft.com/v1.0"
+	graphTokenURL = "https://login.microsoftonline.com/%s/oauth2/v2.0/token"
+	graphScope    = "https://graph.microsoft.com/.default"
+	// Graph accepts a single PUT up to 4 MiB; larger files need an upload session.
+	graphSimpleUploadLimit = 4 << 20
+	// Session chunks must be multiples of 320 KiB.
+	graphChunkSize = 16 * 320 << 10
+)
+
+// uploadSharePoint copies each artifact into the configured drive folder through
+// Microsoft Graph using the client-credentials flow.
+func uploadSharePoint(client *httpretry.Client, cfg config.SharePointConfig, artifacts []string) error {
+	token, err := graphToken(client, cfg)
+	if err != nil {
+		return err
+	}
+	for _, artifact := range artifacts {
+		if err := graphUpload(client, cfg, token, artifact); err != nil {
+			return fmt.Errorf("%s: %v", filepath.Base(artifact), err)
+		}
+	}
+	return nil
+}
+
+func graphToken(client *httpretry.Client, cfg config.SharePointConfig) (string, error) {
+	form := url.Values{
+		"client_id":     {cfg.ClientID},
+		"client_secret": {cfg.ClientSecret},
+		"scope":         {graphScope},
+		"grant_type":    {"client_credentials"},
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf(graphTokenURL, url.PathEscape(cfg.TenantID)), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error_description"`
+	}
+	err = client.Do(req, func(resp *http.Response) error {
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return fmt.Errorf("token response: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+			return fmt.Errorf("token request failed (%s): %s", resp.Status, body.Error)
+		}
+		return nil
+	})
+	return body.AccessToken, err
+}
+
+// graphItemURL addresses a drive item by path: /drives/{id}/root:/{folder}/{name}:
+func graphItemURL(cfg config.SharePointConfig, name string) string {
+	itemPath := strings.Trim(path.Join(cfg.Folder, name), "/")
+	segments := strings.Split(itemPath, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return fmt.Sprintf("%s/drives/%s/root:/%s:", graphBaseURL, url.PathEscape(cfg.DriveID), strings.Join(segments, "/"))
+}
+
+func graphUpload(client *httpretry.Client, cfg config.SharePointConfig, token, artifact string) error {
+	data, err := os.ReadFile(artifact)
+	if err != nil {
+		return err
+	}
+	itemURL := graphItemURL(cfg, filepath.Base(artifact))
+
+	if len(data) <= graphSimpleUploadLimit {
+		req, err := http.NewRequest(http.MethodPut, itemURL+"/content", bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/octet-stream")
+		return doExpect(client, req, http.StatusOK, http.StatusCreated)
+	}
+
+	sessionURL, err := graphUploadSession(client, itemURL, token)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(data); start += graphChunkSize {
+		end := min(start+graphChunkSize, len(data))
+		req, err := http.NewRequest(http.MethodPut, sessionURL, bytes.NewReader(data[start:end]))
+		if err != nil {
+			return err
+		}
+		// The pre-authenticated session URL must not receive the bearer token.
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, len(data)))
+		if err := doExpect(client, req, http.StatusAccepted, http.StatusOK, http.StatusCreated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func graphUploadSession(client *httpretry.Client, itemURL, token string) (string, error) {
+	payload := []byte(`{"item":{"@microsoft.graph.conflictBehavior":"replace"}}`)
+	req, err := http.NewRequest(http.MethodPost, itemURL+"/createUploadSession", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	var body struct {
+		UploadURL string `json:"uploadUrl"`
+	}
+	err = client.Do(req, func(resp *http.Response) error {
+		if resp.StatusCode != http.StatusOK {
+			return responseError(resp)
+		}
+		return json.NewDecoder(resp.Body).Decode(&body)
+	})
+	return body.UploadURL, err
+}
+
+// doExpect sends req and turns any status outside want into an error carrying the body.
+func doExpect(client *httpretry.Client, req *http.Request, want ...int) error {
+	return client.Do(req, func(resp *http.Response) error {
+		for _, code := range want {
+			if resp.StatusCode == code {
+				io.Copy(io.Discard, resp.Body)
+				return nil
+			}
+		}
+		return responseError(resp)
+	})
+}
+
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	msg := strings.TrimSpace(string(body))
+	if msg == "" {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return fmt.Errorf("unexpected status %s: %s", resp.Status, msg)
+}
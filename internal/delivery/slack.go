@@ -0,0 +1,72 @@
+package delivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/httpretry"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+// slackTopAuthors caps how many authors are named in the summary.
+const slackTopAuthors = 5
+
+func notifySlack(client *httpretry.Client, cfg config.SlackConfig, report Report, destinations []string) error {
+	payload := map[string]string{"text": slackSummary(cfg, report, destinations)}
+	if cfg.Channel != "" {
+		payload["channel"] = cfg.Channel
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return doExpect(client, req, http.StatusOK)
+}
+
+func slackSummary(cfg config.SlackConfig, report Report, destinations []string) string {
+	authors := utils.CountByAuthor(report.Commits)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*gommits report for %s*", report.RepoName)
+	if report.Branch != "" {
+		fmt.Fprintf(&b, " (`%s`)", report.Branch)
+	}
+	fmt.Fprintf(&b, "\n• %d commits by %d authors", len(report.Commits), len(authors))
+
+	if len(authors) > 0 {
+		top := make([]string, 0, slackTopAuthors)
+		for i, a := range authors {
+			if i == slackTopAuthors {
+				break
+			}
+			top = append(top, fmt.Sprintf("%s (%d)", a.Author, a.Commits))
+		}
+		b.WriteString("\n• Top authors: " + strings.Join(top, ", "))
+	}
+
+	if len(report.Artifacts) > 0 {
+		names := make([]string, len(report.Artifacts))
+		for i, a := range report.Artifacts {
+			names[i] = filepath.Base(a)
+		}
+		b.WriteString("\n• Files: " + strings.Join(names, ", "))
+	}
+	if len(destinations) > 0 {
+		b.WriteString("\n• Uploaded to " + strings.Join(destinations, ", "))
+	}
+	if cfg.LinkURL != "" {
+		fmt.Fprintf(&b, "\n<%s|Open report>", cfg.LinkURL)
+	}
+	return b.String()
+}
@@ -0,0 +1,45 @@
+package delivery
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/httpretry"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+// SignatureHeader carries "sha256=<hex HMAC of the body>" when a secret is configured.
+const SignatureHeader = "X-Gommits-Signature"
+
+// postWebhook sends the JSON export (see utils.JSONSchema) to the configured URL.
+func postWebhook(client *httpretry.Client, cfg config.WebhookConfig, report Report) error {
+	body, err := utils.EncodeJSONExport(report.Commits, report.RepoName)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range cfg.Headers {
+		req.Header.Set(name, value)
+	}
+	if cfg.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(cfg.Secret))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	return client.Do(req, func(resp *http.Response) error {
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		return responseError(resp)
+	})
+}
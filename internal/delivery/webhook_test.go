@@ -0,0 +1,39 @@
+package delivery
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/httpretry"
+)
+
+func TestPostWebhookRetries(t *testing.T) {
+	var signatures []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signatures = append(signatures, r.Header.Get(SignatureHeader))
+		if len(signatures) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := config.WebhookConfig{URL: srv.URL, Secret: "s3cret"}
+	report := Report{RepoName: "repo"}
+
+	if err := postWebhook(httpretry.New(srv.Client(), 0), cfg, report); err == nil {
+		t.Fatal("want the 503 reported when retries are off")
+	}
+	if len(signatures) != 1 {
+		t.Fatalf("got %d requests with retries off, want 1", len(signatures))
+	}
+
+	signatures = nil
+	if err := postWebhook(httpretry.New(srv.Client(), 1), cfg, report); err != nil {
+		t.Fatal(err)
+	}
+	if len(signatures) != 2 || signatures[0] != signatures[1] || signatures[0] == "" {
+		t.Errorf("signatures = %q, want the same one on both attempts", signatures)
+	}
+}
@@ -0,0 +1,140 @@
+package forge
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// azureBatchSize is the most ids the work items endpoint accepts per request.
+const azureBatchSize = 200
+
+var azureRefPattern = regexp.MustCompile(`(?i)\bAB#(\d+)\b`)
+
+// AzureWorkItemIDs returns the distinct AB#1234 references in message, in order.
+func AzureWorkItemIDs(message string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, m := range azureRefPattern.FindAllStringSubmatch(message, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			ids = append(ids, m[1])
+		}
+	}
+	return ids
+}
+
+// azureBoardsBase resolves the collection URL from config or an Azure Repos remote:
+// https://dev.azure.com/org/..., git@ssh.dev.azure.com:v3/org/... or https://org.visualstudio.com/...
+func azureBoardsBase(cfg config.AzureBoardsConfig, remoteURL string) string {
+	if cfg.BaseURL != "" {
+		return strings.TrimSuffix(cfg.BaseURL, "/")
+	}
+	org := cfg.Organization
+	if org == "" {
+		if r, err := ParseRemote(remoteURL); err == nil {
+			segments := strings.Split(r.Owner, "/")
+			switch {
+			case r.Host == "dev.azure.com":
+				org = segments[0]
+			case r.Host == "ssh.dev.azure.com" && len(segments) > 1:
+				org = segments[1]
+			case strings.HasSuffix(r.Host, ".visualstudio.com"):
+				org = strings.TrimSuffix(r.Host, ".visualstudio.com")
+			}
+		}
+	}
+	if org == "" {
+		return ""
+	}
+	return "https://dev.azure.com/" + url.PathEscape(org)
+}
+
+// enrichAzureBoards records AB# references on each commit and, when enabled, looks
+// up their titles and states in batches.
+func enrichAzureBoards(api *apiClient, cfg config.AzureBoardsConfig, remoteURL string, commits []models.CommitInfo) error {
+	base := azureBoardsBase(cfg, remoteURL)
+
+	var ids []string
+	seen := make(map[string]bool)
+	for i := range commits {
+		commits[i].WorkItems = nil
+		for _, id := range AzureWorkItemIDs(commits[i].Message) {
+			item := models.WorkItem{ID: id}
+			if base != "" {
+				item.URL = base + "/_workitems/edit/" + id
+			}
+			commits[i].WorkItems = append(commits[i].WorkItems, item)
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if !cfg.Enrich || len(ids) == 0 {
+		return nil
+	}
+	if base == "" {
+		return fmt.Errorf("Azure Boards enrichment: set integrations.azureBoards.organization")
+	}
+
+	details := make(map[string]models.WorkItem, len(ids))
+	for start := 0; start < len(ids); start += azureBatchSize {
+		batch := ids[start:min(start+azureBatchSize, len(ids))]
+		if err := fetchAzureWorkItems(api, cfg, base, batch, details); err != nil {
+			return fmt.Errorf("Azure Boards enrichment: %v", err)
+		}
+	}
+	for i := range commits {
+		for j, item := range commits[i].WorkItems {
+			if d, ok := details[item.ID]; ok {
+				commits[i].WorkItems[j].Title = d.Title
+				commits[i].WorkItems[j].State = d.State
+			}
+		}
+	}
+	return nil
+}
+
+func fetchAzureWorkItems(api *apiClient, cfg config.AzureBoardsConfig, base string, ids []string, into map[string]models.WorkItem) error {
+	query := url.Values{
+		"ids":         {strings.Join(ids, ",")},
+		"fields":      {"System.Title,System.State"},
+		"errorPolicy": {"omit"},
+		"api-version": {"7.0"},
+	}
+	req, err := http.NewRequest(http.MethodGet, base+"/_apis/wit/workitems?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if cfg.Token != "" {
+		req.SetBasicAuth("", cfg.Token)
+	}
+
+	var out struct {
+		Value []*struct {
+			ID     int `json:"id"`
+			Fields struct {
+				Title string `json:"System.Title"`
+				State string `json:"System.State"`
+			} `json:"fields"`
+		} `json:"value"`
+	}
+	if err := api.doJSON(req, &out); err != nil {
+		return err
+	}
+	// With errorPolicy=omit, deleted or inaccessible items come back as null.
+	for _, v := range out.Value {
+		if v != nil {
+			into[strconv.Itoa(v.ID)] = models.WorkItem{Title: v.Fields.Title, State: v.Fields.State}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package forge
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+const (
+	bitbucketCloudHost = "bitbucket.org"
+	bitbucketCloudAPI  = "https://api.bitbucket.org/2.0"
+)
+
+// bitbucket serves both Bitbucket Cloud and Bitbucket Server / Data Center, whose
+// REST APIs differ in paths and payloads but map onto the same pull request data.
+type bitbucket struct {
+	cfg    config.BitbucketConfig
+	server bool
+	base   string // web root for Server; unused for Cloud
+	owner  string // Cloud workspace or Server project key
+	repo   string
+	client *apiClient
+}
+
+func newBitbucket(cfg config.BitbucketConfig, r Remote, client *apiClient) *bitbucket {
+	switch {
+	case r.Host == bitbucketCloudHost:
+		return &bitbucket{cfg: cfg, owner: r.Owner, repo: r.Repo, client: client}
+	case cfg.Host != "" && r.Host == strings.ToLower(cfg.Host):
+		base := strings.TrimSuffix(cfg.BaseURL, "/")
+		if base == "" {
+			base = "https://" + r.Host
+		}
+		// HTTP clone URLs look like /scm/PROJ/repo.git (possibly under a context
+		// path); SSH ones like /PROJ/repo.git. The project key is the last segment.
+		return &bitbucket{cfg: cfg, server: true, base: base, owner: path.Base(r.Owner), repo: r.Repo, client: client}
+	}
+	return nil
+}
+
+func (b *bitbucket) Enabled() bool {
+	return b.cfg.Enrich
+}
+
+func (b *bitbucket) CommitURL(hash string) string {
+	if b.server {
+		return fmt.Sprintf("%s/projects/%s/repos/%s/commits/%s", b.base, b.owner, b.repo, hash)
+	}
+	return fmt.Sprintf("https://%s/%s/%s/commits/%s", bitbucketCloudHost, b.owner, b.repo, hash)
+}
+
+func (b *bitbucket) Enrich(commits []models.CommitInfo) error {
+	lookup := b.cloudPullRequest
+	if b.server {
+		lookup = b.serverPullRequest
+	}
+	if err := enrichAll(commits, lookup); err != nil {
+		return fmt.Errorf("Bitbucket enrichment: %v", err)
+	}
+	return nil
+}
+
+func (b *bitbucket) cloudPullRequest(hash string) (*models.PullRequest, error) {
+	repoURL := fmt.Sprintf("%s/repositories/%s/%s", bitbucketCloudAPI, b.owner, b.repo)
+
+	var pulls struct {
+		Values []struct {
+			ID    int    `json:"id"`
+			Title string `json:"title"`
+			State string `json:"state"`
+			Draft bool   `json:"draft"`
+			Links struct {
+				HTML struct {
+					Href string `json:"href"`
+				} `json:"html"`
+			} `json:"links"`
+		} `json:"values"`
+	}
+	if err := b.get(repoURL+"/commit/"+hash+"/pullrequests", &pulls); err != nil {
+		return nil, err
+	}
+	if len(pulls.Values) == 0 {
+		return nil, nil
+	}
+	pull := pulls.Values[0]
+	for _, p := range pulls.Values {
+		if p.State == "MERGED" {
+			pull = p
+			break
+		}
+	}
+	pr := &models.PullRequest{
+		Number: pull.ID,
+		Title:  pull.Title,
+		URL:    pull.Links.HTML.Href,
+		State:  bitbucketState(pull.State, pull.Draft),
+	}
+
+	var detail struct {
+		Participants []struct {
+			Role     string `json:"role"`
+			Approved bool   `json:"approved"`
+			State    string `json:"state"`
+		} `json:"participants"`
+	}
+	if err := b.get(fmt.Sprintf("%s/pullrequests/%d", repoURL, pull.ID), &detail); err != nil {
+		return nil, err
+	}
+	for _, p := range detail.Participants {
+		switch {
+		case p.State == "changes_requested":
+			pr.Review = "changes_requested"
+		case p.Approved && pr.Review == "":
+			pr.Review = "approved"
+		}
+	}
+
+	var statuses struct {
+		Values []struct {
+			State string `json:"state"`
+		} `json:"values"`
+	}
+	if err := b.get(repoURL+"/commit/"+hash+"/statuses", &statuses); err != nil {
+		return nil, err
+	}
+	for _, s := range statuses.Values {
+		pr.Checks = mergeBuildState(pr.Checks, s.State)
+	}
+	return pr, nil
+}
+
+func (b *bitbucket) serverPullRequest(hash string) (*models.PullRequest, error) {
+	var pulls struct {
+		Values []struct {
+			ID        int    `json:"id"`
+			Title     string `json:"title"`
+			State     string `json:"state"`
+			Draft     bool   `json:"draft"`
+			Reviewers []struct {
+				Status string `json:"status"`
+			} `json:"reviewers"`
+			Links struct {
+				Self []struct {
+					Href string `json:"href"`
+				} `json:"self"`
+			} `json:"links"`
+		} `json:"values"`
+	}
+	repoURL := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s", b.base, b.owner, b.repo)
+	if err := b.get(repoURL+"/commits/"+hash+"/pull-requests", &pulls); err != nil {
+		return nil, err
+	}
+	if len(pulls.Values) == 0 {
+		return nil, nil
+	}
+	pull := pulls.Values[0]
+	for _, p := range pulls.Values {
+		if p.State == "MERGED" {
+			pull = p
+			break
+		}
+	}
+	pr := &models.PullRequest{
+		Number: pull.ID,
+		Title:  pull.Title,
+		State:  bitbucketState(pull.State, pull.Draft),
+	}
+	if len(pull.Links.Self) > 0 {
+		pr.URL = pull.Links.Self[0].Href
+	}
+	for _, r := range pull.Reviewers {
+		switch {
+		case r.Status == "NEEDS_WORK":
+			pr.Review = "changes_requested"
+		case r.Status == "APPROVED" && pr.Review == "":
+			pr.Review = "approved"
+		}
+	}
+
+	var statuses struct {
+		Values []struct {
+			State string `json:"state"`
+		} `json:"values"`
+	}
+	if err := b.get(b.base+"/rest/build-status/1.0/commits/"+hash, &statuses); err != nil {
+		return nil, err
+	}
+	for _, s := range statuses.Values {
+		pr.Checks = mergeBuildState(pr.Checks, s.State)
+	}
+	return pr, nil
+}
+
+func bitbucketState(state string, draft bool) string {
+	switch {
+	case state == "MERGED":
+		return "merged"
+	case draft:
+		return "draft"
+	case state == "OPEN":
+		return "open"
+	}
+	return "closed"
+}
+
+// mergeBuildState folds one Bitbucket build state into the overall checks status:
+// any failure wins, then anything still running.
+func mergeBuildState(current, state string) string {
+	switch state {
+	case "FAILED", "STOPPED":
+		return "failure"
+	case "INPROGRESS":
+		if current != "failure" {
+			return "pending"
+		}
+	case "SUCCESSFUL":
+		if current == "" {
+			return "success"
+		}
+	}
+	return current
+}
+
+func (b *bitbucket) get(url string, out any) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case b.cfg.Username != "":
+		req.SetBasicAuth(b.cfg.Username, b.cfg.Token)
+	case b.cfg.Token != "":
+		req.Header.Set("Authorization", "Bearer "+b.cfg.Token)
+	}
+	return b.client.doJSON(req, out)
+}
@@ -0,0 +1,114 @@
+package forge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// Provider knows how to link to and look up commits on one code host.
+type Provider interface {
+	// CommitURL returns the web page of a commit.
+	CommitURL(hash string) string
+	// Enrich fills in the pull request of each commit in place.
+	Enrich(commits []models.CommitInfo) error
+	// Enabled reports whether API lookups were configured for this host.
+	Enabled() bool
+}
+
+// enrichWorkers bounds the concurrent API requests made while enriching.
+const enrichWorkers = 4
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// enrichAll runs lookup for every commit on a small worker pool and stores the results
+// in place, returning the first error.
+func enrichAll(commits []models.CommitInfo, lookup func(hash string) (*models.PullRequest, error)) error {
+	jobs := make(chan int)
+	errs := make([]error, len(commits))
+	var wg sync.WaitGroup
+	for range enrichWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				commits[i].PullRequest, errs[i] = lookup(commits[i].Hash)
+			}
+		}()
+	}
+	for i := range commits {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeJSON(req *http.Request, resp *http.Response, out any) error {
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return json.NewDecoder(resp.Body).Decode(out)
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		return fmt.Errorf("rate limit exceeded; configure a token")
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden, resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("GET %s: %s (private repositories need a token with read access)", req.URL.Path, resp.Status)
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("GET %s: %s: %s", req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+}
+
+// Detect returns the provider serving remoteURL, or nil for unknown hosts.
+func Detect(cfg config.IntegrationsConfig, remoteURL string) Provider {
+	r, err := ParseRemote(remoteURL)
+	if err != nil {
+		return nil
+	}
+	api := newAPIClient(config.RetryCount(cfg.Retries))
+	if gh := newGitHub(cfg.GitHub, r, api); gh != nil {
+		return gh
+	}
+	if bb := newBitbucket(cfg.Bitbucket, r, api); bb != nil {
+		return bb
+	}
+	if gl := newGitLab(r); gl != nil {
+		return gl
+	}
+	return nil
+}
+
+// Enrich returns a copy of commits with web links, tracker references and, for the
+// integrations that are enabled, pull request and work item details. Commits are
+// returned even when a lookup fails.
+func Enrich(cfg config.IntegrationsConfig, remoteURL string, commits []models.CommitInfo) ([]models.CommitInfo, error) {
+	enriched := make([]models.CommitInfo, len(commits))
+	copy(enriched, commits)
+
+	var errs []error
+	if p := Detect(cfg, remoteURL); p != nil {
+		for i := range enriched {
+			enriched[i].URL = p.CommitURL(enriched[i].Hash)
+		}
+		if p.Enabled() {
+			errs = append(errs, p.Enrich(enriched))
+		}
+	}
+	api := newAPIClient(config.RetryCount(cfg.Retries))
+	errs = append(errs, enrichAzureBoards(api, cfg.AzureBoards, remoteURL, enriched))
+	errs = append(errs, enrichJira(api, cfg.Jira, enriched))
+	return enriched, errors.Join(errs...)
+}
@@ -0,0 +1,201 @@
+package forge
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+const githubAPI = "https://api.github.com"
+
+type gitHub struct {
+	cfg    config.GitHubConfig
+	remote Remote
+	client *apiClient
+	api    string
+	web    string
+
+	mu      sync.Mutex
+	reviews map[int]string // review state cached per PR number
+}
+
+func newGitHub(cfg config.GitHubConfig, r Remote, client *apiClient) *gitHub {
+	api := githubAPI
+	switch {
+	case r.Host == "github.com":
+	case cfg.Host != "" && r.Host == strings.ToLower(cfg.Host):
+		api = strings.TrimSuffix(cfg.APIURL, "/")
+		if api == "" {
+			api = "https://" + r.Host + "/api/v3"
+		}
+	default:
+		return nil
+	}
+	return &gitHub{
+		cfg:     cfg,
+		remote:  r,
+		client:  client,
+		api:     api,
+		web:     "https://" + r.Host + "/" + r.Owner + "/" + r.Repo,
+		reviews: make(map[int]string),
+	}
+}
+
+func (g *gitHub) Enabled() bool {
+	return g.cfg.Enrich
+}
+
+func (g *gitHub) CommitURL(hash string) string {
+	return g.web + "/commit/" + hash
+}
+
+func (g *gitHub) Enrich(commits []models.CommitInfo) error {
+	if err := enrichAll(commits, g.pullRequest); err != nil {
+		return fmt.Errorf("GitHub enrichment: %v", err)
+	}
+	return nil
+}
+
+type githubPull struct {
+	Number   int     `json:"number"`
+	Title    string  `json:"title"`
+	HTMLURL  string  `json:"html_url"`
+	State    string  `json:"state"`
+	Draft    bool    `json:"draft"`
+	MergedAt *string `json:"merged_at"`
+}
+
+// pullRequest finds the PR that introduced hash, preferring a merged one.
+func (g *gitHub) pullRequest(hash string) (*models.PullRequest, error) {
+	var pulls []githubPull
+	if err := g.get(g.repoPath("/commits/"+hash+"/pulls"), &pulls); err != nil {
+		return nil, err
+	}
+	if len(pulls) == 0 {
+		return nil, nil
+	}
+	pull := pulls[0]
+	for _, p := range pulls {
+		if p.MergedAt != nil {
+			pull = p
+			break
+		}
+	}
+
+	pr := &models.PullRequest{
+		Number: pull.Number,
+		Title:  pull.Title,
+		URL:    pull.HTMLURL,
+		State:  pull.State,
+	}
+	switch {
+	case pull.MergedAt != nil:
+		pr.State = "merged"
+	case pull.Draft:
+		pr.State = "draft"
+	}
+
+	var err error
+	if pr.Review, err = g.reviewState(pull.Number); err != nil {
+		return nil, err
+	}
+	if pr.Checks, err = g.checksStatus(hash); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// reviewState reduces each reviewer's latest decisive review to one state.
+func (g *gitHub) reviewState(number int) (string, error) {
+	g.mu.Lock()
+	state, ok := g.reviews[number]
+	g.mu.Unlock()
+	if ok {
+		return state, nil
+	}
+
+	var reviews []struct {
+		User  struct{ Login string } `json:"user"`
+		State string                 `json:"state"`
+	}
+	if err := g.get(g.repoPath(fmt.Sprintf("/pulls/%d/reviews?per_page=100", number)), &reviews); err != nil {
+		return "", err
+	}
+
+	latest := make(map[string]string)
+	commented := false
+	for _, r := range reviews {
+		switch r.State {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			latest[r.User.Login] = r.State
+		case "COMMENTED":
+			commented = true
+		}
+	}
+	state = ""
+	if commented {
+		state = "commented"
+	}
+	for _, s := range latest {
+		if s == "CHANGES_REQUESTED" {
+			state = "changes_requested"
+			break
+		}
+		if s == "APPROVED" {
+			state = "approved"
+		}
+	}
+
+	g.mu.Lock()
+	g.reviews[number] = state
+	g.mu.Unlock()
+	return state, nil
+}
+
+func (g *gitHub) checksStatus(hash string) (string, error) {
+	var runs struct {
+		CheckRuns []struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	if err := g.get(g.repoPath("/commits/"+hash+"/check-runs?per_page=100"), &runs); err != nil {
+		return "", err
+	}
+	if len(runs.CheckRuns) == 0 {
+		return "", nil
+	}
+	status := "success"
+	for _, r := range runs.CheckRuns {
+		switch {
+		case r.Status != "completed":
+			if status == "success" {
+				status = "pending"
+			}
+		case r.Conclusion == "failure", r.Conclusion == "timed_out", r.Conclusion == "cancelled", r.Conclusion == "action_required":
+			return "failure", nil
+		}
+	}
+	return status, nil
+}
+
+func (g *gitHub) repoPath(suffix string) string {
+	return "/repos/" + g.remote.Owner + "/" + g.remote.Repo + suffix
+}
+
+func (g *gitHub) get(path string, out any) error {
+	req, err := http.NewRequest(http.MethodGet, g.api+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if g.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.cfg.Token)
+	}
+	return g.client.doJSON(req, out)
+}
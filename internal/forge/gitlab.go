@@ -0,0 +1,32 @@
+package forge
+
+import (
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// gitLab only links commits: gitlab.com and self-hosted instances whose host name
+// contains "gitlab" are recognized.
+type gitLab struct {
+	web string
+}
+
+func newGitLab(r Remote) *gitLab {
+	if !strings.Contains(r.Host, "gitlab") {
+		return nil
+	}
+	return &gitLab{web: "https://" + r.Host + "/" + r.Owner + "/" + r.Repo}
+}
+
+func (g *gitLab) Enabled() bool {
+	return false
+}
+
+func (g *gitLab) CommitURL(hash string) string {
+	return g.web + "/-/commit/" + hash
+}
+
+func (g *gitLab) Enrich([]models.CommitInfo) error {
+	return nil
+}
@@ -0,0 +1,150 @@
+package forge
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+var jiraKeyPattern = regexp.MustCompile(`\b([A-Z][A-Z0-9_]+)-([1-9][0-9]*)\b`)
+
+// jiraFalsePositives are key-shaped tokens common in commit messages that are not issues.
+var jiraFalsePositives = map[string]bool{
+	"UTF": true, "ISO": true, "SHA": true, "RFC": true, "CVE": true, "GPL": true,
+	"LGPL": true, "HTTP": true, "TLS": true, "SSL": true, "UTC": true, "GMT": true,
+	"PEP": true, "AES": true, "RSA": true, "X509": true,
+}
+
+// JiraIssueKeys returns the distinct issue keys in message, in order. With projects
+// set only keys of those projects are returned.
+func JiraIssueKeys(message string, projects []string) []string {
+	allowed := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		allowed[strings.ToUpper(strings.TrimSpace(p))] = true
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	for _, m := range jiraKeyPattern.FindAllStringSubmatch(message, -1) {
+		project := m[1]
+		if len(allowed) > 0 && !allowed[project] || len(allowed) == 0 && jiraFalsePositives[project] {
+			continue
+		}
+		if !seen[m[0]] {
+			seen[m[0]] = true
+			keys = append(keys, m[0])
+		}
+	}
+	return keys
+}
+
+// enrichJira records issue keys on each commit and, when enabled, fetches each
+// issue's summary and status. Keys Jira does not know are kept without details.
+func enrichJira(api *apiClient, cfg config.JiraConfig, commits []models.CommitInfo) error {
+	base := strings.TrimSuffix(cfg.BaseURL, "/")
+
+	var keys []string
+	seen := make(map[string]bool)
+	for i := range commits {
+		commits[i].Issues = nil
+		for _, key := range JiraIssueKeys(commits[i].Message, cfg.Projects) {
+			issue := models.WorkItem{ID: key}
+			if base != "" {
+				issue.URL = base + "/browse/" + key
+			}
+			commits[i].Issues = append(commits[i].Issues, issue)
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	if !cfg.Enrich || len(keys) == 0 {
+		return nil
+	}
+	if base == "" {
+		return fmt.Errorf("Jira enrichment: set integrations.jira.baseUrl")
+	}
+
+	details := make(map[string]models.WorkItem, len(keys))
+	var mu sync.Mutex
+	var firstErr error
+	var wg sync.WaitGroup
+	jobs := make(chan string)
+	for range enrichWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				issue, found, err := fetchJiraIssue(api, cfg, base, key)
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				if found {
+					details[key] = issue
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, key := range keys {
+		jobs <- key
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return fmt.Errorf("Jira enrichment: %v", firstErr)
+	}
+
+	for i := range commits {
+		for j, issue := range commits[i].Issues {
+			if d, ok := details[issue.ID]; ok {
+				commits[i].Issues[j].Title = d.Title
+				commits[i].Issues[j].State = d.State
+			}
+		}
+	}
+	return nil
+}
+
+func fetchJiraIssue(api *apiClient, cfg config.JiraConfig, base, key string) (models.WorkItem, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, base+"/rest/api/2/issue/"+key+"?fields=summary,status", nil)
+	if err != nil {
+		return models.WorkItem{}, false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case cfg.Email != "":
+		req.SetBasicAuth(cfg.Email, cfg.Token)
+	case cfg.Token != "":
+		req.Header.Set("Authorization", "Bearer "+cfg.Token)
+	}
+
+	var out struct {
+		Fields struct {
+			Summary string `json:"summary"`
+			Status  struct {
+				Name string `json:"name"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	found := true
+	err = api.do(req, func(resp *http.Response) error {
+		// A key that looks right but names no issue (or one we cannot see) is not fatal.
+		if resp.StatusCode == http.StatusNotFound {
+			found = false
+			return nil
+		}
+		return decodeJSON(req, resp, &out)
+	})
+	if err != nil || !found {
+		return models.WorkItem{}, false, err
+	}
+	return models.WorkItem{Title: out.Fields.Summary, State: out.Fields.Status.Name}, true, nil
+}
@@ -0,0 +1,42 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Remote identifies a repository on a code host, parsed from a git remote URL.
+type Remote struct {
+	Host  string
+	Owner string // may contain slashes for hosts with nested groups
+	Repo  string
+}
+
+// ParseRemote understands https://host/owner/repo(.git), ssh://git@host[:port]/owner/repo
+// and scp-like git@host:owner/repo forms.
+func ParseRemote(raw string) (Remote, error) {
+	raw = strings.TrimSpace(raw)
+	var host, path string
+
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return Remote{}, fmt.Errorf("invalid remote URL %q: %v", raw, err)
+		}
+		host, path = u.Hostname(), u.Path
+	} else if at := strings.Index(raw, "@"); at >= 0 && strings.Contains(raw[at:], ":") {
+		hostPath := raw[at+1:]
+		colon := strings.Index(hostPath, ":")
+		host, path = hostPath[:colon], hostPath[colon+1:]
+	} else {
+		return Remote{}, fmt.Errorf("unrecognized remote URL %q", raw)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	slash := strings.LastIndex(path, "/")
+	if host == "" || slash <= 0 || slash == len(path)-1 {
+		return Remote{}, fmt.Errorf("unrecognized remote URL %q", raw)
+	}
+	return Remote{Host: strings.ToLower(host), Owner: path[:slash], Repo: path[slash+1:]}, nil
+}
@@ -0,0 +1,30 @@
+package forge
+
+import (
+	"net/http"
+
+	"github.com/leeozaka/gommits/internal/httpretry"
+)
+
+// apiClient sends the lookups of one enrichment run, retrying the ones that fail on
+// a flaky network.
+type apiClient struct {
+	*httpretry.Client
+}
+
+// newAPIClient retries a failed request retries times; 0 disables retries.
+func newAPIClient(retries int) *apiClient {
+	return &apiClient{httpretry.New(httpClient, retries)}
+}
+
+// do sends req and hands the response to handle. req must not have a body.
+func (c *apiClient) do(req *http.Request, handle func(*http.Response) error) error {
+	return c.Do(req, handle)
+}
+
+// doJSON sends req and decodes a 200 response into out.
+func (c *apiClient) doJSON(req *http.Request, out any) error {
+	return c.do(req, func(resp *http.Response) error {
+		return decodeJSON(req, resp, out)
+	})
+}
@@ -0,0 +1,107 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Errors that CommandError classifies git failures as, for errors.Is.
+var (
+	ErrGitNotInstalled = errors.New("git is not installed")
+	ErrNotARepo        = errors.New("not a git repository")
+	ErrNoSuchRef       = errors.New("no such branch or revision")
+	ErrEmptyRepo       = errors.New("repository has no commits yet")
+)
+
+// stderrKinds maps what git prints to the error it means, in the order to try them:
+// an empty repository also reports HEAD as an unknown revision.
+var stderrKinds = []struct {
+	text string
+	kind error
+}{
+	{"does not have any commits yet", ErrEmptyRepo},
+	{"bad default revision 'HEAD'", ErrEmptyRepo},
+	{"ambiguous argument 'HEAD'", ErrEmptyRepo},
+	{"not a git repository", ErrNotARepo},
+	{"unknown revision", ErrNoSuchRef},
+	{"bad revision", ErrNoSuchRef},
+	{"ambiguous argument", ErrNoSuchRef},
+	{"Needed a single revision", ErrNoSuchRef},
+	{"not a valid object name", ErrNoSuchRef},
+	{"invalid upstream", ErrNoSuchRef},
+	{"Unknown commit", ErrNoSuchRef},
+}
+
+// classify returns which of the errors above a failure is, or nil.
+func classify(stderr string, err error) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return ErrGitNotInstalled
+	}
+	for _, k := range stderrKinds {
+		if strings.Contains(stderr, k.text) {
+			return k.kind
+		}
+	}
+	return nil
+}
+
+// CommandError is a git invocation that failed, with what git printed on stderr.
+type CommandError struct {
+	Args   []string // the git arguments, without -C <path>
+	Stderr string
+	Err    error // usually an *exec.ExitError
+	// Kind is ErrNotARepo, ErrNoSuchRef, ErrGitNotInstalled or ErrEmptyRepo when
+	// the failure is one of those, else nil.
+	Kind error
+}
+
+// NewCommandError describes git args failing with err after printing stderr,
+// classifying the failure into Kind.
+func NewCommandError(args []string, stderr string, err error) *CommandError {
+	e := &CommandError{Args: args, Stderr: strings.TrimSpace(stderr), Err: err}
+	e.Kind = classify(e.Stderr, err)
+	return e
+}
+
+// Error names the git subcommand and the first line git printed, which is where
+// it says what went wrong ("fatal: bad revision 'x'").
+func (e *CommandError) Error() string {
+	msg, _, _ := strings.Cut(e.Stderr, "\n")
+	if msg == "" {
+		msg = e.Err.Error()
+	}
+	if len(e.Args) == 0 {
+		return "git: " + msg
+	}
+	return fmt.Sprintf("git %s: %s", e.Args[0], msg)
+}
+
+func (e *CommandError) Unwrap() []error {
+	if e.Kind != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Err}
+}
+
+// Command is the failed command line, for showing the user.
+func (e *CommandError) Command() string {
+	return "git " + strings.Join(e.Args, " ")
+}
+
+// Advice says what to do about err when it is one of the errors above, and is
+// empty otherwise.
+func Advice(err error) string {
+	switch {
+	case errors.Is(err, ErrGitNotInstalled):
+		return "Install Git and make sure it is on your PATH."
+	case errors.Is(err, ErrNotARepo):
+		return "Choose a directory inside a Git repository."
+	case errors.Is(err, ErrNoSuchRef):
+		return "Check the branch name, or fetch it if it only exists on the remote."
+	case errors.Is(err, ErrEmptyRepo):
+		return "Make a first commit, or choose another repository."
+	}
+	return ""
+}
@@ -0,0 +1,11 @@
+package git
+
+// Exported for the tests in package git_test, which can't be in package git
+// because gittest imports it.
+var (
+	ParseCommits   = parseCommits
+	RevArgs        = revArgs
+	GetCommitRange = getCommitRange
+)
+
+const CommitSeparator = commitSeparator
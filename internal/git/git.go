@@ -1,10 +1,14 @@
 package git
 
 import (
+	"fmt"
 	"net/url"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/leeozaka/gommits/internal/models"
 )
@@ -13,26 +17,41 @@ const (
 	OriginPrefix     = "origin/"
 	DefaultBranchRef = "main"
 	GitDelimiter     = "|"
-	LogFormat        = "%H" + GitDelimiter + "%an" + GitDelimiter + "%ae" + GitDelimiter + "%ad" + GitDelimiter + "%s"
-	LogFieldCount    = 5
+	LogFormat        = "%H" + GitDelimiter + "%an" + GitDelimiter + "%ae" + GitDelimiter + "%ad" + GitDelimiter + "%aI" + GitDelimiter + "%S" + GitDelimiter + signOffFormat + GitDelimiter + "%aN" + GitDelimiter + "%aE" + GitDelimiter + "%s"
+	LogFieldCount    = 10
 	HeadBranchPrefix = "HEAD branch:"
 	commitSeparator  = "---COMMIT_SEP---"
+
+	// signOffFormat prints the Signed-off-by trailer values on one line, joined by signOffSeparator.
+	signOffFormat    = "%(trailers:key=Signed-off-by,valueonly,separator=%x1f)"
+	signOffSeparator = "\x1f"
 )
 
 var defaultBranchCandidates = []string{"main", "master", "trunk", "development", "dev"}
 
-func execGit(path string, args ...string) (string, error) {
-	fullArgs := append([]string{"-C", path}, args...)
-	cmd := exec.Command("git", fullArgs...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", err
+// checkRevs rejects revisions git would read as options, such as "--all" or
+// "--output=file", before they reach the command line.
+func checkRevs(revs ...string) error {
+	for _, rev := range revs {
+		if strings.HasPrefix(rev, "-") || strings.ContainsAny(rev, "\x00\n") {
+			return fmt.Errorf("%w %q: names can't start with \"-\"", ErrNoSuchRef, rev)
+		}
 	}
-	return strings.TrimSpace(string(output)), nil
+	return nil
+}
+
+// revArgs lays out git options and revisions so that no revision can be taken for an
+// option and no path for a revision.
+func revArgs(options []string, revs ...string) []string {
+	args := append(options[:len(options):len(options)], "--end-of-options")
+	return append(append(args, revs...), "--")
 }
 
-func refExists(path, ref string) bool {
-	_, err := execGit(path, "rev-parse", "--verify", ref)
+func refExists(r Runner, path, ref string) bool {
+	if checkRevs(ref) != nil {
+		return false
+	}
+	_, err := r.Run(path, "rev-parse", "--verify", "--end-of-options", ref)
 	return err == nil
 }
 
@@ -42,7 +61,35 @@ func IsGitRepo(path string) bool {
 }
 
 func GetCurrentBranch(path string) (string, error) {
-	return execGit(path, "rev-parse", "--abbrev-ref", "HEAD")
+	return currentBranch(CLIRunner{}, path)
+}
+
+func currentBranch(r Runner, path string) (string, error) {
+	return r.Run(path, "rev-parse", "--abbrev-ref", "HEAD")
+}
+
+// RefState fingerprints HEAD and every branch tip; it changes whenever commits land,
+// branches move or history is rewritten.
+func RefState(path string) (string, error) {
+	head, err := execGit(path, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	refs, err := execGit(path, "for-each-ref", "--format=%(objectname) %(refname)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return "", err
+	}
+	return head + "\n" + refs, nil
+}
+
+// RemoteURL returns the fetch URL of the origin remote.
+func RemoteURL(path string) (string, error) {
+	return execGit(path, "remote", "get-url", "origin")
+}
+
+// UserEmail returns the user.email git would commit with in path.
+func UserEmail(path string) (string, error) {
+	return execGit(path, "config", "user.email")
 }
 
 func GetRepositoryName(path string) string {
@@ -72,48 +119,294 @@ func GetRepositoryName(path string) string {
 	return filepath.Base(path)
 }
 
-func GatherCommits(path, authorInput, parentBranch string, currentBranchOnly bool) ([]models.CommitInfo, string, error) {
-	currentBranch, err := GetCurrentBranch(path)
+// GatherOptions select the commits GatherCommits and GatherMissing list.
+type GatherOptions struct {
+	Author       string // git --author pattern; empty matches everyone
+	ParentBranch string
+	// CurrentBranchOnly limits GatherCommits to the commits of the current branch
+	// that ParentBranch lacks; otherwise it lists every branch. GatherMissing ignores it.
+	CurrentBranchOnly bool
+	// OnProgress, when set, is called as git's output is parsed.
+	OnProgress func(Progress)
+}
+
+// Progress is how far a gather has got.
+type Progress struct {
+	Commits int // commits parsed so far
+	Total   int // commits to parse, as counted up front by git rev-list; 0 if unknown
+	Files   int // changed files resolved so far
+}
+
+// GatherCommits lists the commits opts select, returning them with the current branch.
+func GatherCommits(r Runner, path string, opts GatherOptions) ([]models.CommitInfo, string, error) {
+	if err := checkRevs(opts.ParentBranch); err != nil {
+		return nil, "", err
+	}
+	branch, err := currentBranch(r, path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var options, revs []string
+	if opts.Author != "" {
+		options = append(options, "--author="+opts.Author)
+	}
+
+	if opts.CurrentBranchOnly {
+		revs = append(revs, getCommitRange(r, path, branch, opts.ParentBranch))
+	} else {
+		options = append(options, "--all")
+	}
+
+	commits, err := logCommits(r, path, opts.OnProgress, options, revs...)
 	if err != nil {
 		return nil, "", err
 	}
+	return commits, branch, nil
+}
 
+// GatherMissing returns the commits on opts.ParentBranch that the current branch does
+// not have yet, as in "git log HEAD..parent": what a rebase would bring in.
+func GatherMissing(r Runner, path string, opts GatherOptions) ([]models.CommitInfo, string, error) {
+	if err := checkRevs(opts.ParentBranch); err != nil {
+		return nil, "", err
+	}
+	currentBranch, err := currentBranch(r, path)
+	if err != nil {
+		return nil, "", err
+	}
+	parentBranch := resolveBranch(r, path, opts.ParentBranch)
+
+	var options []string
+	if opts.Author != "" {
+		options = append(options, "--author="+opts.Author)
+	}
+
+	commits, err := logCommits(r, path, opts.OnProgress, options, currentBranch+".."+parentBranch)
+	if err != nil {
+		return nil, "", err
+	}
+	return commits, currentBranch, nil
+}
+
+// resolveBranch falls back to the origin remote's copy of branch when there is no
+// local one.
+func resolveBranch(r Runner, path, branch string) string {
+	if !refExists(r, path, branch) && refExists(r, path, OriginPrefix+branch) {
+		return OriginPrefix + branch
+	}
+	return branch
+}
+
+// Unmerged runs `git cherry upstream head` and returns the commits of head whose
+// change has no equivalent on upstream, cherry-picks included.
+func Unmerged(r Runner, path, upstream, head string) ([]string, error) {
+	if err := checkRevs(upstream, head); err != nil {
+		return nil, err
+	}
+	output, err := r.Run(path, "cherry", "--end-of-options", resolveBranch(r, path, upstream), head)
+	if err != nil {
+		return nil, err
+	}
+	var hashes []string
+	for _, line := range strings.Split(output, "\n") {
+		if hash, ok := strings.CutPrefix(line, "+ "); ok {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes, nil
+}
+
+// GatherRange returns the commits reachable from to but not from from, as in
+// "git log from..to". An empty from takes the whole history of to.
+func GatherRange(r Runner, path, from, to string) ([]models.CommitInfo, error) {
+	if err := checkRevs(from, to); err != nil {
+		return nil, err
+	}
+	rev := to
+	if from != "" {
+		rev = from + ".." + to
+	}
+	return logCommits(r, path, nil, nil, rev)
+}
+
+// logCommits runs git log with the parseable format plus extra options and revisions.
+// onProgress, when not nil, follows git's output as it is read.
+func logCommits(r Runner, path string, onProgress func(Progress), options []string, revs ...string) ([]models.CommitInfo, error) {
 	logFmt := commitSeparator + "\n" + LogFormat
 
 	args := []string{"log",
 		"--pretty=format:" + logFmt,
-		"--name-only",
+		"--numstat",
+		"--source",
 	}
+	args = append(args, revArgs(options, revs...)...)
 
-	if authorInput != "" {
-		args = append(args, "--author="+authorInput)
+	if onProgress != nil {
+		output, err := streamLog(r, path, args, countCommits(r, path, options, revs), onProgress)
+		if err != nil {
+			return nil, err
+		}
+		return parseCommits(output), nil
 	}
 
-	if currentBranchOnly {
-		args = append(args, getCommitRange(path, currentBranch, parentBranch))
-	} else {
-		args = append(args, "--all")
+	output, err := r.Run(path, args...)
+	if err != nil {
+		return nil, err
 	}
+	return parseCommits(output), nil
+}
 
-	output, err := execGit(path, args...)
+// countCommits asks git rev-list how many commits the options and revisions select;
+// 0 when it can't tell.
+func countCommits(r Runner, path string, options, revs []string) int {
+	output, err := r.Run(path, append([]string{"rev-list", "--count"}, revArgs(options, revs...)...)...)
 	if err != nil {
-		return nil, "", err
+		return 0
 	}
+	count, _ := strconv.Atoi(output)
+	return count
+}
 
-	commits := parseCommits(output)
-	return commits, currentBranch, nil
+// streamLog runs the git log in args, reporting each commit separator and numstat
+// line as it is read.
+func streamLog(r Runner, path string, args []string, total int, onProgress func(Progress)) (string, error) {
+	p := Progress{Total: total}
+	onProgress(p)
+
+	var output strings.Builder
+	header := false // the line after a separator is the commit's, not a file's
+	err := r.Stream(path, args, func(line string) {
+		switch {
+		case line == commitSeparator:
+			p.Commits++
+			p.Total = max(total, p.Commits)
+			header = true
+			onProgress(p)
+		case header:
+			header = false
+		case line != "":
+			p.Files++
+			onProgress(p)
+		}
+		output.WriteString(line)
+		output.WriteString("\n")
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output.String()), nil
+}
+
+// BranchCommits returns the non-merge commits reachable from ref by any of authors
+// (everyone when empty) since the given time, each with its PatchID set.
+func BranchCommits(r Runner, path, ref string, authors []string, since time.Time) ([]models.CommitInfo, error) {
+	if err := checkRevs(ref); err != nil {
+		return nil, err
+	}
+	options := []string{"--no-merges"}
+	for _, a := range authors {
+		options = append(options, "--author="+a)
+	}
+	if !since.IsZero() {
+		options = append(options, "--since="+since.Format(time.RFC3339))
+	}
+
+	commits, err := logCommits(r, path, nil, options, ref)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := patchIDs(r, path, revArgs(options, ref)...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range commits {
+		commits[i].PatchID = ids[commits[i].Hash]
+	}
+	return commits, nil
+}
+
+// patchIDs pipes the patches of a git log through `git patch-id --stable` and maps
+// each commit hash to its patch ID. Commits without a diff are missing from the map.
+func patchIDs(r Runner, path string, logArgs ...string) (map[string]string, error) {
+	logArgs = append([]string{"log", "-p", "--no-color", "--no-ext-diff"}, logArgs...)
+	output, err := r.Pipe(path, logArgs, []string{"patch-id", "--stable"})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		if patchID, hash, ok := strings.Cut(line, " "); ok {
+			ids[hash] = patchID
+		}
+	}
+	return ids, nil
+}
+
+// ListTags returns the repository's tags, newest first by creation date.
+func ListTags(path string) ([]string, error) {
+	output, err := execGit(path, "tag", "--list", "--sort=-creatordate")
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// Branches lists the local branches, then the remote-tracking ones, by name.
+func Branches(path string) ([]string, error) {
+	output, err := execGit(path, "for-each-ref", "--format=%(refname:short)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []string
+	for _, b := range strings.Split(output, "\n") {
+		// origin/HEAD only points at another remote branch.
+		if b == "" || strings.HasSuffix(b, "/HEAD") {
+			continue
+		}
+		branches = append(branches, b)
+	}
+	return branches, nil
 }
 
-func getCommitRange(path, currentBranch, parentBranch string) string {
-	if !refExists(path, parentBranch) {
-		if refExists(path, OriginPrefix+parentBranch) {
+// Contributors lists everyone who authored a commit on any branch, most commits first.
+func Contributors(path string) ([]models.Contributor, error) {
+	output, err := execGit(path, "shortlog", "-sne", "--all")
+	if err != nil {
+		return nil, err
+	}
+
+	var contributors []models.Contributor
+	for _, line := range strings.Split(output, "\n") {
+		count, author, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			continue
+		}
+		c := models.Contributor{Name: author}
+		c.Commits, _ = strconv.Atoi(count)
+		if open := strings.LastIndex(author, " <"); open >= 0 && strings.HasSuffix(author, ">") {
+			c.Name, c.Email = author[:open], author[open+2:len(author)-1]
+		}
+		contributors = append(contributors, c)
+	}
+	return contributors, nil
+}
+
+func getCommitRange(r Runner, path, currentBranch, parentBranch string) string {
+	if !refExists(r, path, parentBranch) {
+		if refExists(r, path, OriginPrefix+parentBranch) {
 			parentBranch = OriginPrefix + parentBranch
 		} else {
 			return currentBranch
 		}
 	}
 
-	mergeBase, err := execGit(path, "merge-base", currentBranch, parentBranch)
+	mergeBase, err := r.Run(path, "merge-base", "--end-of-options", currentBranch, parentBranch)
 	if err != nil {
 		return currentBranch
 	}
@@ -146,30 +439,106 @@ func parseCommits(output string) []models.CommitInfo {
 		}
 
 		var files []string
+		var changes []models.FileChange
+		var additions, deletions int
 		if len(lines) > 1 {
-			for _, f := range strings.Split(strings.TrimSpace(lines[1]), "\n") {
-				f = strings.TrimSpace(f)
-				if f != "" {
-					files = append(files, f)
+			for _, line := range strings.Split(strings.TrimSpace(lines[1]), "\n") {
+				added, deleted, f, ok := parseNumstatLine(line)
+				if !ok {
+					continue
 				}
+				additions += added
+				deletions += deleted
+				files = append(files, f)
+				changes = append(changes, models.FileChange{Path: f, Additions: added, Deletions: deleted})
 			}
 		}
 
+		when, _ := time.Parse(time.RFC3339, parts[4])
+
 		results = append(results, models.CommitInfo{
-			Hash:    parts[0],
-			Author:  parts[1],
-			Email:   parts[2],
-			Date:    parts[3],
-			Message: parts[4],
-			Files:   files,
+			Hash:      parts[0],
+			Author:    parts[1],
+			Email:     parts[2],
+			Date:      parts[3],
+			When:      when,
+			Branch:    shortRefName(parts[5]),
+			Message:   parts[9],
+			Files:     files,
+			Additions: additions,
+			Deletions: deletions,
+
+			FileChanges:     changes,
+			SignedOff:       splitSignOffs(parts[6]),
+			CanonicalAuthor: parts[7],
+			CanonicalEmail:  parts[8],
 		})
 	}
 
 	return results
 }
 
+func splitSignOffs(field string) []string {
+	var signOffs []string
+	for _, s := range strings.Split(field, signOffSeparator) {
+		if s = strings.TrimSpace(s); s != "" {
+			signOffs = append(signOffs, s)
+		}
+	}
+	return signOffs
+}
+
+// shortRefName trims the refs/heads/, refs/remotes/ or refs/tags/ prefix that --source
+// reports when commits are reached through --all.
+func shortRefName(ref string) string {
+	for _, prefix := range []string{"refs/heads/", "refs/remotes/", "refs/tags/"} {
+		if name, ok := strings.CutPrefix(ref, prefix); ok {
+			return name
+		}
+	}
+	return ref
+}
+
+// parseNumstatLine splits a "--numstat" line ("<added>\t<deleted>\t<path>").
+// Binary files report "-" for both counts and are counted as zero.
+func parseNumstatLine(line string) (added, deleted int, path string, ok bool) {
+	parts := strings.SplitN(strings.TrimSpace(line), "\t", 3)
+	if len(parts) < 3 {
+		return 0, 0, "", false
+	}
+	path = numstatPath(strings.TrimSpace(parts[2]))
+	if path == "" {
+		return 0, 0, "", false
+	}
+	added, _ = strconv.Atoi(parts[0])
+	deleted, _ = strconv.Atoi(parts[1])
+	return added, deleted, path, true
+}
+
+// numstatPath resolves rename notation ("old => new" or "dir/{old => new}/file")
+// to the destination path, matching what --name-only would have printed.
+func numstatPath(p string) string {
+	open := strings.Index(p, "{")
+	arrow := strings.Index(p, " => ")
+	if arrow == -1 {
+		return p
+	}
+	if open != -1 && open < arrow {
+		if end := strings.Index(p[arrow:], "}"); end != -1 {
+			end += arrow
+			newPart := p[arrow+len(" => ") : end]
+			joined := p[:open] + newPart + p[end+1:]
+			return strings.ReplaceAll(joined, "//", "/")
+		}
+	}
+	return p[arrow+len(" => "):]
+}
+
 func GetChangedFiles(path, commitHash string) ([]string, error) {
-	output, err := execGit(path, "show", "--name-only", "--pretty=", commitHash)
+	if err := checkRevs(commitHash); err != nil {
+		return nil, err
+	}
+	output, err := execGit(path, "show", "--name-only", "--pretty=", "--end-of-options", commitHash, "--")
 	if err != nil {
 		return nil, err
 	}
@@ -181,17 +550,35 @@ func GetChangedFiles(path, commitHash string) ([]string, error) {
 	return strings.Split(output, "\n"), nil
 }
 
-func DetectDefaultBranch(path string) string {
+// ShowPatch returns the commit header, diffstat and patch of commitHash, without color.
+func ShowPatch(path, commitHash string) (string, error) {
+	if err := checkRevs(commitHash); err != nil {
+		return "", err
+	}
+	return execGit(path, "show", "--stat", "--patch", "--no-color", "--format=fuller", "--end-of-options", commitHash, "--")
+}
+
+// PagerCommand runs git show for commitHash attached to the terminal, so git pages and
+// colors the output itself. A non-empty pager takes precedence over git's configuration.
+func PagerCommand(path, commitHash, pager string) *exec.Cmd {
+	cmd := exec.Command("git", "-C", path, "show", "--end-of-options", commitHash, "--")
+	if pager != "" {
+		cmd.Env = append(os.Environ(), "GIT_PAGER="+pager)
+	}
+	return cmd
+}
+
+func DetectDefaultBranch(r Runner, path string) string {
 	for _, branch := range defaultBranchCandidates {
-		if refExists(path, branch) {
+		if refExists(r, path, branch) {
 			return branch
 		}
-		if refExists(path, OriginPrefix+branch) {
+		if refExists(r, path, OriginPrefix+branch) {
 			return OriginPrefix + branch
 		}
 	}
 
-	if output, err := execGit(path, "remote", "show", "origin"); err == nil {
+	if output, err := r.Run(path, "remote", "show", "origin"); err == nil {
 		for line := range strings.SplitSeq(output, "\n") {
 			line = strings.TrimSpace(line)
 			if strings.HasPrefix(line, HeadBranchPrefix) {
@@ -202,7 +589,7 @@ func DetectDefaultBranch(path string) string {
 		}
 	}
 
-	if output, err := execGit(path, "branch"); err == nil && output != "" {
+	if output, err := r.Run(path, "branch"); err == nil && output != "" {
 		if lines := strings.Split(output, "\n"); len(lines) > 0 {
 			if branch := strings.TrimSpace(strings.TrimPrefix(lines[0], "*")); branch != "" {
 				return branch
@@ -215,6 +602,9 @@ func DetectDefaultBranch(path string) string {
 
 func PathExistsInRef(repoPath, ref, targetPath string) bool {
 	targetPath = strings.ReplaceAll(targetPath, "\\", "/")
-	output, err := execGit(repoPath, "cat-file", "-t", ref+":"+targetPath)
+	if checkRevs(ref) != nil {
+		return false
+	}
+	output, err := execGit(repoPath, "cat-file", "-t", "--end-of-options", ref+":"+targetPath)
 	return err == nil && strings.TrimSpace(output) != ""
 }
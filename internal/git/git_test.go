@@ -0,0 +1,329 @@
+package git_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/git/gittest"
+)
+
+const repo = "/repo"
+
+// logOutput is what git log prints for two commits with the parseable format: one
+// with sign-offs, a binary file and a rename, and one without files.
+const logOutput = git.CommitSeparator + "\n" +
+	"abc123|Ana|ana@example.com|Fri Jan 2 15:04:05 2026 +0000|2026-01-02T15:04:05Z|refs/heads/feature|Ana <ana@example.com>\x1fBo <bo@example.com>|Ana Lima|ana@example.com|Add parser\n" +
+	"3\t1\tparser.go\n" +
+	"-\t-\tlogo.png\n" +
+	"2\t0\tdocs/{old => new}/guide.md\n" +
+	git.CommitSeparator + "\n" +
+	"def456|Bo|bo@example.com|Thu Jan 1 09:00:00 2026 +0000|2026-01-01T09:00:00Z|HEAD||Bo|bo@example.com|Initial commit\n"
+
+// logArgs are the arguments logCommits gives git log.
+func logArgs(options []string, revs ...string) []string {
+	args := []string{"log", "--pretty=format:" + git.CommitSeparator + "\n" + git.LogFormat, "--numstat", "--source"}
+	return append(args, git.RevArgs(options, revs...)...)
+}
+
+func TestParseCommits(t *testing.T) {
+	commits := git.ParseCommits(logOutput)
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2", len(commits))
+	}
+
+	c := commits[0]
+	if c.Hash != "abc123" || c.Author != "Ana" || c.Email != "ana@example.com" || c.Message != "Add parser" {
+		t.Errorf("commit fields = %q %q %q %q", c.Hash, c.Author, c.Email, c.Message)
+	}
+	if want := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC); !c.When.Equal(want) {
+		t.Errorf("When = %v, want %v", c.When, want)
+	}
+	if c.Branch != "feature" {
+		t.Errorf("Branch = %q, want feature", c.Branch)
+	}
+	if want := []string{"parser.go", "logo.png", "docs/new/guide.md"}; !slices.Equal(c.Files, want) {
+		t.Errorf("Files = %q, want %q", c.Files, want)
+	}
+	if c.Additions != 5 || c.Deletions != 1 {
+		t.Errorf("Additions, Deletions = %d, %d, want 5, 1", c.Additions, c.Deletions)
+	}
+	if want := []string{"Ana <ana@example.com>", "Bo <bo@example.com>"}; !slices.Equal(c.SignedOff, want) {
+		t.Errorf("SignedOff = %q, want %q", c.SignedOff, want)
+	}
+	if c.CanonicalAuthor != "Ana Lima" {
+		t.Errorf("CanonicalAuthor = %q, want Ana Lima", c.CanonicalAuthor)
+	}
+
+	if c := commits[1]; c.Hash != "def456" || len(c.Files) != 0 || c.SignedOff != nil || c.Branch != "HEAD" {
+		t.Errorf("second commit = %+v", c)
+	}
+	if got := git.ParseCommits(""); got != nil {
+		t.Errorf("ParseCommits(\"\") = %v, want nil", got)
+	}
+}
+
+func TestRevArgs(t *testing.T) {
+	tests := []struct {
+		options []string
+		revs    []string
+		want    []string
+	}{
+		{nil, nil, []string{"--end-of-options", "--"}},
+		{[]string{"--all"}, nil, []string{"--all", "--end-of-options", "--"}},
+		{[]string{"--author=--all"}, []string{"main..feature"}, []string{"--author=--all", "--end-of-options", "main..feature", "--"}},
+	}
+	for _, tt := range tests {
+		if got := git.RevArgs(tt.options, tt.revs...); !slices.Equal(got, tt.want) {
+			t.Errorf("RevArgs(%q, %q) = %q, want %q", tt.options, tt.revs, got, tt.want)
+		}
+	}
+
+	// The options slice must not be written through when it has spare capacity.
+	options := make([]string, 1, 4)
+	options[0] = "--no-merges"
+	git.RevArgs(options, "a")
+	if got := options[:cap(options)][1]; got != "" {
+		t.Errorf("RevArgs wrote %q past the options", got)
+	}
+}
+
+func TestGetCommitRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(r *gittest.Runner)
+		want  string
+	}{
+		{
+			name: "local parent",
+			setup: func(r *gittest.Runner) {
+				r.On("1111", "rev-parse", "--verify", "--end-of-options", "main")
+				r.On("base", "merge-base", "--end-of-options", "feature", "main")
+			},
+			want: "base..feature",
+		},
+		{
+			name: "parent only on origin",
+			setup: func(r *gittest.Runner) {
+				r.On("1111", "rev-parse", "--verify", "--end-of-options", "origin/main")
+				r.On("base", "merge-base", "--end-of-options", "feature", "origin/main")
+			},
+			want: "base..feature",
+		},
+		{
+			name:  "parent missing",
+			setup: func(r *gittest.Runner) {},
+			want:  "feature",
+		},
+		{
+			name: "no merge base",
+			setup: func(r *gittest.Runner) {
+				r.On("1111", "rev-parse", "--verify", "--end-of-options", "main")
+			},
+			want: "feature",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gittest.NewRunner()
+			tt.setup(r)
+			if got := git.GetCommitRange(r, repo, "feature", "main"); got != tt.want {
+				t.Errorf("GetCommitRange = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectDefaultBranch(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(r *gittest.Runner)
+		want  string
+	}{
+		{
+			name: "local candidate",
+			setup: func(r *gittest.Runner) {
+				r.On("1111", "rev-parse", "--verify", "--end-of-options", "master")
+			},
+			want: "master",
+		},
+		{
+			name: "candidate only on origin",
+			setup: func(r *gittest.Runner) {
+				r.On("1111", "rev-parse", "--verify", "--end-of-options", "origin/trunk")
+			},
+			want: "origin/trunk",
+		},
+		{
+			name: "origin HEAD",
+			setup: func(r *gittest.Runner) {
+				r.On("* remote origin\n  Fetch URL: x\n  HEAD branch: stable\n", "remote", "show", "origin")
+			},
+			want: "stable",
+		},
+		{
+			name: "first local branch",
+			setup: func(r *gittest.Runner) {
+				r.Fail("fatal: 'origin' does not appear to be a git repository", "remote", "show", "origin")
+				r.On("* topic\n  other", "branch")
+			},
+			want: "topic",
+		},
+		{
+			name:  "nothing found",
+			setup: func(r *gittest.Runner) {},
+			want:  git.DefaultBranchRef,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gittest.NewRunner()
+			tt.setup(r)
+			if got := git.DetectDefaultBranch(r, repo); got != tt.want {
+				t.Errorf("DetectDefaultBranch = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGatherCommits(t *testing.T) {
+	r := gittest.NewRunner().
+		On("feature", "rev-parse", "--abbrev-ref", "HEAD").
+		On("1111", "rev-parse", "--verify", "--end-of-options", "main").
+		On("base", "merge-base", "--end-of-options", "feature", "main").
+		On(logOutput, logArgs([]string{"--author=Ana"}, "base..feature")...)
+
+	commits, branch, err := git.GatherCommits(r, repo, git.GatherOptions{Author: "Ana", ParentBranch: "main", CurrentBranchOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branch != "feature" || len(commits) != 2 {
+		t.Errorf("got branch %q and %d commits, want feature and 2", branch, len(commits))
+	}
+}
+
+func TestGatherCommitsAllBranches(t *testing.T) {
+	r := gittest.NewRunner().
+		On("feature", "rev-parse", "--abbrev-ref", "HEAD").
+		On(logOutput, logArgs([]string{"--all"})...)
+
+	commits, _, err := git.GatherCommits(r, repo, git.GatherOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 {
+		t.Errorf("got %d commits, want 2", len(commits))
+	}
+}
+
+func TestGatherCommitsEmptyRepo(t *testing.T) {
+	r := gittest.NewRunner().
+		Fail("fatal: ambiguous argument 'HEAD': unknown revision or path not in the working tree.", "rev-parse", "--abbrev-ref", "HEAD")
+
+	_, _, err := git.GatherCommits(r, repo, git.GatherOptions{CurrentBranchOnly: true})
+	if !errors.Is(err, git.ErrEmptyRepo) {
+		t.Errorf("err = %v, want ErrEmptyRepo", err)
+	}
+	if git.Advice(err) == "" {
+		t.Error("no advice for an empty repository")
+	}
+}
+
+func TestGatherMissing(t *testing.T) {
+	r := gittest.NewRunner().
+		On("feature", "rev-parse", "--abbrev-ref", "HEAD").
+		On("1111", "rev-parse", "--verify", "--end-of-options", "origin/main").
+		On(logOutput, logArgs([]string{"--author=Bo"}, "feature..origin/main")...)
+
+	commits, branch, err := git.GatherMissing(r, repo, git.GatherOptions{Author: "Bo", ParentBranch: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branch != "feature" || len(commits) != 2 {
+		t.Errorf("got branch %q and %d commits, want feature and 2", branch, len(commits))
+	}
+}
+
+func TestGatherRange(t *testing.T) {
+	r := gittest.NewRunner().On(logOutput, logArgs(nil, "v1.0..v1.1")...)
+	commits, err := git.GatherRange(r, repo, "v1.0", "v1.1")
+	if err != nil || len(commits) != 2 {
+		t.Errorf("GatherRange = %d commits, %v; want 2, nil", len(commits), err)
+	}
+}
+
+func TestOptionLikeRevisionsAreRejected(t *testing.T) {
+	r := gittest.NewRunner()
+	checks := map[string]error{
+		"GatherRange": func() error { _, err := git.GatherRange(r, repo, "", "--all"); return err }(),
+		"GatherCommits": func() error {
+			_, _, err := git.GatherCommits(r, repo, git.GatherOptions{ParentBranch: "--exec=rm", CurrentBranchOnly: true})
+			return err
+		}(),
+		"Unmerged":      func() error { _, err := git.Unmerged(r, repo, "main", "--output=x"); return err }(),
+		"BranchCommits": func() error { _, err := git.BranchCommits(r, repo, "-p", nil, time.Time{}); return err }(),
+	}
+	for name, err := range checks {
+		if !errors.Is(err, git.ErrNoSuchRef) {
+			t.Errorf("%s: err = %v, want ErrNoSuchRef", name, err)
+		}
+	}
+	if len(r.Calls) != 0 {
+		t.Errorf("git ran %q", r.Calls)
+	}
+}
+
+func TestUnmerged(t *testing.T) {
+	r := gittest.NewRunner().
+		On("1111", "rev-parse", "--verify", "--end-of-options", "main").
+		On("+ aaa\n- bbb\n+ ccc", "cherry", "--end-of-options", "main", "feature")
+
+	hashes, err := git.Unmerged(r, repo, "main", "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"aaa", "ccc"}; !slices.Equal(hashes, want) {
+		t.Errorf("Unmerged = %q, want %q", hashes, want)
+	}
+}
+
+func TestBranchCommits(t *testing.T) {
+	options := []string{"--no-merges", "--author=Ana", "--since=2026-01-01T00:00:00Z"}
+	pipe := append([]string{"log", "-p", "--no-color", "--no-ext-diff"}, git.RevArgs(options, "feature")...)
+	pipe = append(pipe, "|", "patch-id", "--stable")
+	r := gittest.NewRunner().
+		On(logOutput, logArgs(options, "feature")...).
+		On("p1 abc123", pipe...)
+
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	commits, err := git.BranchCommits(r, repo, "feature", []string{"Ana"}, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, c := range commits {
+		got[c.Hash] = c.PatchID
+	}
+	if want := map[string]string{"abc123": "p1", "def456": ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("patch IDs = %v, want %v", got, want)
+	}
+}
+
+func TestCLIRunnerStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := git.CLIRunner{Ctx: ctx}
+	if _, err := r.Run(t.TempDir(), "--version"); err != nil {
+		t.Skip("git is not installed")
+	}
+	cancel()
+	if _, err := r.Run(t.TempDir(), "--version"); err == nil {
+		t.Error("Run with a cancelled context succeeded")
+	}
+	if err := r.Stream(t.TempDir(), []string{"--version"}, func(string) {}); err == nil {
+		t.Error("Stream with a cancelled context succeeded")
+	}
+}
@@ -0,0 +1,78 @@
+// Package gittest provides a git.Runner that answers from canned output, for
+// testing the git package's parsing and range logic without a repository.
+package gittest
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/git"
+)
+
+// Response is what a command prints, or how it fails.
+type Response struct {
+	Output string
+	Err    error
+}
+
+// Runner answers each command with the Response registered for its arguments and
+// records every command it was asked to run.
+type Runner struct {
+	responses map[string]Response
+	Calls     [][]string
+}
+
+func NewRunner() *Runner {
+	return &Runner{responses: make(map[string]Response)}
+}
+
+// On registers output as what git prints for args.
+func (r *Runner) On(output string, args ...string) *Runner {
+	r.responses[key(args)] = Response{Output: output}
+	return r
+}
+
+// Fail makes args fail the way git does, printing stderr.
+func (r *Runner) Fail(stderr string, args ...string) *Runner {
+	r.responses[key(args)] = Response{Err: commandError(args, stderr)}
+	return r
+}
+
+func (r *Runner) Run(path string, args ...string) (string, error) {
+	r.Calls = append(r.Calls, args)
+	resp, ok := r.responses[key(args)]
+	if !ok {
+		return "", commandError(args, "fatal: gittest: no response for git "+key(args))
+	}
+	if resp.Err != nil {
+		return "", resp.Err
+	}
+	return strings.TrimSpace(resp.Output), nil
+}
+
+func (r *Runner) Stream(path string, args []string, onLine func(line string)) error {
+	output, err := r.Run(path, args...)
+	if err != nil {
+		return err
+	}
+	for line := range strings.SplitSeq(output, "\n") {
+		onLine(line)
+	}
+	return nil
+}
+
+// Pipe answers with the Response registered for the arguments of both commands
+// joined by " | ", as in On(output, "log", "-p", "|", "patch-id").
+func (r *Runner) Pipe(path string, from, to []string) (string, error) {
+	return r.Run(path, append(append(append([]string{}, from...), "|"), to...)...)
+}
+
+func key(args []string) string {
+	return strings.Join(args, " ")
+}
+
+// commandError builds the error git.CLIRunner returns for a failing command, so
+// it is classified the same way.
+func commandError(args []string, stderr string) error {
+	return git.NewCommandError(args, stderr, errors.New("exit status 128"))
+}
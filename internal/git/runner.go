@@ -0,0 +1,98 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// Runner runs git commands in a repository. CLIRunner runs the git binary;
+// gittest.Runner answers from canned output so parsing can be tested without one.
+type Runner interface {
+	// Run returns the trimmed stdout of git args run in path.
+	Run(path string, args ...string) (string, error)
+	// Stream runs git args in path, handing each line of stdout to onLine as it
+	// is read.
+	Stream(path string, args []string, onLine func(line string)) error
+	// Pipe runs git from in path with its stdout fed to git to, and returns the
+	// trimmed stdout of to.
+	Pipe(path string, from, to []string) (string, error)
+}
+
+// CLIRunner runs the git found on the PATH.
+type CLIRunner struct {
+	// Ctx, when set, kills the git commands still running once it is done.
+	Ctx context.Context
+}
+
+func (r CLIRunner) command(path string, args []string) *exec.Cmd {
+	ctx := r.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return exec.CommandContext(ctx, "git", append([]string{"-C", path}, args...)...)
+}
+
+func (r CLIRunner) Run(path string, args ...string) (string, error) {
+	cmd := r.command(path, args)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", NewCommandError(args, stderr.String(), err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (r CLIRunner) Stream(path string, args []string, onLine func(line string)) error {
+	cmd := r.command(path, args)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return NewCommandError(args, stderr.String(), err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		onLine(scanner.Text())
+	}
+	scanErr := scanner.Err()
+	if err := cmd.Wait(); err != nil {
+		return NewCommandError(args, stderr.String(), err)
+	}
+	return scanErr
+}
+
+func (r CLIRunner) Pipe(path string, from, to []string) (string, error) {
+	fromCmd, toCmd := r.command(path, from), r.command(path, to)
+	var fromStderr, toStderr bytes.Buffer
+	fromCmd.Stderr, toCmd.Stderr = &fromStderr, &toStderr
+
+	stdout, err := fromCmd.StdoutPipe()
+	if err != nil {
+		return "", err
+	}
+	toCmd.Stdin = stdout
+	if err := fromCmd.Start(); err != nil {
+		return "", NewCommandError(from, fromStderr.String(), err)
+	}
+	output, toErr := toCmd.Output()
+	if err := fromCmd.Wait(); err != nil {
+		return "", NewCommandError(from, fromStderr.String(), err)
+	}
+	if toErr != nil {
+		return "", NewCommandError(to, toStderr.String(), toErr)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func execGit(path string, args ...string) (string, error) {
+	return CLIRunner{}.Run(path, args...)
+}
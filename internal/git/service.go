@@ -1,21 +1,44 @@
 package git
 
-import "github.com/leeozaka/gommits/internal/models"
+import (
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
 
 type GitService interface {
 	IsGitRepo(path string) bool
 	GetCurrentBranch(path string) (string, error)
+	RefState(path string) (string, error)
 	GetRepositoryName(path string) string
+	RemoteURL(path string) (string, error)
+	UserEmail(path string) (string, error)
 	DetectDefaultBranch(path string) string
-	GatherCommits(path, author, parentBranch string, currentBranchOnly bool) ([]models.CommitInfo, string, error)
+	GatherCommits(path string, opts GatherOptions) ([]models.CommitInfo, string, error)
+	GatherMissing(path string, opts GatherOptions) ([]models.CommitInfo, string, error)
+	GatherRange(path, from, to string) ([]models.CommitInfo, error)
+	ListTags(path string) ([]string, error)
+	Branches(path string) ([]string, error)
+	Contributors(path string) ([]models.Contributor, error)
+	Unmerged(path, upstream, head string) ([]string, error)
+	BranchCommits(path, ref string, authors []string, since time.Time) ([]models.CommitInfo, error)
 	GetChangedFiles(path, commitHash string) ([]string, error)
+	ShowPatch(path, commitHash string) (string, error)
 	PathExistsInRef(repoPath, ref, targetPath string) bool
 }
 
-type CLIGitService struct{}
+type CLIGitService struct {
+	runner Runner
+}
 
 func NewCLIGitService() *CLIGitService {
-	return &CLIGitService{}
+	return &CLIGitService{runner: CLIRunner{}}
+}
+
+// NewServiceWithRunner returns a CLIGitService whose commit gathering, range logic
+// and branch detection go through r, such as a gittest.Runner.
+func NewServiceWithRunner(r Runner) *CLIGitService {
+	return &CLIGitService{runner: r}
 }
 
 func (s *CLIGitService) IsGitRepo(path string) bool {
@@ -26,22 +49,66 @@ func (s *CLIGitService) GetCurrentBranch(path string) (string, error) {
 	return GetCurrentBranch(path)
 }
 
+func (s *CLIGitService) RefState(path string) (string, error) {
+	return RefState(path)
+}
+
 func (s *CLIGitService) GetRepositoryName(path string) string {
 	return GetRepositoryName(path)
 }
 
+func (s *CLIGitService) RemoteURL(path string) (string, error) {
+	return RemoteURL(path)
+}
+
+func (s *CLIGitService) UserEmail(path string) (string, error) {
+	return UserEmail(path)
+}
+
 func (s *CLIGitService) DetectDefaultBranch(path string) string {
-	return DetectDefaultBranch(path)
+	return DetectDefaultBranch(s.runner, path)
+}
+
+func (s *CLIGitService) GatherCommits(path string, opts GatherOptions) ([]models.CommitInfo, string, error) {
+	return GatherCommits(s.runner, path, opts)
+}
+
+func (s *CLIGitService) GatherMissing(path string, opts GatherOptions) ([]models.CommitInfo, string, error) {
+	return GatherMissing(s.runner, path, opts)
+}
+
+func (s *CLIGitService) GatherRange(path, from, to string) ([]models.CommitInfo, error) {
+	return GatherRange(s.runner, path, from, to)
+}
+
+func (s *CLIGitService) ListTags(path string) ([]string, error) {
+	return ListTags(path)
+}
+
+func (s *CLIGitService) Branches(path string) ([]string, error) {
+	return Branches(path)
 }
 
-func (s *CLIGitService) GatherCommits(path, author, parentBranch string, currentBranchOnly bool) ([]models.CommitInfo, string, error) {
-	return GatherCommits(path, author, parentBranch, currentBranchOnly)
+func (s *CLIGitService) Contributors(path string) ([]models.Contributor, error) {
+	return Contributors(path)
+}
+
+func (s *CLIGitService) Unmerged(path, upstream, head string) ([]string, error) {
+	return Unmerged(s.runner, path, upstream, head)
+}
+
+func (s *CLIGitService) BranchCommits(path, ref string, authors []string, since time.Time) ([]models.CommitInfo, error) {
+	return BranchCommits(s.runner, path, ref, authors, since)
 }
 
 func (s *CLIGitService) GetChangedFiles(path, commitHash string) ([]string, error) {
 	return GetChangedFiles(path, commitHash)
 }
 
+func (s *CLIGitService) ShowPatch(path, commitHash string) (string, error) {
+	return ShowPatch(path, commitHash)
+}
+
 func (s *CLIGitService) PathExistsInRef(repoPath, ref, targetPath string) bool {
 	return PathExistsInRef(repoPath, ref, targetPath)
 }
@@ -0,0 +1,115 @@
+// Package httpretry sends HTTP requests to code hosts, issue trackers and delivery
+// targets, retrying the ones that fail on a flaky network.
+package httpretry
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	initialDelay  = time.Second
+	maxRetryDelay = 30 * time.Second
+	// maxRetryAfter is the longest Retry-After waited out; a server asking for more
+	// fails the request instead of stalling the run.
+	maxRetryAfter = 5 * time.Minute
+)
+
+// OnRetry, when set, is told about each failed request before it is retried, so
+// headless runs can say what they are waiting for. It may be called from several
+// goroutines at once.
+var OnRetry func(attempt, attempts int, err error)
+
+// Client retries network errors, 429 and 5xx responses with exponential backoff,
+// or after the 429's Retry-After when it has one.
+type Client struct {
+	http     *http.Client
+	attempts int
+}
+
+// New retries a failed request retries times; 0 disables retries.
+func New(client *http.Client, retries int) *Client {
+	return &Client{http: client, attempts: max(retries, 0) + 1}
+}
+
+// Attempts is how many times a request is sent at most.
+func (c *Client) Attempts() int {
+	return c.attempts
+}
+
+// Do sends req and hands the response to handle, which returns the request's
+// error. Once a request has been retried, its error says which attempt it came
+// from. A body is sent again through req.GetBody, which http.NewRequest sets for
+// in-memory readers.
+func (c *Client) Do(req *http.Request, handle func(*http.Response) error) error {
+	delay := initialDelay
+	for attempt := 1; ; attempt++ {
+		retry, wait, err := c.once(req, handle)
+		if err == nil {
+			return nil
+		}
+		if wait > maxRetryAfter {
+			retry = false
+		}
+		if c.attempts > 1 && (retry || attempt > 1) {
+			err = fmt.Errorf("attempt %d/%d: %v", attempt, c.attempts, err)
+		}
+		if !retry || attempt == c.attempts {
+			return err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return err
+		}
+		if OnRetry != nil {
+			OnRetry(attempt, c.attempts, err)
+		}
+		if wait > 0 {
+			time.Sleep(wait)
+		} else {
+			time.Sleep(delay)
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
+// once reports whether a failure is worth retrying and, for a 429 that says, how
+// long to wait first.
+func (c *Client) once(req *http.Request, handle func(*http.Response) error) (bool, time.Duration, error) {
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return false, 0, err
+		}
+		req.Body = body
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return true, 0, err
+	}
+	defer resp.Body.Close()
+	var wait time.Duration
+	if resp.StatusCode == http.StatusTooManyRequests {
+		wait = retryAfter(resp.Header.Get("Retry-After"), time.Now())
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, wait, handle(resp)
+}
+
+// retryAfter parses a Retry-After header, either delay-seconds or an HTTP date; 0
+// means it is missing, invalid or already past.
+func retryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(at.Sub(now), 0)
+	}
+	return 0
+}
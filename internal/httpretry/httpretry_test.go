@@ -0,0 +1,101 @@
+package httpretry
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{" 10 ", 10 * time.Second},
+		{"-5", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := retryAfter(tt.header, now); got != tt.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestAttempts(t *testing.T) {
+	tests := []struct {
+		retries int
+		want    int
+	}{
+		{-1, 1},
+		{0, 1},
+		{4, 5},
+	}
+	for _, tt := range tests {
+		if got := New(http.DefaultClient, tt.retries).Attempts(); got != tt.want {
+			t.Errorf("New(%d).Attempts() = %d, want %d", tt.retries, got, tt.want)
+		}
+	}
+}
+
+func TestHonorsRetryAfter(t *testing.T) {
+	var calls []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, time.Now())
+		// The body is sent again with the retry.
+		if body, _ := io.ReadAll(r.Body); string(body) != "payload" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if len(calls) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("payload"))
+	if err := New(http.DefaultClient, 1).Do(req, expectOK); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("got %d requests, want 2", len(calls))
+	}
+	if waited := calls[1].Sub(calls[0]); waited < 2*time.Second {
+		t.Errorf("retried after %v, want the 2s Retry-After", waited)
+	}
+}
+
+func TestWithoutRetries(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err := New(http.DefaultClient, 0).Do(req, expectOK); err == nil {
+		t.Fatal("want an error for a 503")
+	}
+	if calls != 1 {
+		t.Errorf("got %d requests, want 1", calls)
+	}
+}
+
+func expectOK(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %s", resp.Status)
+	}
+	return nil
+}
@@ -1,13 +1,71 @@
 package models
 
+import "time"
+
 type CommitInfo struct {
 	Hash     string
 	Author   string
 	Email    string
 	Date     string
+	When     time.Time // author date parsed from %aI; zero if git emitted something unparseable
+	Branch   string    // ref the commit was reached from (git log --source)
 	Message  string
 	Files    []string
 	RawFiles []string // original file list before ResolveProjects rewrites Files
+
+	Additions int
+	Deletions int
+	// FileChanges holds per-file line counts from --numstat. Commits read back from an
+	// existing workbook have none.
+	FileChanges []FileChange
+
+	SignedOff []string // Signed-off-by trailer values ("Name <email>")
+
+	// CanonicalAuthor and CanonicalEmail are the author after .mailmap is applied.
+	CanonicalAuthor string
+	CanonicalEmail  string
+
+	// PatchID is the stable `git patch-id` of the change, identical for cherry-picks
+	// of the same patch. Only set by git.BranchCommits.
+	PatchID string
+	// Unmerged marks a commit on another branch whose change has not landed on the
+	// parent branch (per `git cherry`). Only set when gathering beyond the current branch.
+	Unmerged bool
+
+	URL         string       // web link on the code host, when the remote is recognized
+	PullRequest *PullRequest // set by forge enrichment when the commit belongs to a PR
+	WorkItems   []WorkItem   // Azure Boards items referenced as AB#1234 in the message
+	Issues      []WorkItem   // Jira issues referenced by key (PROJ-123) in the message
+}
+
+type FileChange struct {
+	Path      string
+	Additions int
+	Deletions int
+}
+
+// WorkItem is an issue-tracker item referenced from a commit message. Title and
+// State are only filled when the tracker was queried.
+type WorkItem struct {
+	ID    string
+	Title string
+	State string
+	URL   string
+}
+
+// PullRequest is the code-host review that introduced a commit.
+type PullRequest struct {
+	Number int
+	Title  string
+	URL    string
+	State  string // open, closed, merged or draft
+	Review string // approved, changes_requested, commented, or "" without reviews
+	Checks string // success, failure, pending, or "" without checks
+}
+
+// LinesChanged returns the total number of added and deleted lines.
+func (c CommitInfo) LinesChanged() int {
+	return c.Additions + c.Deletions
 }
 
 type DotnetEntry struct {
@@ -20,3 +78,10 @@ type DBAEntry struct {
 	Sequence int
 	Path     string
 }
+
+// Contributor is an author as git shortlog counts them, after .mailmap is applied.
+type Contributor struct {
+	Name    string
+	Email   string
+	Commits int
+}
@@ -12,6 +12,14 @@ const (
 	AuthorScreen
 	OptionsScreen
 	ResultsScreen
+	LeaderboardScreen
+	StatsScreen
+	HeatmapScreen
+	ActivityScreen
+	HotspotsScreen
+	CompareScreen
+	DiffScreen
+	PresetScreen
 )
 
 type ToastType int
@@ -22,6 +30,7 @@ const (
 )
 
 type Toast struct {
+	ID        int
 	Message   string
 	Type      ToastType
 	Visible   bool
@@ -29,6 +38,14 @@ type Toast struct {
 	Position  float64
 	StartTime time.Time
 	Duration  time.Duration
+	Action    *ToastAction
+}
+
+// ToastAction is a shortcut a toast offers for as long as it is shown.
+type ToastAction struct {
+	Key  string // the key as tea.KeyMsg.String() spells it, e.g. "ctrl+o"
+	Hint string // shown under the message, e.g. "Press Ctrl+O to open it."
+	Run  func() error
 }
 
 type FetchCommitsMsg struct {
@@ -37,11 +54,33 @@ type FetchCommitsMsg struct {
 	ParentBranch string
 	DotnetMode   bool
 	Err          error
+
+	// The query that produced Commits, so it can be re-run.
+	Author            string
+	MaxCommits        int
+	CurrentBranchOnly bool
+	Missing           bool
+	Unmerged          bool
+}
+
+// EnrichCommitsMsg delivers commits with code-host details looked up after a fetch.
+type EnrichCommitsMsg struct {
+	Commits []CommitInfo
+	Err     error
+}
+
+// DiffMsg delivers the patch of a single commit for the diff viewer.
+type DiffMsg struct {
+	Hash  string
+	Patch string
+	Err   error
 }
 
 type ExportExcelMsg struct {
-	Path string
-	Err  error
+	Count     int      // commits exported; 0 means all loaded results
+	Path      string   // final artifact written (the zip when bundling)
+	Delivered []string // upload/notification destinations that succeeded
+	Err       error
 }
 
 type ResetToHomeMsg struct{}
@@ -50,9 +89,13 @@ type ShowToastMsg struct {
 	Message  string
 	Type     ToastType
 	Duration time.Duration
+	Action   *ToastAction // optional
 }
 
-type HideToastMsg struct{}
+// HideToastMsg dismisses the toast with ID.
+type HideToastMsg struct {
+	ID int
+}
 
 type ErrorMsg struct {
 	Err     error
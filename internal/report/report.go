@@ -0,0 +1,257 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/forge"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+// Query describes one commit lookup, shared by the TUI, the HTTP server and other
+// non-interactive modes.
+type Query struct {
+	Dir string
+	// Author is a comma-separated list; empty matches everyone.
+	Author            string
+	MaxCommits        int
+	CurrentBranchOnly bool
+	ParentBranch      string
+	// Missing reverses the range: commits on ParentBranch that the current branch
+	// lacks. CurrentBranchOnly is ignored.
+	Missing bool
+	// Unmerged keeps only commits on other branches that have not landed on
+	// ParentBranch. Commits gathered beyond the current branch are flagged either way.
+	Unmerged   bool
+	DotnetMode bool
+
+	// Since and Until bound the author date; zero values leave that side open.
+	Since time.Time
+	Until time.Time
+
+	// OnProgress, when set, follows git listing the commits, summed over the
+	// authors gathered. It may be called from several goroutines.
+	OnProgress func(git.Progress)
+}
+
+type Result struct {
+	Commits []models.CommitInfo
+	Branch  string
+}
+
+type authorResult struct {
+	commits []models.CommitInfo
+	branch  string
+	err     error
+}
+
+// Gather runs q against svc, merging per-author results without duplicates.
+func Gather(svc git.GitService, q Query) (Result, error) {
+	authors := SplitAuthors(q.Author)
+	if q.Unmerged {
+		q.CurrentBranchOnly = false
+	}
+
+	var allCommits []models.CommitInfo
+	var branch string
+	var err error
+
+	gather := svc.GatherCommits
+	if q.Missing {
+		gather = svc.GatherMissing
+	}
+	opts := git.GatherOptions{
+		ParentBranch:      q.ParentBranch,
+		CurrentBranchOnly: q.CurrentBranchOnly,
+		OnProgress:        q.OnProgress,
+	}
+
+	if len(authors) == 0 {
+		allCommits, branch, err = gather(q.Dir, opts)
+	} else if len(authors) == 1 {
+		opts.Author = authors[0]
+		allCommits, branch, err = gather(q.Dir, opts)
+	} else {
+		results := make([]authorResult, len(authors))
+		progress := sumProgress(len(authors), q.OnProgress)
+		var wg sync.WaitGroup
+		wg.Add(len(authors))
+
+		for i, a := range authors {
+			go func(idx int, authorName string) {
+				defer wg.Done()
+				authorOpts := opts
+				authorOpts.Author, authorOpts.OnProgress = authorName, progress(idx)
+				c, b, e := gather(q.Dir, authorOpts)
+				results[idx] = authorResult{commits: c, branch: b, err: e}
+			}(i, a)
+		}
+
+		wg.Wait()
+
+		seen := make(map[string]bool)
+		for _, r := range results {
+			if r.err != nil {
+				err = r.err
+				break
+			}
+			if r.branch != "" {
+				branch = r.branch
+			}
+			for _, c := range r.commits {
+				if !seen[c.Hash] {
+					seen[c.Hash] = true
+					allCommits = append(allCommits, c)
+				}
+			}
+		}
+		// Each author's commits are newest first; keep the merged list that way so
+		// MaxCommits keeps the newest overall.
+		sort.SliceStable(allCommits, func(i, j int) bool {
+			return allCommits[i].When.After(allCommits[j].When)
+		})
+	}
+	if err != nil {
+		return Result{Branch: branch}, err
+	}
+
+	if !q.Missing && (!q.CurrentBranchOnly || q.Unmerged) {
+		if err := markUnmerged(svc, q, allCommits); err != nil {
+			return Result{Branch: branch}, err
+		}
+		if q.Unmerged {
+			var kept []models.CommitInfo
+			for _, c := range allCommits {
+				if c.Unmerged {
+					kept = append(kept, c)
+				}
+			}
+			allCommits = kept
+		}
+	}
+
+	allCommits = FilterByDate(allCommits, q.Since, q.Until)
+	if q.MaxCommits > 0 && len(allCommits) > q.MaxCommits {
+		allCommits = allCommits[:q.MaxCommits]
+	}
+	if q.DotnetMode {
+		allCommits = utils.ResolveProjects(q.Dir, allCommits)
+	}
+	return Result{Commits: allCommits, Branch: branch}, nil
+}
+
+// sumProgress splits report into one callback per concurrent gather, each reporting
+// the combined counts. The callbacks are nil when report is.
+func sumProgress(n int, report func(git.Progress)) func(i int) func(git.Progress) {
+	var mu sync.Mutex
+	each := make([]git.Progress, n)
+	return func(i int) func(git.Progress) {
+		if report == nil {
+			return nil
+		}
+		return func(p git.Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+			each[i] = p
+			var total git.Progress
+			for _, e := range each {
+				total.Commits += e.Commits
+				total.Total += e.Total
+				total.Files += e.Files
+			}
+			report(total)
+		}
+	}
+}
+
+// markUnmerged asks git cherry, for every branch the commits were reached from, which
+// of them have no equivalent on the parent branch.
+func markUnmerged(svc git.GitService, q Query, commits []models.CommitInfo) error {
+	unmerged := make(map[string]bool)
+	checked := map[string]bool{q.ParentBranch: true, git.OriginPrefix + q.ParentBranch: true}
+	for _, c := range commits {
+		if c.Branch == "" || checked[c.Branch] {
+			continue
+		}
+		checked[c.Branch] = true
+		hashes, err := svc.Unmerged(q.Dir, q.ParentBranch, c.Branch)
+		if err != nil {
+			return fmt.Errorf("failed to compare %s with %s: %v", c.Branch, q.ParentBranch, err)
+		}
+		for _, h := range hashes {
+			unmerged[h] = true
+		}
+	}
+	for i := range commits {
+		commits[i].Unmerged = unmerged[commits[i].Hash]
+	}
+	return nil
+}
+
+// FilterByDate keeps commits authored in [since, until]; zero bounds are ignored.
+func FilterByDate(commits []models.CommitInfo, since, until time.Time) []models.CommitInfo {
+	if since.IsZero() && until.IsZero() {
+		return commits
+	}
+	var kept []models.CommitInfo
+	for _, c := range commits {
+		if !since.IsZero() && c.When.Before(since) {
+			continue
+		}
+		if !until.IsZero() && c.When.After(until) {
+			continue
+		}
+		kept = append(kept, c)
+	}
+	return kept
+}
+
+func SplitAuthors(input string) []string {
+	if strings.TrimSpace(input) == "" {
+		return nil
+	}
+	parts := strings.Split(input, ",")
+	var result []string
+	for _, p := range parts {
+		trimmed := strings.TrimSpace(p)
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// ParseDate accepts "2006-01-02" or RFC 3339. A bare date used as an upper bound
+// covers the whole day.
+func ParseDate(s string, endOfDay bool, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	t, err := time.ParseInLocation(time.DateOnly, s, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD or RFC 3339", s)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return t, nil
+}
+
+// Enrich adds code-host links, tracker references and, when configured, pull request
+// and work item details to commits of the repository at dir.
+func Enrich(svc git.GitService, cfg config.Config, dir string, commits []models.CommitInfo) ([]models.CommitInfo, error) {
+	remote, _ := svc.RemoteURL(dir)
+	return forge.Enrich(cfg.Integrations, remote, commits)
+}
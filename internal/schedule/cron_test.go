@@ -0,0 +1,96 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronField(t *testing.T) {
+	tests := []struct {
+		field    string
+		min, max int
+		want     []int
+	}{
+		{"*", 0, 5, []int{0, 1, 2, 3, 4, 5}},
+		{"3", 0, 59, []int{3}},
+		{"1,15,30", 0, 59, []int{1, 15, 30}},
+		{"9-12", 0, 23, []int{9, 10, 11, 12}},
+		{"*/15", 0, 59, []int{0, 15, 30, 45}},
+		{"9-17/4", 0, 23, []int{9, 13, 17}},
+		{"10/20", 0, 59, []int{10, 30, 50}},
+		{"1-2,5/3", 1, 12, []int{1, 2, 5, 8, 11}},
+	}
+	for _, tt := range tests {
+		got, err := parseCronField(tt.field, tt.min, tt.max)
+		if err != nil {
+			t.Errorf("parseCronField(%q) failed: %v", tt.field, err)
+			continue
+		}
+		var want uint64
+		for _, v := range tt.want {
+			want |= 1 << uint(v)
+		}
+		if got != want {
+			t.Errorf("parseCronField(%q) = %b, want %b", tt.field, got, want)
+		}
+	}
+}
+
+func TestParseCronRejects(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1-b * * * *",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want an error", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// Monday 1 January 2024, 08:30.
+	from := time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want []string // the next runs after from, in order
+	}{
+		{"*/15 * * * *", []string{"2024-01-01 08:45", "2024-01-01 09:00"}},
+		{"0 9 * * *", []string{"2024-01-01 09:00", "2024-01-02 09:00"}},
+		{"@weekly", []string{"2024-01-07 00:00", "2024-01-14 00:00"}},
+		{"0 17 * * 5", []string{"2024-01-05 17:00", "2024-01-12 17:00"}},
+		{"0 9 * * 7", []string{"2024-01-07 09:00"}},
+		{"0 9 1-5 * *", []string{"2024-01-01 09:00", "2024-01-02 09:00"}},
+		{"0 0 1 */3 *", []string{"2024-04-01 00:00", "2024-07-01 00:00"}},
+		// Both day fields restricted: either one matches (the 15th, or a Friday).
+		{"0 9 15 * 5", []string{"2024-01-05 09:00", "2024-01-12 09:00", "2024-01-15 09:00", "2024-01-19 09:00"}},
+		// A stepped * still counts as unrestricted, so both fields must match: Mondays
+		// on odd days, and firsts of the month on Sundays to Saturdays two apart.
+		{"0 9 */2 * 1", []string{"2024-01-01 09:00", "2024-01-15 09:00", "2024-01-29 09:00"}},
+		{"0 9 1 * */2", []string{"2024-02-01 09:00", "2024-06-01 09:00"}},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q) failed: %v", tt.expr, err)
+			continue
+		}
+		next := from
+		for _, want := range tt.want {
+			next = c.Next(next)
+			if got := next.Format("2006-01-02 15:04"); got != want {
+				t.Errorf("%q: next run %s, want %s", tt.expr, got, want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/delivery"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/report"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+type job struct {
+	cfg  config.ScheduleConfig
+	cron Cron
+	next time.Time
+}
+
+// Daemon runs the configured schedules until its context is cancelled.
+type Daemon struct {
+	svc    git.GitService
+	cfg    config.Config
+	loc    *time.Location
+	jobs   []*job
+	logger *log.Logger
+}
+
+func NewDaemon(svc git.GitService, cfg config.Config, logger *log.Logger) (*Daemon, error) {
+	if len(cfg.Schedules) == 0 {
+		return nil, fmt.Errorf("no schedules configured")
+	}
+	loc, err := cfg.Location()
+	if err != nil {
+		return nil, err
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+
+	d := &Daemon{svc: svc, cfg: cfg, loc: loc, logger: logger}
+	for i, sc := range cfg.Schedules {
+		if sc.Name == "" {
+			sc.Name = fmt.Sprintf("schedule %d", i+1)
+		}
+		if sc.Repo == "" {
+			return nil, fmt.Errorf("%s: repo is required", sc.Name)
+		}
+		c, err := ParseCron(sc.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", sc.Name, err)
+		}
+		d.jobs = append(d.jobs, &job{cfg: sc, cron: c})
+	}
+	return d, nil
+}
+
+func (d *Daemon) Run(ctx context.Context) error {
+	now := time.Now().In(d.loc)
+	for _, j := range d.jobs {
+		j.next = j.cron.Next(now)
+		d.logger.Printf("%s: next run %s", j.cfg.Name, j.next.Format(time.RFC1123))
+	}
+
+	for {
+		var due time.Time
+		for _, j := range d.jobs {
+			if !j.next.IsZero() && (due.IsZero() || j.next.Before(due)) {
+				due = j.next
+			}
+		}
+		if due.IsZero() {
+			return fmt.Errorf("no schedule will ever run again")
+		}
+
+		timer := time.NewTimer(time.Until(due))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		for _, j := range d.jobs {
+			if j.next.Equal(due) {
+				d.runJob(j.cfg, due)
+				j.next = j.cron.Next(time.Now().In(d.loc))
+			}
+		}
+	}
+}
+
+// RunNow executes the schedule called name once, ignoring its cron expression.
+func (d *Daemon) RunNow(name string) error {
+	for _, j := range d.jobs {
+		if j.cfg.Name == name {
+			return d.runJob(j.cfg, time.Now().In(d.loc))
+		}
+	}
+	return fmt.Errorf("no schedule named %q", name)
+}
+
+func (d *Daemon) runJob(sc config.ScheduleConfig, at time.Time) error {
+	err := d.generate(sc, at)
+	if err != nil {
+		d.logger.Printf("%s: %v", sc.Name, err)
+	}
+	return err
+}
+
+func (d *Daemon) generate(sc config.ScheduleConfig, at time.Time) error {
+	q := report.Query{
+		Dir:               sc.Repo,
+		Author:            sc.Author,
+		CurrentBranchOnly: !sc.AllBranches,
+		ParentBranch:      sc.ParentBranch,
+	}
+	if !d.svc.IsGitRepo(q.Dir) {
+		return fmt.Errorf("%s is not a git repository", q.Dir)
+	}
+	if q.ParentBranch == "" {
+		q.ParentBranch = d.svc.DetectDefaultBranch(q.Dir)
+	}
+	if sc.Days > 0 {
+		q.Since = at.AddDate(0, 0, -sc.Days)
+	}
+
+	res, err := report.Gather(d.svc, q)
+	if err != nil {
+		return fmt.Errorf("failed to gather commits: %v", err)
+	}
+	loc, _ := d.cfg.Location()
+	commits := utils.ConvertTimezone(res.Commits, loc)
+	if commits, err = report.Enrich(d.svc, d.cfg, q.Dir, commits); err != nil {
+		d.logger.Printf("%s: %v", sc.Name, err)
+	}
+
+	cfg := d.cfg
+	if len(sc.Formats) > 0 {
+		cfg.Export.Formats = sc.Formats
+	}
+	if sc.Upload != nil {
+		cfg.Upload = *sc.Upload
+	}
+	if sc.Notify != nil {
+		cfg.Notify = *sc.Notify
+	}
+
+	repoName := d.svc.GetRepositoryName(q.Dir)
+	artifacts, err := utils.ExportCommits(commits, q.Dir, repoName, cfg, nil)
+	if err != nil {
+		return fmt.Errorf("failed to export: %v", err)
+	}
+	delivered, err := delivery.Deliver(cfg, delivery.Report{
+		RepoName:  repoName,
+		Branch:    res.Branch,
+		Commits:   commits,
+		Artifacts: artifacts,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deliver: %v", err)
+	}
+	d.logger.Printf("%s: exported %d commits to %v, delivered to %v", sc.Name, len(commits), artifacts, delivered)
+	return nil
+}
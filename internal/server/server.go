@@ -0,0 +1,224 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/internal/report"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+// Server exposes commit queries over HTTP for repositories below Root:
+//
+//	GET /repos/{path}/commits?author=&since=&until=&all=&parent=&max=
+//	GET /repos/{path}/export.xlsx (also .csv and .json)
+//
+// {path} is relative to Root and may contain slashes.
+type Server struct {
+	svc  git.GitService
+	cfg  config.Config
+	root string
+}
+
+func New(svc git.GitService, cfg config.Config, root string) (*Server, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve root: %v", err)
+	}
+	return &Server{svc: svc, cfg: cfg, root: abs}, nil
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /repos/{rest...}", s.handleRepo)
+	return mux
+}
+
+func (s *Server) handleRepo(w http.ResponseWriter, r *http.Request) {
+	rest := r.PathValue("rest")
+	repoRel, action, ok := cutLast(rest)
+	if !ok {
+		writeError(w, http.StatusNotFound, "expected /repos/{path}/commits or /repos/{path}/export.{xlsx,csv,json}")
+		return
+	}
+
+	repoPath, err := s.resolveRepo(repoRel)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	switch action {
+	case "commits", "export.json", "export.csv", "export.xlsx":
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown resource %q", action))
+		return
+	}
+
+	q, err := s.parseQuery(r, repoPath)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	res, err := report.Gather(s.svc, q)
+	if err != nil {
+		writeError(w, gatherErrorStatus(err), err.Error())
+		return
+	}
+	loc, _ := s.cfg.Location()
+	commits := utils.ConvertTimezone(res.Commits, loc)
+	if commits, err = report.Enrich(s.svc, s.cfg, repoPath, commits); err != nil {
+		log.Printf("gommits serve: %v", err)
+	}
+	repoName := s.svc.GetRepositoryName(repoPath)
+
+	switch action {
+	case "commits", "export.json":
+		s.writeJSON(w, action, commits, repoName)
+	case "export.csv":
+		s.writeExport(w, "text/csv; charset=utf-8", utils.CSVPath("", repoName), func(buf *bytes.Buffer) error {
+			return utils.WriteCSV(buf, commits, s.cfg.Export)
+		})
+	case "export.xlsx":
+		s.writeExport(w, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", utils.ExcelPath("", repoName), func(buf *bytes.Buffer) error {
+			return utils.WriteExcelTo(buf, commits, repoPath, repoName, s.cfg)
+		})
+	}
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, action string, commits []models.CommitInfo, repoName string) {
+	data, err := utils.EncodeJSONExport(commits, repoName)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if action == "export.json" {
+		w.Header().Set("Content-Disposition", attachment(utils.JSONPath("", repoName)))
+	}
+	w.Write(data)
+}
+
+// writeExport renders into memory first so a failure can still be reported as an error status.
+func (s *Server) writeExport(w http.ResponseWriter, contentType, filename string, render func(*bytes.Buffer) error) {
+	var buf bytes.Buffer
+	if err := render(&buf); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", attachment(filename))
+	w.Write(buf.Bytes())
+}
+
+// resolveRepo maps a request path onto a git repository inside the server root.
+func (s *Server) resolveRepo(rel string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(rel))
+	inside, err := filepath.Rel(s.root, path)
+	if err != nil || inside == ".." || strings.HasPrefix(inside, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("repository %q is outside the served root", rel)
+	}
+	if !s.svc.IsGitRepo(path) {
+		return "", fmt.Errorf("%q is not a git repository", rel)
+	}
+	return path, nil
+}
+
+func (s *Server) parseQuery(r *http.Request, repoPath string) (report.Query, error) {
+	v := r.URL.Query()
+	loc, _ := s.cfg.Location()
+
+	q := report.Query{
+		Dir:               repoPath,
+		Author:            v.Get("author"),
+		CurrentBranchOnly: true,
+		ParentBranch:      v.Get("parent"),
+	}
+	if q.ParentBranch == "" {
+		q.ParentBranch = s.svc.DetectDefaultBranch(repoPath)
+	}
+	if all := v.Get("all"); all != "" {
+		b, err := strconv.ParseBool(all)
+		if err != nil {
+			return q, fmt.Errorf("invalid all=%q", all)
+		}
+		q.CurrentBranchOnly = !b
+	}
+	if missing := v.Get("missing"); missing != "" {
+		b, err := strconv.ParseBool(missing)
+		if err != nil {
+			return q, fmt.Errorf("invalid missing=%q", missing)
+		}
+		q.Missing = b
+	}
+	if unmerged := v.Get("unmerged"); unmerged != "" {
+		b, err := strconv.ParseBool(unmerged)
+		if err != nil {
+			return q, fmt.Errorf("invalid unmerged=%q", unmerged)
+		}
+		q.Unmerged = b
+	}
+	if max := v.Get("max"); max != "" {
+		n, err := strconv.Atoi(max)
+		if err != nil || n < 0 {
+			return q, fmt.Errorf("invalid max=%q", max)
+		}
+		q.MaxCommits = n
+	}
+
+	var err error
+	if q.Since, err = report.ParseDate(v.Get("since"), false, loc); err != nil {
+		return q, err
+	}
+	if q.Until, err = report.ParseDate(v.Get("until"), true, loc); err != nil {
+		return q, err
+	}
+	return q, nil
+}
+
+// cutLast splits "a/b/commits" into ("a/b", "commits"). A bare action means the root itself.
+func cutLast(rest string) (string, string, bool) {
+	rest = strings.Trim(rest, "/")
+	if rest == "" {
+		return "", "", false
+	}
+	if i := strings.LastIndex(rest, "/"); i >= 0 {
+		return rest[:i], rest[i+1:], true
+	}
+	return ".", rest, true
+}
+
+func attachment(path string) string {
+	return fmt.Sprintf("attachment; filename=%q", filepath.Base(path))
+}
+
+// gatherErrorStatus blames the request for refs that don't exist and repositories
+// without history, and the server for anything else.
+func gatherErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, git.ErrNoSuchRef):
+		return http.StatusBadRequest
+	case errors.Is(err, git.ErrEmptyRepo), errors.Is(err, git.ErrNotARepo):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
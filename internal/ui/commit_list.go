@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/paginator"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+// commitItem adapts a commit to the bubbles list.
+type commitItem struct {
+	commit  models.CommitInfo
+	ordinal int // 1-based position among the listed commits, ignoring group headers
+}
+
+func (i commitItem) FilterValue() string { return i.commit.Message }
+
+// groupHeader separates the commits of one day, week or month.
+type groupHeader struct {
+	label string
+	count int
+}
+
+func (h groupHeader) FilterValue() string { return "" }
+
+// commitDelegate renders each commit as a two-line row: hash and subject, then author and flags.
+type commitDelegate struct {
+	dco    bool
+	query  string          // lowercased search filter whose matches are highlighted
+	marked map[string]bool // hashes picked for export, shared with the screen
+
+	hyperlinks bool // link hashes to the code host with OSC 8
+}
+
+func (d commitDelegate) Height() int                             { return 2 }
+func (d commitDelegate) Spacing() int                            { return 1 }
+func (d commitDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d commitDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if h, ok := item.(groupHeader); ok {
+		count := trf("%d commits", h.count)
+		if h.count == 1 {
+			count = tr("1 commit")
+		}
+		if accessible {
+			fmt.Fprint(w, trf("Period %s: %s", h.label, count))
+		} else {
+			fmt.Fprint(w, dimmedStyle.Render(fmt.Sprintf("── %s (%s) ──", h.label, count)))
+		}
+		return
+	}
+	c := item.(commitItem).commit
+	if accessible {
+		d.renderLabeled(w, index == m.Index(), c)
+		return
+	}
+
+	cursor := "  "
+	if index == m.Index() {
+		cursor = highlightStyle.Render("> ")
+	}
+	mark := "○ "
+	if d.marked[c.Hash] {
+		mark = commitAuthorStyle.Render("● ")
+	}
+
+	message := c.Message
+	if limit := m.Width() - 20; limit > 10 {
+		message = truncate(message, limit)
+	}
+	message = highlightMatches(message, d.query, lipgloss.NewStyle())
+	if cc, ok := utils.ParseConventional(c.Message); ok {
+		badge := cc.Type
+		if cc.Breaking {
+			badge += "!"
+		}
+		message = typeBadgeStyle(cc.Type).Render(badge) + " " + message
+	}
+
+	hash := highlightMatches(shortHash(c.Hash), d.query, commitHashStyle)
+	if d.hyperlinks && c.URL != "" {
+		hash = hyperlink(c.URL, hash)
+	}
+
+	meta := []string{commitAuthorStyle.Render(c.Author), c.Date}
+	if c.Unmerged {
+		meta = append(meta, warningTextStyle.Render("⚠ "+tr("unmerged")))
+	}
+	if d.dco && utils.DCOStatus(c) != utils.DCOSigned {
+		meta = append(meta, warningTextStyle.Render("✗ DCO"))
+	}
+
+	fmt.Fprintf(w, "%s%s%s %s\n    %s", cursor, mark, hash, message, strings.Join(meta, dimmedStyle.Render(" · ")))
+}
+
+// renderLabeled is the screen-reader form of a row: words instead of glyphs and
+// colors, and every field named.
+func (d commitDelegate) renderLabeled(w io.Writer, selected bool, c models.CommitInfo) {
+	cursor := "  "
+	if selected {
+		cursor = "> "
+	}
+	mark := ""
+	if d.marked[c.Hash] {
+		mark = tr("marked") + ", "
+	}
+	meta := []string{trf("author %s", c.Author), trf("date %s", c.Date)}
+	if c.Unmerged {
+		meta = append(meta, tr("not merged"))
+	}
+	if d.dco && utils.DCOStatus(c) != utils.DCOSigned {
+		meta = append(meta, tr("sign-off missing"))
+	}
+	fmt.Fprintf(w, "%s%s, %s%s\n    %s", cursor, trf("Commit %s", shortHash(c.Hash)), mark, c.Message, strings.Join(meta, ", "))
+}
+
+// newCommitList builds a list with the results-screen key map; letter keys stay free for screen actions.
+func newCommitList(commits []models.CommitInfo, delegate commitDelegate) list.Model {
+	l := list.New(nil, delegate, 0, 0)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.SetFilteringEnabled(false)
+	l.DisableQuitKeybindings()
+	// Items are added only after switching to the "page/total" paginator: the default dots
+	// render one glyph per page, which costs seconds on large histories.
+	l.Paginator.Type = paginator.Arabic
+	l.SetItems(commitItems(commits, ""))
+
+	l.KeyMap.CursorUp = key.NewBinding(key.WithKeys("up", "k"))
+	l.KeyMap.CursorDown = key.NewBinding(key.WithKeys("down", "j"))
+	l.KeyMap.PrevPage = key.NewBinding(key.WithKeys("left", "pgup"))
+	l.KeyMap.NextPage = key.NewBinding(key.WithKeys("right", "pgdown"))
+	l.KeyMap.GoToStart = key.NewBinding(key.WithKeys("home"))
+	l.KeyMap.GoToEnd = key.NewBinding(key.WithKeys("end"))
+	l.KeyMap.ShowFullHelp.SetEnabled(false)
+	l.KeyMap.CloseFullHelp.SetEnabled(false)
+
+	return l
+}
+
+// hyperlink wraps text in an OSC 8 link; terminals without support show text alone.
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// commitItems lists commits, preceded by a header per period when groupBy is set.
+func commitItems(commits []models.CommitInfo, groupBy string) []list.Item {
+	if groupBy == "" {
+		items := make([]list.Item, len(commits))
+		for i, c := range commits {
+			items[i] = commitItem{commit: c, ordinal: i + 1}
+		}
+		return items
+	}
+
+	var items []list.Item
+	ordinal := 0
+	for _, g := range utils.GroupCommits(commits, groupBy) {
+		label := g.Label
+		if label == "" {
+			label = tr("undated")
+		}
+		items = append(items, groupHeader{label: label, count: len(g.Commits)})
+		for _, c := range g.Commits {
+			ordinal++
+			items = append(items, commitItem{commit: c, ordinal: ordinal})
+		}
+	}
+	return items
+}
+
+// highlightMatches renders text in base with each case-insensitive occurrence of the
+// lowercased query picked out in searchMatchStyle.
+func highlightMatches(text, query string, base lipgloss.Style) string {
+	lower := strings.ToLower(text)
+	// Offsets are only shared when lowercasing kept every byte in place.
+	if query == "" || len(lower) != len(text) {
+		return base.Render(text)
+	}
+
+	var out strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			break
+		}
+		end := i + len(query)
+		if i > 0 {
+			out.WriteString(base.Render(text[:i]))
+		}
+		out.WriteString(searchMatchStyle.Render(text[i:end]))
+		text, lower = text[end:], lower[end:]
+	}
+	if text != "" {
+		out.WriteString(base.Render(text))
+	}
+	return out.String()
+}
+
+// searchText is what the results filter matches against: hash, message and file paths, lowercased.
+func searchText(c models.CommitInfo) string {
+	return strings.ToLower(c.Hash + "\n" + c.Message + "\n" + strings.Join(c.Files, "\n"))
+}
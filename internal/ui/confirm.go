@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// confirmDialog is a yes or no question shown over the active screen, which gets
+// no keys until it is answered. onYes runs when the answer is yes.
+type confirmDialog struct {
+	question string
+	onYes    tea.Cmd
+}
+
+// confirmMsg asks the model to open a confirmDialog.
+type confirmMsg confirmDialog
+
+// confirmCmd opens a dialog asking question and runs onYes if the user agrees.
+func confirmCmd(question string, onYes tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return confirmMsg{question: question, onYes: onYes}
+	}
+}
+
+// answer reports whether msg answers the dialog and, if it was a yes, what to run.
+// Y and Enter accept; N, Esc and the back key decline.
+func (d confirmDialog) answer(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch {
+	case msg.Type == tea.KeyEnter, strings.EqualFold(msg.String(), "y"):
+		return d.onYes, true
+	case msg.Type == tea.KeyEsc, strings.EqualFold(msg.String(), "n"), key.Matches(msg, keys.Back):
+		return nil, true
+	}
+	return nil, false
+}
+
+func (d confirmDialog) view() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Width(0).Render(tr("Are you sure?")) + "\n")
+	b.WriteString(tr(d.question) + "\n\n")
+	b.WriteString(pressText(
+		trf("%s to confirm", highlightStyle.Render("Y")),
+		trf("%s to cancel", highlightStyle.Render("N"))))
+	return helpPanelStyle.Render(b.String())
+}
+
+// busy returns why quitting from screen needs confirming: the fetch or export it
+// is still running. It is empty when nothing would be lost.
+func busy(screen ScreenModel) string {
+	switch s := screen.(type) {
+	case *optionsScreen:
+		if s.fetching {
+			return "Commits are still being fetched. Quit anyway?"
+		}
+	case *resultsScreen:
+		if s.fetching {
+			return "Commits are still being fetched. Quit anyway?"
+		}
+		if s.exporting {
+			return "An export is still running. Quit anyway?"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// splitMinWidth is the narrowest terminal that still fits two readable columns.
+const splitMinWidth = 120
+
+// highlightPatch colors the output of git show: file and hunk headers get fixed
+// styles, and the code in each hunk is highlighted with a lexer chosen from the
+// file name, keeping the +/- marker in the added/removed color.
+func highlightPatch(patch string) string {
+	var out strings.Builder
+	lexer := lexers.Fallback
+	inHunk := false
+
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			inHunk = false
+			lexer = patchLexer(line)
+			out.WriteString(diffFileStyle.Render(line))
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+			out.WriteString(diffHunkStyle.Render(line))
+		case inHunk && isHunkLine(line):
+			out.WriteString(diffMarker(line[0]) + highlightCode(lexer, line[1:]))
+		default:
+			out.WriteString(line)
+		}
+		out.WriteString("\n")
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// highlightSplitPatch renders patch with removed lines on the left and added lines on
+// the right, pairing each run of removals with the additions that follow it.
+func highlightSplitPatch(patch string, width int) string {
+	column := (width - 3) / 2
+	var out strings.Builder
+	var removed, added []string
+	lexer := lexers.Fallback
+	inHunk := false
+
+	row := func(left, right string) {
+		out.WriteString(left + dimmedStyle.Render(" │ ") + right + "\n")
+	}
+	flush := func() {
+		for i := 0; i < max(len(removed), len(added)); i++ {
+			left, right := strings.Repeat(" ", column), strings.Repeat(" ", column)
+			if i < len(removed) {
+				left = splitCell(lexer, '-', removed[i], column)
+			}
+			if i < len(added) {
+				right = splitCell(lexer, '+', added[i], column)
+			}
+			row(left, right)
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	for _, line := range strings.Split(patch, "\n") {
+		if inHunk && isHunkLine(line) {
+			switch line[0] {
+			case '-':
+				if len(added) > 0 {
+					flush()
+				}
+				removed = append(removed, line[1:])
+			case '+':
+				added = append(added, line[1:])
+			default:
+				flush()
+				cell := splitCell(lexer, ' ', line[1:], column)
+				row(cell, cell)
+			}
+			continue
+		}
+
+		flush()
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			inHunk = false
+			lexer = patchLexer(line)
+			out.WriteString(diffFileStyle.Render(line))
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+			out.WriteString(diffHunkStyle.Render(line))
+		default:
+			out.WriteString(line)
+		}
+		out.WriteString("\n")
+	}
+	flush()
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// splitCell highlights one side of a split row, cut and padded to exactly width columns.
+func splitCell(lexer chroma.Lexer, marker byte, code string, width int) string {
+	code = ansi.Truncate(strings.ReplaceAll(code, "\t", "    "), width-1, "…")
+	cell := diffMarker(marker) + highlightCode(lexer, code)
+	return cell + strings.Repeat(" ", max(width-lipgloss.Width(cell), 0))
+}
+
+func isHunkLine(line string) bool {
+	return line != "" && strings.ContainsRune("+- ", rune(line[0]))
+}
+
+func diffMarker(marker byte) string {
+	switch marker {
+	case '+':
+		return diffAddedStyle.Render("+")
+	case '-':
+		return diffRemovedStyle.Render("-")
+	}
+	return string(marker)
+}
+
+// patchLexer picks a lexer from the "b/" path of a diff --git header.
+func patchLexer(header string) chroma.Lexer {
+	if i := strings.LastIndex(header, " b/"); i >= 0 {
+		if lexer := lexers.Match(header[i+3:]); lexer != nil {
+			return chroma.Coalesce(lexer)
+		}
+	}
+	return lexers.Fallback
+}
+
+func highlightCode(lexer chroma.Lexer, code string) string {
+	if plain {
+		return code
+	}
+	iterator, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		return code
+	}
+	var out strings.Builder
+	if err := formatters.TTY256.Format(&out, styles.Get(theme.Syntax), iterator); err != nil {
+		return code
+	}
+	return strings.TrimRight(out.String(), "\n")
+}
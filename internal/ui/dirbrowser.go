@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+// dirEntry is a subdirectory listed by dirBrowser.
+type dirEntry struct {
+	name string
+	repo bool // it holds a .git directory or file
+}
+
+// dirBrowser walks the filesystem one directory at a time, flagging the
+// directories that are Git repositories.
+type dirBrowser struct {
+	dir      string
+	entries  []dirEntry
+	selected int
+	err      error
+}
+
+func newDirBrowser(start string) dirBrowser {
+	dir, err := utils.ResolveDir(start)
+	if err != nil {
+		dir = "."
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	b := dirBrowser{}
+	b.open(dir)
+	return b
+}
+
+// open lists the subdirectories of dir, hidden ones left out.
+func (b *dirBrowser) open(dir string) {
+	b.dir, b.entries, b.selected, b.err = dir, nil, 0, nil
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		b.err = err
+		return
+	}
+	for _, f := range files {
+		if !f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		b.entries = append(b.entries, dirEntry{name: f.Name(), repo: isRepoDir(filepath.Join(dir, f.Name()))})
+	}
+	sort.Slice(b.entries, func(i, j int) bool {
+		return strings.ToLower(b.entries[i].name) < strings.ToLower(b.entries[j].name)
+	})
+}
+
+// isRepoDir is a cheap stand-in for git.GitService.IsGitRepo, fast enough to run
+// on every directory listed.
+func isRepoDir(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// update moves through the directories. Enter on a repository picks it; otherwise
+// Enter and → go into the selected directory and ← or Backspace go up.
+func (b dirBrowser) update(msg tea.KeyMsg) (dirBrowser, string, bool) {
+	switch msg.Type {
+	case tea.KeyUp:
+		b.selected = max(b.selected-1, 0)
+	case tea.KeyDown:
+		b.selected = max(min(b.selected+1, len(b.entries)-1), 0)
+	case tea.KeyHome:
+		b.selected = 0
+	case tea.KeyEnd:
+		b.selected = max(len(b.entries)-1, 0)
+	case tea.KeyEnter, tea.KeyRight:
+		if len(b.entries) == 0 {
+			if msg.Type == tea.KeyEnter && isRepoDir(b.dir) {
+				return b, b.dir, true
+			}
+			break
+		}
+		e := b.entries[b.selected]
+		path := filepath.Join(b.dir, e.name)
+		if msg.Type == tea.KeyEnter && e.repo {
+			return b, path, true
+		}
+		b.open(path)
+	case tea.KeyLeft, tea.KeyBackspace:
+		parent := filepath.Dir(b.dir)
+		if parent == b.dir {
+			break
+		}
+		from := filepath.Base(b.dir)
+		b.open(parent)
+		for i, e := range b.entries {
+			if e.name == from {
+				b.selected = i
+			}
+		}
+	}
+	return b, "", false
+}
+
+func (b dirBrowser) view(height int) string {
+	var content strings.Builder
+	content.WriteString(highlightStyle.Render(truncatePathLeft(b.dir, 60)) + "\n")
+	if b.err != nil {
+		content.WriteString(warningTextStyle.Render(b.err.Error()) + "\n")
+		return content.String()
+	}
+	if len(b.entries) == 0 {
+		content.WriteString(dimmedStyle.Render(tr("No subdirectories.")) + "\n")
+		return content.String()
+	}
+
+	// Keep the selection visible when there are more directories than rows.
+	maxRows := max(height-24, 5)
+	start := 0
+	if b.selected >= maxRows {
+		start = b.selected - maxRows + 1
+	}
+	end := min(start+maxRows, len(b.entries))
+	for i := start; i < end; i++ {
+		e := b.entries[i]
+		row := truncate(e.name, 50) + string(filepath.Separator)
+		if e.repo {
+			row += " " + commitAuthorStyle.Render("("+tr("git repository")+")")
+		}
+		if i == b.selected {
+			content.WriteString(highlightStyle.Render("> ") + row + "\n")
+		} else {
+			content.WriteString("  " + row + "\n")
+		}
+	}
+	if end < len(b.entries) {
+		content.WriteString(dimmedStyle.Render("  "+trf("...and %d more directories", len(b.entries)-end)) + "\n")
+	}
+	return content.String()
+}
+
+// completePath completes the last element of path to the subdirectories it
+// prefixes, as far as they agree.
+func completePath(path string) string {
+	dir, prefix := filepath.Split(path)
+	listDir := dir
+	if listDir == "" {
+		listDir = "."
+	}
+	files, err := os.ReadDir(listDir)
+	if err != nil {
+		return path
+	}
+
+	var matches []string
+	for _, f := range files {
+		if f.IsDir() && strings.HasPrefix(f.Name(), prefix) && (prefix != "" || !strings.HasPrefix(f.Name(), ".")) {
+			matches = append(matches, f.Name())
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return path
+	case 1:
+		return dir + matches[0] + string(filepath.Separator)
+	}
+	common := matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	for !utf8.ValidString(common) {
+		common = common[:len(common)-1]
+	}
+	return dir + common
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// errorDetailKey expands the last error into its detail panel.
+const errorDetailKey = "!"
+
+// gitFailure returns the failed git command inside err, if there is one.
+func gitFailure(err error) (*git.CommandError, bool) {
+	var cmdErr *git.CommandError
+	ok := errors.As(err, &cmdErr)
+	return cmdErr, ok
+}
+
+// errorDetailView shows everything about an error that the message line cuts
+// short: the failed git command and all that git printed on stderr.
+func errorDetailView(e models.ErrorMsg, width, height int) string {
+	lineWidth := max(min(width-10, 100), 20)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Width(0).Render(tr("Error details")) + "\n")
+	b.WriteString(warningTextStyle.Width(lineWidth).Render(trf("Error (%s): %v", tr(e.Context), e.Err)) + "\n")
+	if advice := git.Advice(e.Err); advice != "" {
+		b.WriteString(tr(advice) + "\n")
+	}
+
+	if cmdErr, ok := gitFailure(e.Err); ok {
+		b.WriteString("\n" + dimmedStyle.Render(tr("Command")) + "\n")
+		b.WriteString(truncate(cmdErr.Command(), lineWidth) + "\n")
+		b.WriteString("\n" + dimmedStyle.Render(trf("Git output (%v)", cmdErr.Err)) + "\n")
+		lines := strings.Split(cmdErr.Stderr, "\n")
+		if cmdErr.Stderr == "" {
+			lines = []string{tr("(nothing on stderr)")}
+		}
+		rows := max(height-16, 3)
+		for i, line := range lines {
+			if i == rows {
+				b.WriteString(dimmedStyle.Render(trf("...and %d more lines", len(lines)-rows)) + "\n")
+				break
+			}
+			b.WriteString(truncate(line, lineWidth) + "\n")
+		}
+	}
+	b.WriteString("\n" + dimmedStyle.Render(tr("Press any key to close.")))
+	return helpPanelStyle.Render(b.String())
+}
@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leeozaka/gommits/internal/git"
+)
+
+// fuzzyItem is one choice offered by a fuzzyList: Label is matched against the
+// query, Detail is shown dimmed beside it.
+type fuzzyItem struct {
+	Label  string
+	Detail string
+}
+
+// fuzzyMatch is an item matching the query, with the runes of Label that matched.
+type fuzzyMatch struct {
+	index     int
+	score     int
+	positions []int
+}
+
+// fuzzyScore matches query against s the way fzf does: its runes must appear in
+// s in order, ignoring case, and runs of consecutive runes or runes starting a
+// word score higher. ok is false when query does not match.
+func fuzzyScore(s, query string) (score int, positions []int, ok bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return 0, nil, true
+	}
+	runes := []rune(s)
+	qi, last := 0, -2
+	for i, r := range runes {
+		if qi == len(q) {
+			break
+		}
+		if unicode.ToLower(r) != q[qi] {
+			continue
+		}
+		score++
+		if i == last+1 {
+			score += 5
+		}
+		if i == 0 || strings.ContainsRune(" /\\-_.@<", runes[i-1]) {
+			score += 8
+		}
+		positions = append(positions, i)
+		last = i
+		qi++
+	}
+	if qi < len(q) {
+		return 0, nil, false
+	}
+	// Among equal matches, prefer those starting earlier.
+	return score*100 - positions[0], positions, true
+}
+
+// fuzzyList narrows items to those matching a query, best matches first, and
+// keeps one of them selected.
+type fuzzyList struct {
+	items    []fuzzyItem
+	query    string
+	matches  []fuzzyMatch
+	selected int
+}
+
+func newFuzzyList(items []fuzzyItem) fuzzyList {
+	l := fuzzyList{items: items}
+	l.setQuery("")
+	return l
+}
+
+func (l *fuzzyList) setQuery(query string) {
+	l.query = query
+	l.matches = nil
+	for i, item := range l.items {
+		if score, positions, ok := fuzzyScore(item.Label, query); ok {
+			l.matches = append(l.matches, fuzzyMatch{index: i, score: score, positions: positions})
+		}
+	}
+	sort.SliceStable(l.matches, func(i, j int) bool {
+		return l.matches[i].score > l.matches[j].score
+	})
+	l.selected = 0
+}
+
+// chosen returns the index in items of the selected match.
+func (l fuzzyList) chosen() (int, bool) {
+	if len(l.matches) == 0 {
+		return 0, false
+	}
+	return l.matches[l.selected].index, true
+}
+
+// move handles the keys that change the selection, reporting whether msg was one.
+func (l *fuzzyList) move(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyUp, tea.KeyCtrlP:
+		l.selected = max(l.selected-1, 0)
+	case tea.KeyDown, tea.KeyCtrlN:
+		l.selected = max(min(l.selected+1, len(l.matches)-1), 0)
+	case tea.KeyPgUp:
+		l.selected = max(l.selected-10, 0)
+	case tea.KeyPgDown:
+		l.selected = max(min(l.selected+10, len(l.matches)-1), 0)
+	default:
+		return false
+	}
+	return true
+}
+
+// view lists up to rows matches around the selection, with the matched runes
+// highlighted.
+func (l fuzzyList) view(rows, width int) string {
+	if len(l.matches) == 0 {
+		return dimmedStyle.Render(trf("Nothing matches %q.", l.query)) + "\n"
+	}
+
+	start := 0
+	if l.selected >= rows {
+		start = l.selected - rows + 1
+	}
+	end := min(start+rows, len(l.matches))
+
+	var b strings.Builder
+	for i := start; i < end; i++ {
+		m := l.matches[i]
+		item := l.items[m.index]
+		row := highlightPositions(item.Label, m.positions)
+		if item.Detail != "" {
+			row += "  " + dimmedStyle.Render(item.Detail)
+		}
+		if width > 0 {
+			row = truncate(row, width-2)
+		}
+		if i == l.selected {
+			b.WriteString(highlightStyle.Render("> ") + row + "\n")
+		} else {
+			b.WriteString("  " + row + "\n")
+		}
+	}
+	if end < len(l.matches) {
+		b.WriteString(dimmedStyle.Render("  "+trf("...and %d more", len(l.matches)-end)) + "\n")
+	}
+	return b.String()
+}
+
+// highlightPositions marks the runes of s at positions as search matches.
+func highlightPositions(s string, positions []int) string {
+	if len(positions) == 0 {
+		return s
+	}
+	var b strings.Builder
+	next := 0
+	for i, r := range []rune(s) {
+		if next < len(positions) && positions[next] == i {
+			b.WriteString(searchMatchStyle.Render(string(r)))
+			next++
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// fuzzyPicker is a fuzzyList with its own query field, like fzf.
+type fuzzyPicker struct {
+	input textinput.Model
+	list  fuzzyList
+}
+
+func newFuzzyPicker(placeholder string, items []fuzzyItem) fuzzyPicker {
+	input := newTextInput()
+	input.Prompt = "/"
+	input.Placeholder = placeholder
+	input.CharLimit = 256
+	input.Width = 50
+	input.Focus()
+	return fuzzyPicker{input: input, list: newFuzzyList(items)}
+}
+
+// update moves the selection or edits the query; Enter and Esc are left to the screen.
+func (p fuzzyPicker) update(msg tea.Msg) (fuzzyPicker, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && p.list.move(keyMsg) {
+		return p, nil
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != p.list.query {
+		p.list.setQuery(p.input.Value())
+	}
+	return p, cmd
+}
+
+func (p fuzzyPicker) view(rows, width int) string {
+	return p.input.View() + "\n" + p.list.view(rows, width)
+}
+
+// newBranchPicker offers the repository's branches, for choosing a parent branch.
+func newBranchPicker(svc git.GitService, dir string) (fuzzyPicker, error) {
+	branches, err := svc.Branches(dir)
+	if err != nil {
+		return fuzzyPicker{}, err
+	}
+	items := make([]fuzzyItem, len(branches))
+	for i, b := range branches {
+		items[i] = fuzzyItem{Label: b}
+	}
+	return newFuzzyPicker(tr("branch or any other ref"), items), nil
+}
+
+// branchPickerView renders a branch picker with its keys.
+func branchPickerView(p fuzzyPicker, width, height int) string {
+	return tr("Choose the parent branch:") + "\n\n" +
+		p.view(max(height-20, 5), width) + "\n" +
+		pressText(
+			trf("%s to select a branch", highlightStyle.Render("↑/↓")),
+			trf("%s to use it", highlightStyle.Render("Enter")),
+			trf("%s to cancel", highlightStyle.Render("Esc")),
+		) + "\n"
+}
+
+// picked is the label of the selected item or, when nothing matches, the query
+// itself, so refs that aren't listed can still be typed.
+func (p fuzzyPicker) picked() string {
+	if i, ok := p.list.chosen(); ok {
+		return p.list.items[i].Label
+	}
+	return strings.TrimSpace(p.input.Value())
+}
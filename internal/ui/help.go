@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpLine is one key and what it does on the help overlay.
+type helpLine struct {
+	Key  string
+	Desc string
+}
+
+// keyHelper is implemented by screens that list their keys on the help overlay.
+type keyHelper interface {
+	keyHelp() []helpLine
+}
+
+// helpView renders every key of the active screen, followed by the keys that work
+// everywhere, in a panel that replaces the screen until a key is pressed.
+func (m model) helpView() string {
+	var lines []helpLine
+	if h, ok := m.activeScreen.(keyHelper); ok {
+		lines = h.keyHelp()
+	}
+	global := []helpLine{
+		{helpKey(keys.Back), "go back"},
+		{helpKey(keys.Quit), "quit"},
+		{"Ctrl+C", "quit from anywhere"},
+		{"?", "show this help"},
+		{errorDetailKey, "show the last error's details"},
+	}
+
+	width := 0
+	for _, l := range append(lines, global...) {
+		width = max(width, lipgloss.Width(l.Key))
+	}
+	section := func(b *strings.Builder, lines []helpLine) {
+		for _, l := range lines {
+			b.WriteString(highlightStyle.Render(l.Key+strings.Repeat(" ", width-lipgloss.Width(l.Key))) + "  " + tr(l.Desc) + "\n")
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Width(0).Render(tr("Keys")) + "\n")
+	section(&b, lines)
+	if len(lines) > 0 {
+		b.WriteString("\n")
+	}
+	section(&b, global)
+	b.WriteString("\n" + dimmedStyle.Render(tr("Press any key to close.")))
+	return helpPanelStyle.Render(b.String())
+}
@@ -1,107 +1,200 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/leeozaka/gommits/internal/browser"
+	"github.com/leeozaka/gommits/internal/clipboard"
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/delivery"
 	"github.com/leeozaka/gommits/internal/git"
 	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/internal/report"
 	"github.com/leeozaka/gommits/pkg/utils"
 )
 
-type authorResult struct {
-	commits []models.CommitInfo
-	branch  string
-	err     error
+func fetchCommitsCmd(svc git.GitService, dir, author string, maxCommits int, currentBranchOnly, missing, unmerged bool, parentBranch string, dotnetMode bool) tea.Cmd {
+	return func() tea.Msg {
+		return fetchCommits(svc, dir, author, maxCommits, currentBranchOnly, missing, unmerged, parentBranch, dotnetMode, nil)
+	}
 }
 
-func fetchCommitsCmd(svc git.GitService, dir, author string, maxCommits int, currentBranchOnly bool, parentBranch string, dotnetMode bool) tea.Cmd {
+// progressMsg tells how far a withProgress task has got; next delivers the
+// following update, and finally the task's own message.
+type progressMsg struct {
+	done, total int
+	next        <-chan tea.Msg
+}
+
+// withProgress runs task in the background, sending a progressMsg for its progress
+// calls and then the message it returns.
+func withProgress(task func(progress func(done, total int)) tea.Msg) tea.Cmd {
 	return func() tea.Msg {
-		authors := splitAuthors(author)
-
-		var allCommits []models.CommitInfo
-		var branch string
-		var err error
-
-		if len(authors) == 0 {
-			allCommits, branch, err = svc.GatherCommits(dir, "", parentBranch, currentBranchOnly)
-		} else if len(authors) == 1 {
-			allCommits, branch, err = svc.GatherCommits(dir, authors[0], parentBranch, currentBranchOnly)
-		} else {
-			results := make([]authorResult, len(authors))
-			var wg sync.WaitGroup
-			wg.Add(len(authors))
-
-			for i, a := range authors {
-				go func(idx int, authorName string) {
-					defer wg.Done()
-					c, b, e := svc.GatherCommits(dir, authorName, parentBranch, currentBranchOnly)
-					results[idx] = authorResult{commits: c, branch: b, err: e}
-				}(i, a)
+		updates := make(chan tea.Msg, 1)
+		progress := func(done, total int) {
+			// Skip updates while the UI still has one to draw; a later one follows.
+			select {
+			case updates <- progressMsg{done: done, total: total, next: updates}:
+			default:
 			}
+		}
+		go func() {
+			updates <- task(progress)
+		}()
+		return <-updates
+	}
+}
 
-			wg.Wait()
-
-			seen := make(map[string]bool)
-			for _, r := range results {
-				if r.err != nil {
-					err = r.err
-					break
-				}
-				if r.branch != "" {
-					branch = r.branch
-				}
-				for _, c := range r.commits {
-					if !seen[c.Hash] {
-						seen[c.Hash] = true
-						allCommits = append(allCommits, c)
-					}
-				}
-			}
+func waitForProgressCmd(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+// fetchCommitsProgressCmd is fetchCommitsCmd sending progressMsgs on the way.
+func fetchCommitsProgressCmd(svc git.GitService, dir, author string, maxCommits int, currentBranchOnly, missing, unmerged bool, parentBranch string, dotnetMode bool) tea.Cmd {
+	return withProgress(func(progress func(done, total int)) tea.Msg {
+		return fetchCommits(svc, dir, author, maxCommits, currentBranchOnly, missing, unmerged, parentBranch, dotnetMode, progress)
+	})
+}
+
+func fetchCommits(svc git.GitService, dir, author string, maxCommits int, currentBranchOnly, missing, unmerged bool, parentBranch string, dotnetMode bool, progress func(done, total int)) models.FetchCommitsMsg {
+	var onProgress func(git.Progress)
+	if progress != nil {
+		onProgress = func(p git.Progress) {
+			progress(p.Commits, p.Total)
 		}
+	}
+	res, err := report.Gather(svc, report.Query{
+		Dir:               dir,
+		Author:            author,
+		MaxCommits:        maxCommits,
+		CurrentBranchOnly: currentBranchOnly,
+		Missing:           missing,
+		Unmerged:          unmerged,
+		ParentBranch:      parentBranch,
+		DotnetMode:        dotnetMode,
+		OnProgress:        onProgress,
+	})
+	return models.FetchCommitsMsg{
+		Commits:      res.Commits,
+		Branch:       res.Branch,
+		ParentBranch: parentBranch,
+		DotnetMode:   dotnetMode,
+		Err:          err,
+
+		Author:            author,
+		MaxCommits:        maxCommits,
+		CurrentBranchOnly: currentBranchOnly,
+		Missing:           missing,
+		Unmerged:          unmerged,
+	}
+}
 
-		if err == nil && maxCommits > 0 && len(allCommits) > maxCommits {
-			allCommits = allCommits[:maxCommits]
+func enrichCommitsCmd(svc git.GitService, cfg config.Config, dir string, commits []models.CommitInfo) tea.Cmd {
+	return func() tea.Msg {
+		enriched, err := report.Enrich(svc, cfg, dir, commits)
+		return models.EnrichCommitsMsg{Commits: enriched, Err: err}
+	}
+}
+
+// exportExcelCmd exports commits in the configured formats, sending progressMsgs
+// for the rows written.
+func exportExcelCmd(svc git.GitService, cfg config.Config, commits []models.CommitInfo, repoPath, branch string) tea.Cmd {
+	return withProgress(func(progress func(done, total int)) tea.Msg {
+		repoName := svc.GetRepositoryName(repoPath)
+		artifacts, err := utils.ExportCommits(commits, repoPath, repoName, cfg, progress)
+		if err != nil {
+			return models.ExportExcelMsg{Path: lastArtifact(artifacts), Err: err}
 		}
-		if err == nil && dotnetMode {
-			allCommits = utils.ResolveProjects(dir, allCommits)
+		delivered, err := delivery.Deliver(cfg, delivery.Report{
+			RepoName:  repoName,
+			Branch:    branch,
+			Commits:   commits,
+			Artifacts: artifacts,
+		})
+		return models.ExportExcelMsg{Count: len(commits), Path: lastArtifact(artifacts), Delivered: delivered, Err: err}
+	})
+}
+
+func loadContributorsCmd(svc git.GitService, dir string) tea.Cmd {
+	return func() tea.Msg {
+		contributors, err := svc.Contributors(dir)
+		return contributorsMsg{contributors: contributors, err: err}
+	}
+}
+
+// saveRecentReposCmd remembers recent for the home and directory screens of later runs.
+func saveRecentReposCmd(recent []string) tea.Cmd {
+	return func() tea.Msg {
+		if err := config.SaveRecentRepos(recent); err != nil {
+			return models.NewError(err, "saving recent repositories")
 		}
-		return models.FetchCommitsMsg{
-			Commits:      allCommits,
-			Branch:       branch,
-			ParentBranch: parentBranch,
-			DotnetMode:   dotnetMode,
-			Err:          err,
+		return nil
+	}
+}
+
+func copyChangelogCmd(cfg config.Config, commits []models.CommitInfo) tea.Cmd {
+	return func() tea.Msg {
+		section := utils.RenderChangelog(commits, cfg.Export.Changelog, time.Now())
+		if err := clipboard.Write(section); err != nil {
+			return models.NewError(err, "copying changelog")
 		}
+		return models.ShowToastMsg{Message: "📋 " + tr("Changelog copied to clipboard"), Type: models.ToastSuccess, Duration: 3 * time.Second}
 	}
 }
 
-func splitAuthors(input string) []string {
-	if strings.TrimSpace(input) == "" {
-		return nil
+// copyCherryPickCmd copies a git cherry-pick line for commits, oldest first so they
+// apply in their original order.
+func copyCherryPickCmd(commits []models.CommitInfo) tea.Cmd {
+	return func() tea.Msg {
+		hashes := make([]string, len(commits))
+		for i, c := range commits {
+			hashes[len(commits)-1-i] = c.Hash
+		}
+		if err := clipboard.Write("git cherry-pick " + strings.Join(hashes, " ")); err != nil {
+			return models.NewError(err, "copying cherry-pick command")
+		}
+		return models.ShowToastMsg{
+			Message:  "🍒 " + trf("cherry-pick of %d commits copied to clipboard", len(commits)),
+			Type:     models.ToastSuccess,
+			Duration: 3 * time.Second,
+		}
 	}
-	parts := strings.Split(input, ",")
-	var result []string
-	for _, p := range parts {
-		trimmed := strings.TrimSpace(p)
-		if trimmed != "" {
-			result = append(result, trimmed)
+}
+
+func openCommitCmd(c models.CommitInfo) tea.Cmd {
+	return func() tea.Msg {
+		if c.URL == "" {
+			return models.NewError(fmt.Errorf("no web page known for %s: the remote is not on a recognized code host", shortHash(c.Hash)), "opening commit")
+		}
+		if err := browser.Open(c.URL); err != nil {
+			return models.NewError(err, "opening commit")
 		}
+		return nil
 	}
-	return result
 }
 
-func exportExcelCmd(svc git.GitService, commits []models.CommitInfo, repoPath string) tea.Cmd {
+func exportComparisonCmd(svc git.GitService, cmp utils.AuthorComparison, repoPath string) tea.Cmd {
 	return func() tea.Msg {
-		repoName := svc.GetRepositoryName(repoPath)
-		err := utils.ExportToExcel(commits, repoPath, repoName)
-		return models.ExportExcelMsg{Path: repoPath, Err: err}
+		path := utils.ComparisonPath(utils.ExportDir(repoPath), svc.GetRepositoryName(repoPath))
+		return models.ExportExcelMsg{Path: path, Err: utils.ExportComparison(cmp, path)}
+	}
+}
+
+func lastArtifact(artifacts []string) string {
+	if len(artifacts) == 0 {
+		return ""
 	}
+	return artifacts[len(artifacts)-1]
 }
 
-func exportDotnetExcelCmd(svc git.GitService, commits []models.CommitInfo, repoPath, branch, parentBranch string) tea.Cmd {
+func exportDotnetExcelCmd(svc git.GitService, cfg config.Config, commits []models.CommitInfo, repoPath, branch, parentBranch string) tea.Cmd {
 	return func() tea.Msg {
 		repoName := svc.GetRepositoryName(repoPath)
 
@@ -114,8 +207,21 @@ func exportDotnetExcelCmd(svc git.GitService, commits []models.CommitInfo, repoP
 
 		entries := utils.AggregateDotnetEntries(commits, branch, existsInParent)
 		up, down := utils.AggregateDBAEntries(commits, time.Now().Year())
-		err := utils.ExportDotnetExcel(entries, up, down, repoPath, repoName)
-		return models.ExportExcelMsg{Path: repoPath, Err: err}
+		dir := utils.ExportDir(repoPath)
+		if err := utils.ExportDotnetExcel(entries, up, down, dir, repoName); err != nil {
+			return models.ExportExcelMsg{Err: err}
+		}
+		artifacts, err := utils.FinalizeArtifacts([]string{utils.DotnetExcelPath(dir, repoName)}, dir, repoName, cfg.Export)
+		if err != nil {
+			return models.ExportExcelMsg{Path: lastArtifact(artifacts), Err: err}
+		}
+		delivered, err := delivery.Deliver(cfg, delivery.Report{
+			RepoName:  repoName,
+			Branch:    branch,
+			Commits:   commits,
+			Artifacts: artifacts,
+		})
+		return models.ExportExcelMsg{Count: len(commits), Path: lastArtifact(artifacts), Delivered: delivered, Err: err}
 	}
 }
 
@@ -125,6 +231,16 @@ func resetToHomeCmd() tea.Cmd {
 	}
 }
 
+// runToastActionCmd runs a toast's action, reporting its failure like any other.
+func runToastActionCmd(action *models.ToastAction) tea.Cmd {
+	return func() tea.Msg {
+		if err := action.Run(); err != nil {
+			return models.NewError(err, "running toast action")
+		}
+		return nil
+	}
+}
+
 func showToastCmd(message string, toastType models.ToastType, duration time.Duration) tea.Cmd {
 	return func() tea.Msg {
 		return models.ShowToastMsg{Message: message, Type: toastType, Duration: duration}
@@ -133,30 +249,63 @@ func showToastCmd(message string, toastType models.ToastType, duration time.Dura
 
 func boolToYesNo(b bool) string {
 	if b {
-		return "Yes"
+		return tr("Yes")
 	}
-	return "No"
+	return tr("No")
 }
 
 func modifyHelpText(enterAction string, includeBack bool, includeQuit bool, showTabHint bool) string {
 	var parts []string
 	if enterAction != "" {
-		parts = append(parts, highlightStyle.Render("Enter")+" to "+enterAction)
+		parts = append(parts, trf("%s to "+enterAction, highlightStyle.Render("Enter")))
 	}
 	if includeBack {
-		parts = append(parts, highlightStyle.Render("B")+" for back")
+		parts = append(parts, trf("%s for back", highlightStyle.Render(helpKey(keys.Back))))
 	}
 	if includeQuit {
-		parts = append(parts, highlightStyle.Render("Esc")+" to quit")
+		parts = append(parts, trf("%s to quit", highlightStyle.Render(helpKey(keys.Quit))))
 	}
 
 	var finalHelp string
 	if len(parts) > 0 {
-		finalHelp = "Press " + strings.Join(parts, ", ") + ".\n"
+		finalHelp = pressText(parts...) + "\n"
 	}
 
 	if showTabHint {
-		finalHelp += dimmedStyle.Render("Hint: Press Tab to use current directory (.).") + "\n"
+		finalHelp += dimmedStyle.Render(tr("Hint: Press Tab to complete the path or, when empty, use the current directory (.); Ctrl+O to browse.")) + "\n"
 	}
 	return finalHelp
 }
+
+// pressText joins "key to action" parts into one translated "Press ..." sentence.
+func pressText(parts ...string) string {
+	return trf("Press %s.", strings.Join(parts, ", "))
+}
+
+// newTextInput is textinput.New with a steady cursor in plain mode.
+func newTextInput() textinput.Model {
+	ti := textinput.New()
+	if plain {
+		ti.SetCursorMode(textinput.CursorStatic)
+	}
+	return ti
+}
+
+// symbol returns glyph, or nothing in accessible mode where the words around it suffice.
+func symbol(glyph string) string {
+	if accessible {
+		return ""
+	}
+	return glyph
+}
+
+// truncate shortens s to width terminal cells, ending it with an ellipsis. Wide
+// characters count twice and escape sequences not at all.
+func truncate(s string, width int) string {
+	return ansi.Truncate(s, width, "…")
+}
+
+// padRight fills s with spaces to width cells; fmt's %-*s pads by runes instead.
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-ansi.StringWidth(s), 0))
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+// catalog holds the translations of the active locale, keyed by the English text;
+// English itself needs none.
+var catalog map[string]string
+
+// setLocale picks the TUI language from the configured locale, else from the
+// environment ($LC_ALL, $LC_MESSAGES, $LANG), and falls back to English.
+func setLocale(locale string) {
+	tags := make([]string, 0, len(uiLocales))
+	for tag := range uiLocales {
+		tags = append(tags, tag)
+	}
+	candidates := []string{locale, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")}
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		catalog = uiLocales[utils.MatchLocale(c, tags)]
+		return
+	}
+	catalog = nil
+}
+
+// tr translates a TUI string; strings missing from the catalog are shown in English.
+func tr(s string) string {
+	if t, ok := catalog[s]; ok {
+		return t
+	}
+	return s
+}
+
+// trf translates a format string, then formats it.
+func trf(format string, args ...any) string {
+	return fmt.Sprintf(tr(format), args...)
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMap holds the actions users can rebind with ui.keys in the config file.
+type keyMap struct {
+	Back   key.Binding
+	Export key.Binding
+	Quit   key.Binding
+}
+
+// keys is the active key map; initialModel replaces it with the configured one.
+var keys = defaultKeyMap()
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Back:   newKeyBinding("b"),
+		Export: newKeyBinding("enter"),
+		Quit:   newKeyBinding("esc"),
+	}
+}
+
+func newKeyBinding(k ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(k...), key.WithHelp(keyLabel(k), ""))
+}
+
+// loadKeyMap applies configured bindings, keyed by action name, over the defaults.
+func loadKeyMap(bindings map[string][]string) (keyMap, error) {
+	km := defaultKeyMap()
+	actions := map[string]*key.Binding{"back": &km.Back, "export": &km.Export, "quit": &km.Quit}
+
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		binding, ok := actions[name]
+		if !ok {
+			return defaultKeyMap(), fmt.Errorf("unknown key action %q: use back, export or quit", name)
+		}
+		if len(bindings[name]) == 0 {
+			return defaultKeyMap(), fmt.Errorf("no keys bound to %q", name)
+		}
+		*binding = newKeyBinding(bindings[name]...)
+	}
+
+	// Quit is checked before the screens see a key, so sharing one would shadow the other.
+	for _, k := range km.Quit.Keys() {
+		for _, other := range slices.Concat(km.Back.Keys(), km.Export.Keys()) {
+			if k == other {
+				return defaultKeyMap(), fmt.Errorf("key %q is bound to quit and another action", k)
+			}
+		}
+	}
+	return km, nil
+}
+
+// keyLabel renders keys the way help text shows them, e.g. "alt+b" as "Alt+B".
+func keyLabel(k []string) string {
+	labels := make([]string, len(k))
+	for i, name := range k {
+		parts := strings.Split(name, "+")
+		for j, part := range parts {
+			if part != "" {
+				parts[j] = strings.ToUpper(part[:1]) + part[1:]
+			}
+		}
+		labels[i] = strings.Join(parts, "+")
+	}
+	return strings.Join(labels, "/")
+}
+
+// helpKey is the label of a binding for help text.
+func helpKey(b key.Binding) string {
+	return b.Help().Key
+}
@@ -0,0 +1,337 @@
+package ui
+
+// uiLocales are the TUI translations, keyed by locale tag and then by the English
+// text passed to tr and trf. Format verbs must keep their order.
+var uiLocales = map[string]map[string]string{
+	"en": {},
+	"pt-BR": {
+		// Title, status line and navigation.
+		"Gommits - Commit Analyzer":                                       "Gommits - Analisador de Commits",
+		"Welcome to Gommits App!":                                         "Bem-vindo ao Gommits!",
+		"Please enter the path to a Git repository":                       "Informe o caminho de um repositório Git",
+		"Enter author(s) to filter, or leave empty for all":               "Informe autor(es) para filtrar, ou deixe vazio para todos",
+		"Configure additional options":                                    "Configure as opções adicionais",
+		"Contributor leaderboard for %d commits":                          "Ranking de contribuidores em %d commits",
+		"Author statistics for %d commits":                                "Estatísticas por autor em %d commits",
+		"Contribution calendar":                                           "Calendário de contribuições",
+		"Commits by weekday and hour":                                     "Commits por dia da semana e hora",
+		"Most frequently changed files":                                   "Arquivos alterados com mais frequência",
+		"Author comparison":                                               "Comparação de autores",
+		"Patch of %s":                                                     "Patch de %s",
+		"Found %d commits in branch '%s'":                                 "%d commits encontrados no branch '%s'",
+		"Found %d commits on '%s' missing from '%s'":                      "%d commits em '%s' que faltam em '%s'",
+		"watching for new commits":                                        "observando novos commits",
+		"Navigation: %s to proceed, %s for back, %s to quit, %s for help": "Navegação: %s para avançar, %s para voltar, %s para sair, %s para ajuda",
+
+		// Errors and notifications.
+		"Error (%s): %v":                                "Erro (%s): %v",
+		"loading config":                                "carregando a configuração",
+		"loading theme":                                 "carregando o tema",
+		"loading keys":                                  "carregando as teclas",
+		"fetching commits":                              "buscando commits",
+		"enriching commits":                             "detalhando commits",
+		"loading diff":                                  "carregando o diff",
+		"exporting":                                     "exportando",
+		"copying changelog":                             "copiando o changelog",
+		"copying cherry-pick command":                   "copiando o comando cherry-pick",
+		"opening commit":                                "abrindo o commit",
+		"running pager":                                 "executando o pager",
+		"resolving directory path":                      "resolvendo o caminho do diretório",
+		"validating repository":                         "validando o repositório",
+		"getting branch name":                           "obtendo o nome do branch",
+		"%s is not a Git repository":                    "%s não é um repositório Git",
+		"Some commit details could not be loaded":       "Alguns detalhes dos commits não puderam ser carregados",
+		"Export failed":                                 "Falha na exportação",
+		"Exported %d commits to %s":                     "%d commits exportados para %s",
+		"Changelog copied to clipboard":                 "Changelog copiado para a área de transferência",
+		"cherry-pick of %d commits copied to clipboard": "cherry-pick de %d commits copiado para a área de transferência",
+		"Stopped watching":                              "Observação encerrada",
+		"Results refreshed with new commits":            "Resultados atualizados com novos commits",
+
+		// Shared help text.
+		"Press %s.":      "Pressione %s.",
+		"%s to start":    "%s para começar",
+		"%s to continue": "%s para continuar",
+		"%s for back":    "%s para voltar",
+		"%s to quit":     "%s para sair",
+		"Hint: Press Tab to complete the path or, when empty, use the current directory (.); Ctrl+O to browse.": "Dica: pressione Tab para completar o caminho ou, se vazio, usar o diretório atual (.); Ctrl+O para navegar.",
+		"Yes":                           "Sim",
+		"No":                            "Não",
+		"Keys":                          "Teclas",
+		"Press any key to close.":       "Pressione qualquer tecla para fechar.",
+		"go back":                       "voltar",
+		"quit":                          "sair",
+		"quit from anywhere":            "sair de qualquer tela",
+		"show this help":                "mostrar esta ajuda",
+		"show the last error's details": "mostrar os detalhes do último erro",
+		"Error details":                 "Detalhes do erro",
+		"Command":                       "Comando",
+		"Git output (%v)":               "Saída do Git (%v)",
+		"(nothing on stderr)":           "(nada no stderr)",
+		"...and %d more lines":          "...e mais %d linhas",
+		"%s for details":                "%s para detalhes",
+		"Install Git and make sure it is on your PATH.":                       "Instale o Git e verifique se ele está no PATH.",
+		"Choose a directory inside a Git repository.":                         "Escolha um diretório dentro de um repositório Git.",
+		"Check the branch name, or fetch it if it only exists on the remote.": "Verifique o nome do branch, ou faça fetch se ele só existir no remoto.",
+		"Make a first commit, or choose another repository.":                  "Faça um primeiro commit, ou escolha outro repositório.",
+
+		// Home, directory, author and options screens.
+		"This application helps you analyze Git commits and export changed files.": "Este aplicativo ajuda a analisar commits do Git e exportar os arquivos alterados.",
+		"Features:":                                   "Recursos:",
+		"Find commits by specific authors":            "Encontre commits de autores específicos",
+		"View detailed commit information":            "Veja informações detalhadas dos commits",
+		"Export changed files to Excel":               "Exporte os arquivos alterados para o Excel",
+		"Stylized terminal output":                    "Saída estilizada no terminal",
+		"start":                                       "começar",
+		"Enter path to Git repository":                "Caminho do repositório Git",
+		"Author(s) comma-separated, or empty for all": "Autor(es) separados por vírgula, ou vazio para todos",
+		"Leave empty to include all authors. Separate multiple with commas.":                  "Deixe vazio para incluir todos os autores. Separe vários com vírgulas.",
+		"Enter maximum number of commits (0 for no limit)":                                    "Número máximo de commits (0 para sem limite)",
+		"Press Enter to confirm, Esc to cancel.":                                              "Pressione Enter para confirmar, Esc para cancelar.",
+		"Press %s to fetch commits.":                                                          "Pressione %s para buscar os commits.",
+		"Press %s to set max commits.":                                                        "Pressione %s para definir o máximo de commits.",
+		"Press %s to edit parent branch (%s).":                                                "Pressione %s para editar o branch pai (%s).",
+		"Press %s to toggle current branch only (%s).":                                        "Pressione %s para alternar somente o branch atual (%s).",
+		"Press %s to toggle missing from %s (commits on the parent branch not on yours: %s).": "Pressione %s para alternar os que faltam de %s (commits do branch pai que não estão no seu: %s).",
+		"Press %s to toggle unmerged only (commits on any branch not yet on %s: %s).":         "Pressione %s para alternar somente não mesclados (commits em qualquer branch que ainda não estão em %s: %s).",
+		"Press %s to toggle show files (%s).":                                                 "Pressione %s para alternar a exibição de arquivos (%s).",
+		"Press %s to toggle dotnet project mode (%s).":                                        "Pressione %s para alternar o modo de projeto dotnet (%s).",
+		"all authors":          "todos os autores",
+		"Author filter: %s":    "Filtro de autor: %s",
+		"Fetching commits...":  "Buscando commits...",
+		"%d of %d commits":     "%d de %d commits",
+		"%s to open it":        "%s para abri-lo",
+		"running toast action": "executando a ação do aviso",
+		"Are you sure?":        "Tem certeza?",
+		"%s to confirm":        "%s para confirmar",
+		"%s to cancel":         "%s para cancelar",
+		"Overwrite %s?":        "Sobrescrever %s?",
+		"Commits are still being fetched. Quit anyway?": "Os commits ainda estão sendo buscados. Sair mesmo assim?",
+		"An export is still running. Quit anyway?":      "Uma exportação ainda está em andamento. Sair mesmo assim?",
+		"branch %s":                                  "branch %s",
+		"current branch only":                        "apenas o branch atual",
+		"missing from %s":                            "ausentes de %s",
+		"unmerged into %s":                           "não mesclados em %s",
+		"files shown":                                "arquivos exibidos",
+		"dotnet mode":                                "modo dotnet",
+		"watching":                                   "observando",
+		"%s to change the author":                    "%s para trocar o autor",
+		"%s to change the max commits":               "%s para trocar o máximo de commits",
+		"%s to change the parent branch":             "%s para trocar o branch pai",
+		"%s to toggle current branch only":           "%s para alternar apenas o branch atual",
+		"change the author filter and fetch again":   "trocar o filtro de autor e buscar de novo",
+		"change the max commits and fetch again":     "trocar o máximo de commits e buscar de novo",
+		"change the parent branch and fetch again":   "trocar o branch pai e buscar de novo",
+		"toggle current branch only and fetch again": "alternar apenas o branch atual e buscar de novo",
+		"Choose a saved preset to run":               "Escolha um preset salvo para executar",
+		"No presets saved. Add them under \"presets\" in the config file.": "Nenhum preset salvo. Adicione-os em \"presets\" no arquivo de configuração.",
+		"preset %d":                                        "preset %d",
+		"preset %s has no repo":                            "o preset %s não tem repo",
+		"running preset":                                   "executando o preset",
+		"%s to select a preset":                            "%s para selecionar um preset",
+		"%s to run it":                                     "%s para executá-lo",
+		"%s to run a saved preset":                         "%s para executar um preset salvo",
+		"run a saved preset":                               "executar um preset salvo",
+		"select a preset":                                  "selecionar um preset",
+		"run it":                                           "executá-lo",
+		"Recent repositories:":                             "Repositórios recentes:",
+		"%s to pick a recent repository":                   "%s para escolher um repositório recente",
+		"pick a recent repository":                         "escolher um repositório recente",
+		"start, or open the picked recent repository":      "começar, ou abrir o repositório recente escolhido",
+		"use the current directory":                        "usar o diretório atual",
+		"complete the path, or use the current directory":  "completar o caminho, ou usar o diretório atual",
+		"browse for the repository":                        "navegar até o repositório",
+		"No subdirectories.":                               "Nenhum subdiretório.",
+		"git repository":                                   "repositório git",
+		"...and %d more directories":                       "...e mais %d diretórios",
+		"%s to move":                                       "%s para mover",
+		"%s to open a directory":                           "%s para abrir um diretório",
+		"%s to go up":                                      "%s para subir",
+		"%s to pick a repository":                          "%s para escolher um repositório",
+		"%s to type the path":                              "%s para digitar o caminho",
+		"continue":                                         "continuar",
+		"loading recent repositories":                      "carregando os repositórios recentes",
+		"saving recent repositories":                       "salvando os repositórios recentes",
+		"Could not list contributors: %v":                  "Não foi possível listar os contribuidores: %v",
+		"%s to select a contributor":                       "%s para selecionar um contribuidor",
+		"%s to add them":                                   "%s para adicioná-lo",
+		"select a contributor":                             "selecionar um contribuidor",
+		"add the selected contributor":                     "adicionar o contribuidor selecionado",
+		"Nothing matches %q.":                              "Nada corresponde a %q.",
+		"...and %d more":                                   "...e mais %d",
+		"preset name":                                      "nome do preset",
+		"%s to search them":                                "%s para pesquisá-los",
+		"search the presets":                               "pesquisar os presets",
+		"branch or any other ref":                          "branch ou qualquer outra ref",
+		"Choose the parent branch:":                        "Escolha o branch pai:",
+		"%s to select a branch":                            "%s para selecionar um branch",
+		"%s to use it":                                     "%s para usá-lo",
+		"listing branches":                                 "listando os branches",
+		"file path":                                        "caminho do arquivo",
+		"Show the commits that changed:":                   "Mostrar os commits que alteraram:",
+		"%s to select a file":                              "%s para selecionar um arquivo",
+		"%s to filter by it":                               "%s para filtrar por ele",
+		"%s to find a file":                                "%s para encontrar um arquivo",
+		"find a file and show the commits that changed it": "encontrar um arquivo e mostrar os commits que o alteraram",
+		"Exporting...":                                     "Exportando...",
+		"%d of %d rows":                                    "%d de %d linhas",
+		"fetch commits":                                    "buscar os commits",
+		"set max commits and fetch":                        "definir o máximo de commits e buscar",
+		"edit the parent branch":                           "editar o branch pai",
+		"toggle current branch only":                       "alternar somente o branch atual",
+		"toggle commits missing from the parent branch":    "alternar os commits que faltam do branch pai",
+		"toggle unmerged commits only":                     "alternar somente commits não mesclados",
+		"toggle showing changed files":                     "alternar a exibição dos arquivos alterados",
+		"toggle dotnet project mode":                       "alternar o modo de projeto dotnet",
+
+		// Results screen.
+		"message, file or hash":                            "mensagem, arquivo ou hash",
+		"No commits found for this author.":                "Nenhum commit encontrado para este autor.",
+		"No commits match %q.":                             "Nenhum commit corresponde a %q.",
+		"Commit %d of %d matching (%d loaded):":            "Commit %d de %d correspondentes (%d carregados):",
+		"Commit %d of %d:":                                 "Commit %d de %d:",
+		"↑/↓ select, ←/→ or PgUp/PgDn page, Home/End jump": "↑/↓ seleciona, ←/→ ou PgUp/PgDn pagina, Home/End salta",
+		"j/k select, h/l or Ctrl+D/Ctrl+U page, gg/G jump": "j/k seleciona, h/l ou Ctrl+D/Ctrl+U pagina, gg/G salta",
+		"grouped by %s":                                    "agrupado por %s",
+		"day":                                              "dia",
+		"week":                                             "semana",
+		"month":                                            "mês",
+		"%d marked for export":                             "%d marcados para exportar",
+		"%s to export to Excel":                            "%s para exportar para o Excel",
+		"%s to mark a commit for export":                   "%s para marcar um commit para exportar",
+		"%s to mark all":                                   "%s para marcar todos",
+		"%s to open the selected commit in the browser":    "%s para abrir o commit selecionado no navegador",
+		"%s to copy a cherry-pick of the marked commits":   "%s para copiar um cherry-pick dos commits marcados",
+		"%s to watch for new commits":                      "%s para observar novos commits",
+		"%s to copy a changelog":                           "%s para copiar um changelog",
+		"%s for the leaderboard":                           "%s para o ranking",
+		"%s for author statistics":                         "%s para as estatísticas por autor",
+		"%s for the activity calendar":                     "%s para o calendário de atividade",
+		"%s for commits by weekday and hour":               "%s para commits por dia da semana e hora",
+		"%s for file hotspots":                             "%s para os arquivos mais alterados",
+		"%s to compare two authors":                        "%s para comparar dois autores",
+		"%s to view the selected commit's diff":            "%s para ver o diff do commit selecionado",
+		"%s to open it in your pager":                      "%s para abri-lo no seu pager",
+		"%s to filter by message, file or hash":            "%s para filtrar por mensagem, arquivo ou hash",
+		"%s to group by day, week or month":                "%s para agrupar por dia, semana ou mês",
+		"%d commits":                                       "%d commits",
+		"1 commit":                                         "1 commit",
+		"Period %s: %s":                                    "Período %s: %s",
+		"undated":                                          "sem data",
+		"unmerged":                                         "não mesclado",
+		"marked":                                           "marcado",
+		"author %s":                                        "autor %s",
+		"date %s":                                          "data %s",
+		"not merged":                                       "não mesclado",
+		"sign-off missing":                                 "sem sign-off",
+		"Commit %s":                                        "Commit %s",
+		"Commit: %s":                                       "Commit: %s",
+		"Author: %s <%s>":                                  "Autor: %s <%s>",
+		"Date: %s":                                         "Data: %s",
+		"Message: %s":                                      "Mensagem: %s",
+		"Work items: %s":                                   "Itens de trabalho: %s",
+		"Issues: %s":                                       "Issues: %s",
+		"Files: %s":                                        "Arquivos: %s",
+		"Files:":                                           "Arquivos:",
+		"Changes: %s %s in %d files":                       "Alterações: %s %s em %d arquivos",
+		"and %d more...":                                   "e mais %d...",
+		"not merged into %s":                               "não mesclado em %s",
+		"signed off by someone other than the author":      "sign-off de alguém que não é o autor",
+		"missing Signed-off-by":                            "sem Signed-off-by",
+		"review: %s":                                       "revisão: %s",
+		"checks: %s":                                       "verificações: %s",
+		"select a commit":                                  "selecionar um commit",
+		"page through the list":                            "paginar a lista",
+		"jump to the first or last commit":                 "saltar para o primeiro ou o último commit",
+		"scroll with the wheel, click to select":           "rolar com a roda, clicar para selecionar",
+		"export to Excel (only the marked commits, if any)": "exportar para o Excel (só os commits marcados, se houver)",
+		"mark the selected commit":                          "marcar o commit selecionado",
+		"mark or unmark all listed commits":                 "marcar ou desmarcar todos os commits listados",
+		"filter by message, file or hash":                   "filtrar por mensagem, arquivo ou hash",
+		"group by day, week or month":                       "agrupar por dia, semana ou mês",
+		"view the selected commit's diff":                   "ver o diff do commit selecionado",
+		"open the selected commit in your pager":            "abrir o commit selecionado no seu pager",
+		"open the selected commit in the browser":           "abrir o commit selecionado no navegador",
+		"copy a cherry-pick of the marked commits":          "copiar um cherry-pick dos commits marcados",
+		"copy a changelog":                                  "copiar um changelog",
+		"watch for new commits":                             "observar novos commits",
+		"leaderboard":                                       "ranking",
+		"author statistics":                                 "estatísticas por autor",
+		"activity calendar":                                 "calendário de atividade",
+		"commits by weekday and hour":                       "commits por dia da semana e hora",
+		"file hotspots":                                     "arquivos mais alterados",
+		"compare two authors":                               "comparar dois autores",
+
+		// Diff screen.
+		"Loading %s...":                 "Carregando %s...",
+		"S for side-by-side":            "S para lado a lado",
+		"S for unified":                 "S para unificado",
+		"side-by-side needs %d columns": "lado a lado precisa de %d colunas",
+		"%3.f%% · ↑/↓ scroll, PgUp/PgDn page, Home/End jump · %s · P for pager": "%3.f%% · ↑/↓ rola, PgUp/PgDn pagina, Home/End salta · %s · P para o pager",
+		"scroll":                    "rolar",
+		"page":                      "paginar",
+		"jump to the top or bottom": "saltar para o início ou o fim",
+		"toggle side-by-side":       "alternar lado a lado",
+		"open in your pager":        "abrir no seu pager",
+
+		// Leaderboard, statistics, hotspots and comparison.
+		"By %s":                     "Por %s",
+		"commits":                   "commits",
+		"files":                     "arquivos",
+		"lines":                     "linhas",
+		"Author":                    "Autor",
+		"Authors":                   "Autores",
+		"Commits":                   "Commits",
+		"File":                      "Arquivo",
+		"Files":                     "Arquivos",
+		"Lines":                     "Linhas",
+		"Churn":                     "Churn",
+		"Avg size":                  "Tam. médio",
+		"Busiest day":               "Dia mais ativo",
+		"...and %d more authors":    "...e mais %d autores",
+		"...and %d more files":      "...e mais %d arquivos",
+		"%s to change the ranking":  "%s para mudar o critério",
+		"change the ranking":        "mudar o critério",
+		"%s to scroll":              "%s para rolar",
+		"scroll the files":          "rolar os arquivos",
+		"%s to select an author":    "%s para selecionar um autor",
+		"select an author":          "selecionar um autor",
+		"No changed files to rank.": "Nenhum arquivo alterado para classificar.",
+		"No commits to summarize.":  "Nenhum commit para resumir.",
+		"Commits: %d   Files: %d   Lines: +%d / -%d": "Commits: %d   Arquivos: %d   Linhas: +%d / -%d",
+		"Average commit size: %.1f lines":            "Tamanho médio do commit: %.1f linhas",
+		"Active: %s to %s":                           "Ativo: %s a %s",
+		"Cadence: %.2f commits/day":                  "Cadência: %.2f commits/dia",
+		"median %s between commits":                  "mediana de %s entre commits",
+		"Longest streak: %s":                         "Maior sequência: %s",
+		"Longest gaps:":                              "Maiores pausas:",
+		"Busiest day: %s (%d commits)":               "Dia mais ativo: %s (%d commits)",
+		"Languages: %s":                              "Linguagens: %s",
+		"%.0f%% other":                               "%.0f%% outras",
+		"Comparing needs commits from at least two authors; search for several authors separated by commas.": "A comparação precisa de commits de pelo menos dois autores; busque vários autores separados por vírgulas.",
+		"Files touched":   "Arquivos tocados",
+		"Avg commit size": "Tam. médio do commit",
+		"Commits per day": "Commits por dia",
+		"Longest streak":  "Maior sequência",
+		"%d days":         "%d dias",
+		"%d of %d files touched by both (%.0f%% overlap)": "%d de %d arquivos tocados por ambos (%.0f%% de sobreposição)",
+		"...and %d more shared files":                     "...e mais %d arquivos em comum",
+		"%s to change authors":                            "%s para trocar os autores",
+		"%s to export the comparison":                     "%s para exportar a comparação",
+		"export the comparison":                           "exportar a comparação",
+		"change the right-hand author":                    "trocar o autor da direita",
+		"change the left-hand author":                     "trocar o autor da esquerda",
+
+		// Calendars.
+		"No dated commits to plot.":    "Nenhum commit com data para exibir.",
+		"Less":                         "Menos",
+		"More":                         "Mais",
+		"%d commits from %s to %s":     "%d commits de %s a %s",
+		"%s to move through time":      "%s para navegar no tempo",
+		"move back or forward in time": "voltar ou avançar no tempo",
+		"After hours (weekends or outside %02d:00–%02d:00): %d of %d commits (%.0f%%)": "Fora do expediente (fins de semana ou fora de %02d:00–%02d:00): %d de %d commits (%.0f%%)",
+		"Mon": "Seg", "Tue": "Ter", "Wed": "Qua", "Thu": "Qui", "Fri": "Sex", "Sat": "Sáb", "Sun": "Dom",
+		"Jan": "Jan", "Feb": "Fev", "Mar": "Mar", "Apr": "Abr", "May": "Mai", "Jun": "Jun",
+		"Jul": "Jul", "Aug": "Ago", "Sep": "Set", "Oct": "Out", "Nov": "Nov", "Dec": "Dez",
+	},
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// progressView shows a spinner next to what is running and, once the total is
+// known, a bar of done out of total. Plain mode keeps still; accessible mode
+// writes the counts out in words.
+type progressView struct {
+	spinner spinner.Model
+	bar     progress.Model
+	label   string
+	count   string // format of the done and total counts, e.g. "%d of %d commits"
+	done    int
+	total   int
+}
+
+func newProgressView(label, count string) progressView {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = highlightStyle
+	bar := progress.New(progress.WithSolidFill(theme.Primary), progress.WithWidth(40))
+	bar.EmptyColor = theme.Muted
+	return progressView{spinner: s, bar: bar, label: label, count: count}
+}
+
+// start begins the spinner animation.
+func (p progressView) start() tea.Cmd {
+	if plain {
+		return nil
+	}
+	return p.spinner.Tick
+}
+
+func (p progressView) update(msg tea.Msg) (progressView, tea.Cmd) {
+	var cmd tea.Cmd
+	p.spinner, cmd = p.spinner.Update(msg)
+	return p, cmd
+}
+
+func (p *progressView) set(done, total int) {
+	p.done, p.total = done, total
+}
+
+func (p progressView) view() string {
+	if accessible {
+		if p.total == 0 {
+			return tr(p.label)
+		}
+		return tr(p.label) + " " + trf(p.count, p.done, p.total)
+	}
+
+	var b strings.Builder
+	if !plain {
+		b.WriteString(p.spinner.View())
+	}
+	b.WriteString(tr(p.label) + "\n")
+	if p.total > 0 {
+		bar := p.bar.ViewAs(float64(p.done) / float64(p.total))
+		if plain {
+			// The bar colors itself for the terminal, whatever lipgloss was told.
+			bar = ansi.Strip(bar)
+		}
+		b.WriteString("\n" + bar + "\n")
+		b.WriteString(dimmedStyle.Render(trf(p.count, p.done, p.total)) + "\n")
+	}
+	return b.String()
+}
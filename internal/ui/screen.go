@@ -23,6 +23,7 @@ type NavigateData struct {
 	Branch       string
 	ParentBranch string
 	MaxCommits   int
+	Commit       string // hash of the commit to show or reselect
 	GitService   git.GitService
 	MessageStyle lipgloss.Style
 	Message      string
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+var weekdayLabels = [7]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+type activityScreen struct {
+	matrix  utils.ActivityMatrix
+	commits int
+}
+
+func newActivityScreen(commits []models.CommitInfo) ScreenModel {
+	return &activityScreen{matrix: utils.WeekdayHourMatrix(commits), commits: len(commits)}
+}
+
+func (s *activityScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && key.Matches(keyMsg, keys.Back) {
+		return s, func() tea.Msg {
+			return NavigateMsg{To: models.ResultsScreen}
+		}
+	}
+	return s, nil
+}
+
+func (s *activityScreen) View(width, height int) string {
+	var content strings.Builder
+
+	content.WriteString("    ")
+	for hour := 0; hour < 24; hour += 3 {
+		content.WriteString(dimmedStyle.Render(fmt.Sprintf("%-6s", fmt.Sprintf("%02d", hour))))
+	}
+	content.WriteString("\n")
+
+	busiest := s.matrix.Max()
+	for day := 0; day < 7; day++ {
+		content.WriteString(dimmedStyle.Render(tr(weekdayLabels[day])) + " ")
+		for hour := 0; hour < 24; hour++ {
+			content.WriteString(heatmapCell(s.matrix[day][hour], busiest) + " ")
+		}
+		content.WriteString(fmt.Sprintf(" %4d\n", s.matrix.WeekdayTotal(day)))
+	}
+
+	content.WriteString("\n")
+	if s.commits > 0 {
+		after := s.matrix.AfterHours()
+		content.WriteString(trf("After hours (weekends or outside %02d:00–%02d:00): %d of %d commits (%.0f%%)",
+			utils.WorkdayStartHour, utils.WorkdayEndHour, after, s.commits, 100*float64(after)/float64(s.commits)) + "\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(modifyHelpText("", true, true, false))
+	return content.String()
+}
@@ -1,27 +1,37 @@
 package ui
 
 import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/leeozaka/gommits/internal/models"
 )
 
+// contributorsMsg delivers the repository's contributors to the author screen.
+type contributorsMsg struct {
+	contributors []models.Contributor
+	err          error
+}
+
 type authorScreen struct {
 	textInput textinput.Model
+
+	// contributors are listed under the field, fuzzy matched against the author
+	// being typed; Tab puts the selected one in its place.
+	contributors []models.Contributor
+	list         fuzzyList
+	loadErr      error
 }
 
 func newAuthorScreen() ScreenModel {
-	ti := textinput.New()
-	ti.Placeholder = "Author(s) comma-separated, or empty for all"
-	ti.Focus()
-	ti.CharLimit = 512
-	ti.Width = 60
-	return &authorScreen{textInput: ti}
+	return newAuthorScreenWithValue("")
 }
 
 func newAuthorScreenWithValue(value string) ScreenModel {
-	ti := textinput.New()
-	ti.Placeholder = "Author(s) comma-separated, or empty for all"
+	ti := newTextInput()
+	ti.Placeholder = tr("Author(s) comma-separated, or empty for all")
 	ti.Focus()
 	ti.CharLimit = 512
 	ti.Width = 60
@@ -29,11 +39,56 @@ func newAuthorScreenWithValue(value string) ScreenModel {
 	return &authorScreen{textInput: ti}
 }
 
+// typed is the author being typed: whatever follows the last comma.
+func (s *authorScreen) typed() string {
+	value := s.textInput.Value()
+	return strings.TrimSpace(value[strings.LastIndex(value, ",")+1:])
+}
+
+// pick replaces the typed author with the selected contributor's email, leaving
+// the field ready for another.
+func (s *authorScreen) pick() {
+	i, ok := s.list.chosen()
+	if !ok {
+		return
+	}
+	c := s.contributors[i]
+	author := c.Email
+	if author == "" {
+		author = c.Name
+	}
+	value := s.textInput.Value()
+	s.textInput.SetValue(value[:strings.LastIndex(value, ",")+1] + author + ", ")
+	s.textInput.CursorEnd()
+	s.list.setQuery(s.typed())
+}
+
 func (s *authorScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if msg, ok := msg.(contributorsMsg); ok {
+		s.contributors, s.loadErr = msg.contributors, msg.err
+		items := make([]fuzzyItem, len(s.contributors))
+		for i, c := range s.contributors {
+			items[i] = fuzzyItem{Label: c.Name + " <" + c.Email + ">", Detail: trf("%d commits", c.Commits)}
+		}
+		s.list = newFuzzyList(items)
+		s.list.setQuery(s.typed())
+		return s, nil
+	}
+
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(keyMsg, keys.Back) {
+			return s, func() tea.Msg {
+				return NavigateMsg{To: models.DirectoryScreen}
+			}
+		}
+
+		if s.list.move(keyMsg) {
+			return s, nil
+		}
+
 		switch keyMsg.Type {
 		case tea.KeyEnter:
-			author := s.textInput.Value()
+			author := strings.TrimSuffix(strings.TrimSpace(s.textInput.Value()), ",")
 			return s, func() tea.Msg {
 				return NavigateMsg{
 					To:   models.OptionsScreen,
@@ -41,22 +96,46 @@ func (s *authorScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
 				}
 			}
 
-		case tea.KeyRunes:
-			if string(keyMsg.Runes) == "b" {
-				return s, func() tea.Msg {
-					return NavigateMsg{To: models.DirectoryScreen}
-				}
-			}
+		case tea.KeyTab:
+			s.pick()
+			return s, nil
 		}
 	}
 
+	value := s.textInput.Value()
 	var cmd tea.Cmd
 	s.textInput, cmd = s.textInput.Update(msg)
+	if s.textInput.Value() != value {
+		s.list.setQuery(s.typed())
+	}
 	return s, cmd
 }
 
 func (s *authorScreen) View(width, height int) string {
-	return s.textInput.View() + "\n" +
-		dimmedStyle.Render("Leave empty to include all authors. Separate multiple with commas.") + "\n\n" +
-		modifyHelpText("continue", true, true, false)
+	var content strings.Builder
+	content.WriteString(s.textInput.View() + "\n")
+	content.WriteString(dimmedStyle.Render(tr("Leave empty to include all authors. Separate multiple with commas.")) + "\n\n")
+
+	switch {
+	case s.loadErr != nil:
+		content.WriteString(warningTextStyle.Render(trf("Could not list contributors: %v", s.loadErr)) + "\n\n")
+	case len(s.contributors) == 0:
+	default:
+		content.WriteString(s.list.view(max(height-24, 5), width) + "\n")
+		content.WriteString(pressText(
+			trf("%s to select a contributor", highlightStyle.Render("↑/↓")),
+			trf("%s to add them", highlightStyle.Render("Tab")),
+		) + "\n")
+	}
+
+	content.WriteString(modifyHelpText("continue", true, true, false))
+	return content.String()
+}
+
+func (s *authorScreen) keyHelp() []helpLine {
+	return []helpLine{
+		{"Enter", "continue"},
+		{"↑/↓", "select a contributor"},
+		{"Tab", "add the selected contributor"},
+	}
 }
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+type compareScreen struct {
+	gitService  git.GitService
+	commits     []models.CommitInfo
+	directory   string
+	stats       []utils.AuthorStats
+	left, right int // indexes into stats
+	cmp         utils.AuthorComparison
+}
+
+func newCompareScreen(svc git.GitService, commits []models.CommitInfo, directory string) ScreenModel {
+	s := &compareScreen{gitService: svc, commits: commits, directory: directory, stats: utils.ComputeAuthorStats(commits), right: 1}
+	s.compare()
+	return s
+}
+
+func (s *compareScreen) compare() {
+	if len(s.stats) > 1 {
+		s.cmp = utils.CompareAuthors(s.commits, s.stats[s.left], s.stats[s.right])
+	}
+}
+
+// cycle moves one side to the next author that is not on the other side.
+func (s *compareScreen) cycle(side *int, other int) {
+	*side = (*side + 1) % len(s.stats)
+	if *side == other {
+		*side = (*side + 1) % len(s.stats)
+	}
+	s.compare()
+}
+
+func (s *compareScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(keyMsg, keys.Export) && len(s.stats) > 1 {
+			return s, exportComparisonCmd(s.gitService, s.cmp, s.directory)
+		}
+		if key.Matches(keyMsg, keys.Back) {
+			return s, func() tea.Msg {
+				return NavigateMsg{To: models.ResultsScreen}
+			}
+		}
+
+		switch keyMsg.Type {
+		case tea.KeyTab:
+			if len(s.stats) > 2 {
+				s.cycle(&s.right, s.left)
+			}
+		case tea.KeyShiftTab:
+			if len(s.stats) > 2 {
+				s.cycle(&s.left, s.right)
+			}
+		}
+	}
+	return s, nil
+}
+
+func (s *compareScreen) View(width, height int) string {
+	if len(s.stats) < 2 {
+		return tr("Comparing needs commits from at least two authors; search for several authors separated by commas.") + "\n\n" +
+			modifyHelpText("", true, true, false)
+	}
+
+	var content strings.Builder
+	l, r := s.cmp.Left, s.cmp.Right
+	row := func(label, left, right string) {
+		content.WriteString(fmt.Sprintf("  %-18s %24s %24s\n", tr(label), left, right))
+	}
+	content.WriteString(commitHashStyle.Render(fmt.Sprintf("  %-18s %s %s", "", truncateName(l.Name), truncateName(r.Name))))
+	content.WriteString("\n")
+	row("Commits", fmt.Sprint(l.Commits), fmt.Sprint(r.Commits))
+	row("Files touched", fmt.Sprint(l.Files), fmt.Sprint(r.Files))
+	row("Lines", fmt.Sprintf("+%d / -%d", l.Additions, l.Deletions), fmt.Sprintf("+%d / -%d", r.Additions, r.Deletions))
+	row("Avg commit size", fmt.Sprintf("%.1f", l.AvgCommitSize()), fmt.Sprintf("%.1f", r.AvgCommitSize()))
+	row("Commits per day", fmt.Sprintf("%.2f", l.CommitsPerDay), fmt.Sprintf("%.2f", r.CommitsPerDay))
+	row("Busiest day", l.BusiestDay, r.BusiestDay)
+	row("Longest streak", trf("%d days", l.LongestStreak.Days), trf("%d days", r.LongestStreak.Days))
+
+	shared := s.cmp.SharedFiles()
+	content.WriteString("\n")
+	content.WriteString("  " + trf("%d of %d files touched by both (%.0f%% overlap)", shared, len(s.cmp.Files), s.cmp.Overlap()*100) + "\n")
+
+	maxRows := min(height-30, shared)
+	for i := 0; i < maxRows; i++ {
+		fo := s.cmp.Files[i]
+		content.WriteString(fmt.Sprintf("  %s %5d %5d\n", commitFilesStyle.Render(padRight(truncatePathLeft(fo.Path, 50), 50)), fo.Left, fo.Right))
+	}
+	if maxRows > 0 && shared > maxRows {
+		content.WriteString(dimmedStyle.Render("  " + trf("...and %d more shared files", shared-maxRows) + "\n"))
+	}
+
+	content.WriteString("\n")
+	content.WriteString(pressText(
+		trf("%s to change authors", highlightStyle.Render("Tab")+"/"+highlightStyle.Render("Shift+Tab")),
+		trf("%s to export the comparison", highlightStyle.Render(helpKey(keys.Export))),
+	) + "\n")
+	content.WriteString(modifyHelpText("", true, true, false))
+	return content.String()
+}
+
+// truncateName fits an author name right-aligned into a 24-cell column.
+func truncateName(name string) string {
+	return lipgloss.PlaceHorizontal(24, lipgloss.Right, truncate(name, 24))
+}
+
+func (s *compareScreen) keyHelp() []helpLine {
+	return []helpLine{
+		{"Tab", "change the right-hand author"},
+		{"Shift+Tab", "change the left-hand author"},
+		{helpKey(keys.Export), "export the comparison"},
+	}
+}
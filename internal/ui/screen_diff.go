@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// diffScreen shows the patch of one commit in a scrollable viewport.
+type diffScreen struct {
+	config    config.Config
+	directory string
+	hash      string
+	patch     string
+	loaded    bool
+	split     bool // side-by-side rendering, used when the terminal is wide enough
+	viewport  viewport.Model
+
+	// The layout the viewport content was rendered for; it is redone when either changes.
+	renderedWidth int
+	renderedSplit bool
+}
+
+func newDiffScreen(cfg config.Config, directory, hash string) ScreenModel {
+	vp := viewport.New(0, 0)
+	vp.KeyMap.PageUp = key.NewBinding(key.WithKeys("pgup"))
+	vp.KeyMap.PageDown = key.NewBinding(key.WithKeys("pgdown", " "))
+	return &diffScreen{config: cfg, directory: directory, hash: hash, viewport: vp}
+}
+
+func loadDiffCmd(svc git.GitService, repoPath, hash string) tea.Cmd {
+	return func() tea.Msg {
+		patch, err := svc.ShowPatch(repoPath, hash)
+		return models.DiffMsg{Hash: hash, Patch: patch, Err: err}
+	}
+}
+
+// openPagerCmd suspends the TUI while the commit is shown in the user's pager.
+func openPagerCmd(cfg config.Config, repoPath, hash string) tea.Cmd {
+	return tea.ExecProcess(git.PagerCommand(repoPath, hash, cfg.UI.Pager), func(err error) tea.Msg {
+		if err != nil {
+			return models.NewError(err, "running pager")
+		}
+		return nil
+	})
+}
+
+func (s *diffScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case models.DiffMsg:
+		if msg.Hash == s.hash && msg.Err == nil {
+			s.patch = msg.Patch
+			s.loaded = true
+			s.renderedWidth = 0
+		}
+		return s, nil
+
+	case tea.KeyMsg:
+		if key.Matches(msg, keys.Back) {
+			return s, func() tea.Msg {
+				return NavigateMsg{To: models.ResultsScreen, Data: NavigateData{Commit: s.hash}}
+			}
+		}
+		switch msg.Type {
+		case tea.KeyHome:
+			s.viewport.GotoTop()
+			return s, nil
+		case tea.KeyEnd:
+			s.viewport.GotoBottom()
+			return s, nil
+		case tea.KeyRunes:
+			switch string(msg.Runes) {
+			case "s":
+				s.split = !s.split
+				return s, nil
+			case "p":
+				return s, openPagerCmd(s.config, s.directory, s.hash)
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	s.viewport, cmd = s.viewport.Update(msg)
+	return s, cmd
+}
+
+func (s *diffScreen) View(width, height int) string {
+	var content strings.Builder
+
+	if !s.loaded {
+		content.WriteString(trf("Loading %s...", shortHash(s.hash)) + "\n\n")
+	} else {
+		split := s.split && width >= splitMinWidth && !accessible
+		if width != s.renderedWidth || split != s.renderedSplit {
+			if split {
+				s.viewport.SetContent(highlightSplitPatch(s.patch, width))
+			} else {
+				s.viewport.SetContent(highlightPatch(s.patch))
+			}
+			s.renderedWidth, s.renderedSplit = width, split
+		}
+		s.viewport.Width = width
+		s.viewport.Height = max(height-16, 5)
+		content.WriteString(s.viewport.View())
+		content.WriteString("\n\n")
+
+		mode := tr("S for side-by-side")
+		if split {
+			mode = tr("S for unified")
+		} else if s.split {
+			mode = trf("side-by-side needs %d columns", splitMinWidth)
+		}
+		content.WriteString(dimmedStyle.Render(trf("%3.f%% · ↑/↓ scroll, PgUp/PgDn page, Home/End jump · %s · P for pager", s.viewport.ScrollPercent()*100, mode)))
+		content.WriteString("\n")
+	}
+	content.WriteString(modifyHelpText("", true, true, false))
+
+	return content.String()
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func (s *diffScreen) keyHelp() []helpLine {
+	return []helpLine{
+		{"↑/↓", "scroll"},
+		{"PgUp/PgDn, Space", "page"},
+		{"Home/End", "jump to the top or bottom"},
+		{"S", "toggle side-by-side"},
+		{"P", "open in your pager"},
+	}
+}
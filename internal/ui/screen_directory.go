@@ -2,83 +2,112 @@ package ui
 
 import (
 	"fmt"
-	"path/filepath"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/leeozaka/gommits/internal/git"
 	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
 )
 
 type directoryScreen struct {
 	textInput  textinput.Model
 	gitService git.GitService
+	recent     []string    // recently analyzed repositories, most recent first
+	recentAt   int         // recent entry in the text field, -1 when typed by hand
+	browser    *dirBrowser // replaces the text field while browsing, nil otherwise
 }
 
-func newDirectoryScreen(svc git.GitService) ScreenModel {
-	ti := textinput.New()
-	ti.Placeholder = "Enter path to Git repository"
-	ti.Focus()
-	ti.CharLimit = 256
-	ti.Width = 50
-	return &directoryScreen{textInput: ti, gitService: svc}
+func newDirectoryScreen(svc git.GitService, recent []string) ScreenModel {
+	return newDirectoryScreenWithValue(svc, "", recent)
 }
 
-func newDirectoryScreenWithValue(svc git.GitService, value string) ScreenModel {
-	ti := textinput.New()
-	ti.Placeholder = "Enter path to Git repository"
+func newDirectoryScreenWithValue(svc git.GitService, value string, recent []string) ScreenModel {
+	ti := newTextInput()
+	ti.Placeholder = tr("Enter path to Git repository")
 	ti.Focus()
 	ti.CharLimit = 256
 	ti.Width = 50
 	ti.SetValue(value)
-	return &directoryScreen{textInput: ti, gitService: svc}
+	return &directoryScreen{textInput: ti, gitService: svc, recent: recent, recentAt: -1}
+}
+
+// openRepoCmd checks that dir is a Git repository and moves on to the author screen
+// with its branches.
+func openRepoCmd(svc git.GitService, dir string) tea.Cmd {
+	absDir, err := utils.ResolveDir(dir)
+	if err != nil {
+		return errorCmd(err, "resolving directory path")
+	}
+
+	if !svc.IsGitRepo(absDir) {
+		return errorCmd(fmt.Errorf(tr("%s is not a Git repository"), absDir), "validating repository")
+	}
+
+	branchName, err := svc.GetCurrentBranch(absDir)
+	if err != nil {
+		return errorCmd(err, "getting branch name")
+	}
+
+	parentBranch := svc.DetectDefaultBranch(absDir)
+
+	return func() tea.Msg {
+		return NavigateMsg{
+			To: models.AuthorScreen,
+			Data: NavigateData{
+				Directory:    absDir,
+				Branch:       branchName,
+				ParentBranch: parentBranch,
+			},
+		}
+	}
 }
 
 func (s *directoryScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && s.browser != nil {
+		return s, s.updateBrowser(keyMsg)
+	}
+
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		switch keyMsg.Type {
-		case tea.KeyEnter:
-			dir := s.textInput.Value()
-			if dir == "" {
-				dir = "."
-			}
-			absDir, err := filepath.Abs(dir)
-			if err != nil {
-				return s, errorCmd(err, "resolving directory path")
+		if keyMsg.Type == tea.KeyCtrlO {
+			b := newDirBrowser(s.textInput.Value())
+			s.browser = &b
+			return s, nil
+		}
+		if key.Matches(keyMsg, keys.Back) {
+			return s, func() tea.Msg {
+				return NavigateMsg{To: models.HomeScreen}
 			}
+		}
 
-			if !s.gitService.IsGitRepo(absDir) {
-				return s, errorCmd(fmt.Errorf("%s is not a Git repository", absDir), "validating repository")
-			}
+		switch keyMsg.Type {
+		case tea.KeyEnter:
+			return s, openRepoCmd(s.gitService, s.textInput.Value())
 
-			branchName, err := s.gitService.GetCurrentBranch(absDir)
-			if err != nil {
-				return s, errorCmd(err, "getting branch name")
+		case tea.KeyUp, tea.KeyDown:
+			if len(s.recent) == 0 {
+				return s, nil
 			}
-
-			parentBranch := s.gitService.DetectDefaultBranch(absDir)
-
-			return s, func() tea.Msg {
-				return NavigateMsg{
-					To: models.AuthorScreen,
-					Data: NavigateData{
-						Directory:    absDir,
-						Branch:       branchName,
-						ParentBranch: parentBranch,
-					},
-				}
+			if keyMsg.Type == tea.KeyDown {
+				s.recentAt = min(s.recentAt+1, len(s.recent)-1)
+			} else {
+				s.recentAt = max(s.recentAt-1, 0)
 			}
+			s.textInput.SetValue(s.recent[s.recentAt])
+			s.textInput.CursorEnd()
+			return s, nil
 
 		case tea.KeyTab:
-			s.textInput.SetValue(".")
+			if s.textInput.Value() == "" {
+				s.textInput.SetValue(".")
+			} else {
+				s.textInput.SetValue(completePath(s.textInput.Value()))
+			}
+			s.textInput.CursorEnd()
 			return s, nil
 
-		case tea.KeyRunes:
-			if string(keyMsg.Runes) == "b" {
-				return s, func() tea.Msg {
-					return NavigateMsg{To: models.HomeScreen}
-				}
-			}
 		}
 	}
 
@@ -87,6 +116,62 @@ func (s *directoryScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
 	return s, cmd
 }
 
+// updateBrowser moves the browser, opening the repository picked with it. Esc and
+// Ctrl+O go back to the text field, filled with the directory browsed to.
+func (s *directoryScreen) updateBrowser(msg tea.KeyMsg) tea.Cmd {
+	if msg.Type == tea.KeyEsc || msg.Type == tea.KeyCtrlO {
+		s.textInput.SetValue(s.browser.dir)
+		s.textInput.CursorEnd()
+		s.browser = nil
+		return nil
+	}
+	b, dir, ok := s.browser.update(msg)
+	s.browser = &b
+	if ok {
+		return openRepoCmd(s.gitService, dir)
+	}
+	return nil
+}
+
 func (s *directoryScreen) View(width, height int) string {
-	return s.textInput.View() + "\n\n" + modifyHelpText("continue", true, true, true)
+	if s.browser != nil {
+		return s.browser.view(height) + "\n" + pressText(
+			trf("%s to move", highlightStyle.Render("↑/↓")),
+			trf("%s to open a directory", highlightStyle.Render("→")),
+			trf("%s to go up", highlightStyle.Render("←")),
+			trf("%s to pick a repository", highlightStyle.Render("Enter")),
+			trf("%s to type the path", highlightStyle.Render("Esc")),
+		) + "\n"
+	}
+
+	content := s.textInput.View() + "\n\n"
+	if len(s.recent) > 0 {
+		content += recentReposView(s.recent, s.recentAt) + "\n"
+		content += pressText(trf("%s to pick a recent repository", highlightStyle.Render("↑/↓"))) + "\n"
+	}
+	return content + modifyHelpText("continue", true, true, true)
+}
+
+// recentReposView lists the recent repositories, marking the one at selected.
+func recentReposView(recent []string, selected int) string {
+	var b strings.Builder
+	b.WriteString(highlightStyle.Render(tr("Recent repositories:")) + "\n")
+	for i, dir := range recent {
+		row := truncatePathLeft(dir, 60)
+		if i == selected {
+			b.WriteString(highlightStyle.Render("> "+row) + "\n")
+		} else {
+			b.WriteString("  " + row + "\n")
+		}
+	}
+	return b.String()
+}
+
+func (s *directoryScreen) keyHelp() []helpLine {
+	return []helpLine{
+		{"Enter", "continue"},
+		{"Tab", "complete the path, or use the current directory"},
+		{"Ctrl+O", "browse for the repository"},
+		{"↑/↓", "pick a recent repository"},
+	}
 }
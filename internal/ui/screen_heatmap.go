@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+// heatmapShift is how many weeks ←/→ move the calendar.
+const heatmapShift = 4
+
+type heatmapScreen struct {
+	counts      map[string]int
+	first, last time.Time // days of the oldest and newest commit
+	max         int
+	offset      int // weeks scrolled back from the newest commit
+}
+
+func newHeatmapScreen(commits []models.CommitInfo) ScreenModel {
+	s := &heatmapScreen{counts: utils.DailyCounts(commits)}
+	for _, c := range commits {
+		if c.When.IsZero() {
+			continue
+		}
+		day := truncateDay(c.When)
+		if s.first.IsZero() || day.Before(s.first) {
+			s.first = day
+		}
+		if day.After(s.last) {
+			s.last = day
+		}
+	}
+	for _, n := range s.counts {
+		s.max = max(s.max, n)
+	}
+	return s
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func (s *heatmapScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(keyMsg, keys.Back) {
+			return s, func() tea.Msg {
+				return NavigateMsg{To: models.ResultsScreen}
+			}
+		}
+
+		switch keyMsg.Type {
+		case tea.KeyLeft:
+			if s.last.AddDate(0, 0, -7*(s.offset+heatmapShift)).After(s.first) {
+				s.offset += heatmapShift
+			}
+		case tea.KeyRight:
+			s.offset = max(0, s.offset-heatmapShift)
+		}
+	}
+	return s, nil
+}
+
+func (s *heatmapScreen) View(width, height int) string {
+	if s.last.IsZero() {
+		return tr("No dated commits to plot.") + "\n\n" + modifyHelpText("", true, true, false)
+	}
+
+	weeks := min((width-8)/2, 53)
+	if weeks < 4 {
+		weeks = 4
+	}
+
+	// Columns are ISO weeks starting on Monday; the last one holds end.
+	end := s.last.AddDate(0, 0, -7*s.offset)
+	start := end.AddDate(0, 0, -((int(end.Weekday())+6)%7)-7*(weeks-1))
+
+	// Month names sit above the first week of each month, skipped when they would overlap.
+	months := []byte(strings.Repeat(" ", 4+2*weeks+2))
+	next := 0
+	for col := 0; col < weeks; col++ {
+		day := start.AddDate(0, 0, 7*col)
+		if pos := 4 + 2*col; day.Day() <= 7 && pos >= next {
+			copy(months[pos:], tr(day.Format("Jan")))
+			next = pos + 4
+		}
+	}
+
+	var grid strings.Builder
+	grid.WriteString(dimmedStyle.Render(strings.TrimRight(string(months), " ")) + "\n")
+	total := 0
+	for row := 0; row < 7; row++ {
+		label := "   "
+		if row%2 == 0 {
+			label = tr(start.AddDate(0, 0, row).Format("Mon"))
+		}
+		grid.WriteString(dimmedStyle.Render(label) + " ")
+		for col := 0; col < weeks; col++ {
+			day := start.AddDate(0, 0, 7*col+row)
+			if day.After(end) {
+				grid.WriteString("  ")
+				continue
+			}
+			n := s.counts[day.Format(time.DateOnly)]
+			total += n
+			grid.WriteString(heatmapCell(n, s.max) + " ")
+		}
+		grid.WriteString("\n")
+	}
+
+	var legend strings.Builder
+	legend.WriteString(dimmedStyle.Render(tr("Less") + " "))
+	for level := range theme.Heatmap {
+		legend.WriteString(heatmapGlyph(level) + " ")
+	}
+	legend.WriteString(dimmedStyle.Render(tr("More")))
+
+	var content strings.Builder
+	content.WriteString(grid.String())
+	content.WriteString("\n" + legend.String() + "\n\n")
+	content.WriteString(trf("%d commits from %s to %s", total, start.Format(time.DateOnly), end.Format(time.DateOnly)) + "\n\n")
+	content.WriteString(pressText(trf("%s to move through time", highlightStyle.Render("←/→"))) + "\n")
+	content.WriteString(modifyHelpText("", true, true, false))
+	return content.String()
+}
+
+// heatmapCell colors a day by its share of the busiest day, in five levels.
+func heatmapCell(n, busiest int) string {
+	level := 0
+	if n > 0 && busiest > 0 {
+		level = 1 + (n-1)*(len(theme.Heatmap)-1)/busiest
+	}
+	return heatmapGlyph(level)
+}
+
+// heatmapGlyph draws a level as a colored square, or as its digit in accessible mode.
+func heatmapGlyph(level int) string {
+	if accessible {
+		return strconv.Itoa(level)
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Heatmap[level])).Render("■")
+}
+
+func (s *heatmapScreen) keyHelp() []helpLine {
+	return []helpLine{{"←/→", "move back or forward in time"}}
+}
@@ -2,33 +2,65 @@ package ui
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leeozaka/gommits/internal/git"
 	"github.com/leeozaka/gommits/internal/models"
 )
 
-type homeScreen struct{}
+type homeScreen struct {
+	gitService git.GitService
+	recent     []string // recently analyzed repositories, most recent first
+	selected   int      // recent repository Enter opens, -1 to start from the directory screen
+}
 
-func newHomeScreen() ScreenModel {
-	return homeScreen{}
+func newHomeScreen(svc git.GitService, recent []string) ScreenModel {
+	return &homeScreen{gitService: svc, recent: recent, selected: -1}
 }
 
-func (s homeScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
-	if msg, ok := msg.(tea.KeyMsg); ok && msg.Type == tea.KeyEnter {
-		return s, func() tea.Msg {
-			return NavigateMsg{To: models.DirectoryScreen}
+func (s *homeScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case msg.Type == tea.KeyEnter:
+			if s.selected >= 0 {
+				return s, openRepoCmd(s.gitService, s.recent[s.selected])
+			}
+			return s, func() tea.Msg {
+				return NavigateMsg{To: models.DirectoryScreen}
+			}
+		case msg.Type == tea.KeyDown:
+			s.selected = min(s.selected+1, len(s.recent)-1)
+		case msg.Type == tea.KeyUp:
+			s.selected = max(s.selected-1, -1)
+		case msg.String() == "p":
+			return s, func() tea.Msg {
+				return NavigateMsg{To: models.PresetScreen}
+			}
 		}
 	}
 	return s, nil
 }
 
-func (s homeScreen) View(width, height int) string {
+func (s *homeScreen) View(width, height int) string {
 	var content string
-	content += "Welcome to Gommits App!\n\n"
-	content += "This application helps you analyze Git commits and export changed files.\n\n"
-	content += highlightStyle.Render("Features:\n")
-	content += "• Find commits by specific authors\n"
-	content += "• View detailed commit information\n"
-	content += "• Export changed files to Excel\n"
-	content += "• Stylized terminal output\n\n"
+	content += tr("Welcome to Gommits App!") + "\n\n"
+	content += tr("This application helps you analyze Git commits and export changed files.") + "\n\n"
+	content += highlightStyle.Render(tr("Features:") + "\n")
+	content += "• " + tr("Find commits by specific authors") + "\n"
+	content += "• " + tr("View detailed commit information") + "\n"
+	content += "• " + tr("Export changed files to Excel") + "\n"
+	content += "• " + tr("Stylized terminal output") + "\n\n"
+	if len(s.recent) > 0 {
+		content += recentReposView(s.recent, s.selected) + "\n"
+		content += pressText(trf("%s to pick a recent repository", highlightStyle.Render("↑/↓"))) + "\n"
+	}
+	content += pressText(trf("%s to run a saved preset", highlightStyle.Render("P"))) + "\n"
 	content += modifyHelpText("start", false, true, false)
 	return content
 }
+
+func (s *homeScreen) keyHelp() []helpLine {
+	return []helpLine{
+		{"Enter", "start, or open the picked recent repository"},
+		{"↑/↓", "pick a recent repository"},
+		{"P", "run a saved preset"},
+	}
+}
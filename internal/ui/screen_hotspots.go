@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+type hotspotsScreen struct {
+	spots  []utils.Hotspot
+	offset int
+}
+
+func newHotspotsScreen(commits []models.CommitInfo) ScreenModel {
+	return &hotspotsScreen{spots: utils.Hotspots(commits)}
+}
+
+func (s *hotspotsScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(keyMsg, keys.Back) {
+			return s, func() tea.Msg {
+				return NavigateMsg{To: models.ResultsScreen}
+			}
+		}
+
+		switch keyMsg.Type {
+		case tea.KeyUp:
+			s.offset = max(0, s.offset-1)
+		case tea.KeyDown:
+			if s.offset < len(s.spots)-1 {
+				s.offset++
+			}
+		}
+	}
+	return s, nil
+}
+
+func (s *hotspotsScreen) View(width, height int) string {
+	if len(s.spots) == 0 {
+		return tr("No changed files to rank.") + "\n\n" + modifyHelpText("", true, true, false)
+	}
+
+	pathWidth := min(max(width-44, 20), 60)
+	maxRows := max(height-18, 5)
+
+	var content strings.Builder
+	content.WriteString(commitHashStyle.Render(fmt.Sprintf("%4s  %-*s %8s %8s %10s", "#", pathWidth, tr("File"), tr("Commits"), tr("Authors"), tr("Churn"))))
+	content.WriteString("\n")
+
+	end := min(s.offset+maxRows, len(s.spots))
+	for i := s.offset; i < end; i++ {
+		h := s.spots[i]
+		content.WriteString(fmt.Sprintf("%4d  %s %8d %8d %10s\n", i+1,
+			commitFilesStyle.Render(padRight(truncatePathLeft(h.Path, pathWidth), pathWidth)),
+			h.Commits, h.Authors, fmt.Sprintf("+%d/-%d", h.Additions, h.Deletions)))
+	}
+	if end < len(s.spots) {
+		content.WriteString(dimmedStyle.Render(trf("...and %d more files", len(s.spots)-end) + "\n"))
+	}
+
+	content.WriteString("\n")
+	content.WriteString(pressText(trf("%s to scroll", highlightStyle.Render("↑/↓"))) + "\n")
+	content.WriteString(modifyHelpText("", true, true, false))
+	return content.String()
+}
+
+// truncatePathLeft keeps the end of a path, where the file name is.
+func truncatePathLeft(path string, width int) string {
+	if excess := ansi.StringWidth(path) - width; excess > 0 {
+		return ansi.TruncateLeft(path, excess+1, "…")
+	}
+	return path
+}
+
+func (s *hotspotsScreen) keyHelp() []helpLine {
+	return []helpLine{{"↑/↓", "scroll the files"}}
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+type leaderboardScreen struct {
+	commits []models.CommitInfo
+	ranking int // index into utils.LeaderboardRankings
+	entries []utils.LeaderboardEntry
+}
+
+func newLeaderboardScreen(commits []models.CommitInfo) ScreenModel {
+	s := &leaderboardScreen{commits: commits}
+	s.entries = utils.Leaderboard(commits, utils.LeaderboardRankings[s.ranking])
+	return s
+}
+
+func (s *leaderboardScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(keyMsg, keys.Back) {
+			return s, func() tea.Msg {
+				return NavigateMsg{To: models.ResultsScreen}
+			}
+		}
+
+		switch keyMsg.Type {
+		case tea.KeyTab:
+			s.ranking = (s.ranking + 1) % len(utils.LeaderboardRankings)
+			s.entries = utils.Leaderboard(s.commits, utils.LeaderboardRankings[s.ranking])
+
+		}
+	}
+	return s, nil
+}
+
+func (s *leaderboardScreen) View(width, height int) string {
+	var content strings.Builder
+
+	var tabs []string
+	for i, by := range utils.LeaderboardRankings {
+		label := trf("By %s", tr(by))
+		if i == s.ranking {
+			tabs = append(tabs, highlightStyle.Render("["+label+"]"))
+		} else {
+			tabs = append(tabs, dimmedStyle.Render(" "+label+" "))
+		}
+	}
+	content.WriteString(strings.Join(tabs, "  ") + "\n\n")
+
+	maxRows := height - 18
+	if maxRows < 5 {
+		maxRows = 5
+	}
+	content.WriteString(commitHashStyle.Render(fmt.Sprintf("%4s  %-24s %8s %8s %10s", "#", tr("Author"), tr("Commits"), tr("Files"), tr("Lines"))))
+	content.WriteString("\n")
+	for i, e := range s.entries {
+		if i == maxRows {
+			content.WriteString(dimmedStyle.Render(trf("...and %d more authors", len(s.entries)-maxRows) + "\n"))
+			break
+		}
+		name := padRight(truncate(e.Name, 24), 24)
+		content.WriteString(fmt.Sprintf("%4d  %s %8d %8d %10d\n", i+1, commitAuthorStyle.Render(name), e.Commits, e.Files, e.Lines()))
+	}
+
+	content.WriteString("\n")
+	content.WriteString(pressText(trf("%s to change the ranking", highlightStyle.Render("Tab"))) + "\n")
+	content.WriteString(modifyHelpText("", true, true, false))
+	return content.String()
+}
+
+func (s *leaderboardScreen) keyHelp() []helpLine {
+	return []helpLine{{"Tab", "change the ranking"}}
+}
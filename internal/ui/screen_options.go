@@ -3,6 +3,8 @@ package ui
 import (
 	"fmt"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/leeozaka/gommits/internal/git"
@@ -16,14 +18,21 @@ type optionsScreen struct {
 	author            string
 	parentBranch      string
 	currentBranchOnly bool
+	missing           bool
+	unmerged          bool
 	showFiles         bool
 	dotnetMode        bool
 	editing           bool
 	editingField      string
+	branches          *fuzzyPicker // open while choosing the parent branch
+
+	// fetching is set while the commits load, which progress follows.
+	fetching bool
+	progress progressView
 }
 
 func newOptionsScreen(svc git.GitService, directory, author, parentBranch string) ScreenModel {
-	ti := textinput.New()
+	ti := newTextInput()
 	ti.CharLimit = 256
 	ti.Width = 50
 	ti.Blur()
@@ -38,8 +47,8 @@ func newOptionsScreen(svc git.GitService, directory, author, parentBranch string
 	}
 }
 
-func newOptionsScreenWithValues(svc git.GitService, directory, author, parentBranch string, currentBranchOnly, showFiles, dotnetMode bool) ScreenModel {
-	ti := textinput.New()
+func newOptionsScreenWithValues(svc git.GitService, directory, author, parentBranch string, currentBranchOnly, missing, unmerged, showFiles, dotnetMode bool) ScreenModel {
+	ti := newTextInput()
 	ti.CharLimit = 256
 	ti.Width = 50
 	ti.Blur()
@@ -50,6 +59,8 @@ func newOptionsScreenWithValues(svc git.GitService, directory, author, parentBra
 		author:            author,
 		parentBranch:      parentBranch,
 		currentBranchOnly: currentBranchOnly,
+		missing:           missing,
+		unmerged:          unmerged,
 		showFiles:         showFiles,
 		dotnetMode:        dotnetMode,
 	}
@@ -71,21 +82,58 @@ func (s *optionsScreen) stopEditing() {
 	s.textInput.SetValue("")
 }
 
+// fetch starts loading the commits with the chosen options.
+func (s *optionsScreen) fetch(maxCommits int) tea.Cmd {
+	s.fetching = true
+	s.progress = newProgressView("Fetching commits...", "%d of %d commits")
+	return tea.Batch(
+		fetchCommitsProgressCmd(s.gitService, s.directory, s.author, maxCommits, s.currentBranchOnly, s.missing, s.unmerged, s.parentBranch, s.dotnetMode),
+		s.progress.start(),
+	)
+}
+
 func (s *optionsScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case progressMsg:
+		s.progress.set(msg.done, msg.total)
+		return s, nil
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		s.progress, cmd = s.progress.update(msg)
+		return s, cmd
+	case models.FetchCommitsMsg:
+		// Only a failed fetch leaves this screen showing.
+		s.fetching = false
+		return s, nil
+	}
+
 	keyMsg, ok := msg.(tea.KeyMsg)
-	if !ok {
+	if !ok || s.fetching {
 		return s, nil
 	}
 
+	if s.branches != nil {
+		switch keyMsg.Type {
+		case tea.KeyEnter:
+			if branch := s.branches.picked(); branch != "" {
+				s.parentBranch = branch
+			}
+			s.branches = nil
+			return s, nil
+		case tea.KeyEsc:
+			s.branches = nil
+			return s, nil
+		}
+		var cmd tea.Cmd
+		*s.branches, cmd = s.branches.update(msg)
+		return s, cmd
+	}
+
 	if s.editing {
 		switch keyMsg.Type {
 		case tea.KeyEnter:
 			val := s.textInput.Value()
 			switch s.editingField {
-			case "parentBranch":
-				if val != "" {
-					s.parentBranch = val
-				}
 			case "maxCommits":
 				maxCommits := 0
 				if val != "" {
@@ -95,7 +143,7 @@ func (s *optionsScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
 					}
 				}
 				s.stopEditing()
-				return s, fetchCommitsCmd(s.gitService, s.directory, s.author, maxCommits, s.currentBranchOnly, s.parentBranch, s.dotnetMode)
+				return s, s.fetch(maxCommits)
 			}
 			s.stopEditing()
 			return s, nil
@@ -109,9 +157,15 @@ func (s *optionsScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
 		return s, cmd
 	}
 
+	if key.Matches(keyMsg, keys.Back) {
+		return s, func() tea.Msg {
+			return NavigateMsg{To: models.AuthorScreen}
+		}
+	}
+
 	switch keyMsg.Type {
 	case tea.KeyEnter:
-		return s, fetchCommitsCmd(s.gitService, s.directory, s.author, 0, s.currentBranchOnly, s.parentBranch, s.dotnetMode)
+		return s, s.fetch(0)
 
 	case tea.KeyTab:
 		if keyMsg.Alt {
@@ -126,14 +180,21 @@ func (s *optionsScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
 		switch key {
 		case "d":
 			s.dotnetMode = !s.dotnetMode
+		case "r":
+			s.missing = !s.missing
+			s.unmerged = false
+		case "u":
+			s.unmerged = !s.unmerged
+			s.missing = false
 		case "p":
-			return s, s.startEditing("parentBranch", "Enter parent branch name", s.parentBranch)
-		case "m":
-			return s, s.startEditing("maxCommits", "Enter maximum number of commits (0 for no limit)", "0")
-		case "b":
-			return s, func() tea.Msg {
-				return NavigateMsg{To: models.AuthorScreen}
+			picker, err := newBranchPicker(s.gitService, s.directory)
+			if err != nil {
+				return s, errorCmd(err, "listing branches")
 			}
+			s.branches = &picker
+			return s, textinput.Blink
+		case "m":
+			return s, s.startEditing("maxCommits", tr("Enter maximum number of commits (0 for no limit)"), "0")
 		}
 	}
 
@@ -143,23 +204,46 @@ func (s *optionsScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
 func (s *optionsScreen) View(width, height int) string {
 	var content string
 
+	if s.fetching {
+		return s.progress.view()
+	}
+
+	if s.branches != nil {
+		return branchPickerView(*s.branches, width, height)
+	}
+
 	if s.editing {
 		content += s.textInput.View() + "\n"
-		content += dimmedStyle.Render("Press Enter to confirm, Esc to cancel.") + "\n\n"
+		content += dimmedStyle.Render(tr("Press Enter to confirm, Esc to cancel.")) + "\n\n"
 		return content
 	}
 
-	content += "Press " + highlightStyle.Render("Enter") + " to fetch commits.\n"
-	content += "Press " + highlightStyle.Render("M") + " to set max commits.\n"
-	content += "Press " + highlightStyle.Render("P") + " to edit parent branch (" + s.parentBranch + ").\n"
-	content += "Press " + highlightStyle.Render("Tab") + " to toggle current branch only (" + boolToYesNo(s.currentBranchOnly) + ").\n"
-	content += "Press " + highlightStyle.Render("Alt+Tab") + " to toggle show files (" + boolToYesNo(s.showFiles) + ").\n"
-	content += "Press " + highlightStyle.Render("D") + " to toggle dotnet project mode (" + boolToYesNo(s.dotnetMode) + ").\n"
+	content += trf("Press %s to fetch commits.", highlightStyle.Render("Enter")) + "\n"
+	content += trf("Press %s to set max commits.", highlightStyle.Render("M")) + "\n"
+	content += trf("Press %s to edit parent branch (%s).", highlightStyle.Render("P"), s.parentBranch) + "\n"
+	content += trf("Press %s to toggle current branch only (%s).", highlightStyle.Render("Tab"), boolToYesNo(s.currentBranchOnly)) + "\n"
+	content += trf("Press %s to toggle missing from %s (commits on the parent branch not on yours: %s).", highlightStyle.Render("R"), s.parentBranch, boolToYesNo(s.missing)) + "\n"
+	content += trf("Press %s to toggle unmerged only (commits on any branch not yet on %s: %s).", highlightStyle.Render("U"), s.parentBranch, boolToYesNo(s.unmerged)) + "\n"
+	content += trf("Press %s to toggle show files (%s).", highlightStyle.Render("Alt+Tab"), boolToYesNo(s.showFiles)) + "\n"
+	content += trf("Press %s to toggle dotnet project mode (%s).", highlightStyle.Render("D"), boolToYesNo(s.dotnetMode)) + "\n"
 	authorDisplay := s.author
 	if authorDisplay == "" {
-		authorDisplay = "all authors"
+		authorDisplay = tr("all authors")
 	}
-	content += dimmedStyle.Render("Author filter: "+authorDisplay) + "\n"
+	content += dimmedStyle.Render(trf("Author filter: %s", authorDisplay)) + "\n"
 	content += modifyHelpText("", true, true, false)
 	return content
 }
+
+func (s *optionsScreen) keyHelp() []helpLine {
+	return []helpLine{
+		{"Enter", "fetch commits"},
+		{"M", "set max commits and fetch"},
+		{"P", "edit the parent branch"},
+		{"Tab", "toggle current branch only"},
+		{"R", "toggle commits missing from the parent branch"},
+		{"U", "toggle unmerged commits only"},
+		{"Alt+Tab", "toggle showing changed files"},
+		{"D", "toggle dotnet project mode"},
+	}
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+// runPresetMsg fetches the commits of a preset, skipping the wizard screens.
+type runPresetMsg struct {
+	data    NavigateData
+	formats []string
+}
+
+type presetScreen struct {
+	gitService git.GitService
+	presets    []config.PresetConfig
+	picker     fuzzyPicker
+	searching  bool // the picker's query field has focus
+}
+
+func newPresetScreen(svc git.GitService, presets []config.PresetConfig) ScreenModel {
+	items := make([]fuzzyItem, len(presets))
+	for i, p := range presets {
+		name := p.Name
+		if name == "" {
+			name = trf("preset %d", i+1)
+		}
+		author := p.Author
+		if author == "" {
+			author = tr("all authors")
+		}
+		items[i] = fuzzyItem{Label: name, Detail: filepath.Base(p.Repo) + " · " + author}
+	}
+	picker := newFuzzyPicker(tr("preset name"), items)
+	picker.input.Blur()
+	return &presetScreen{gitService: svc, presets: presets, picker: picker}
+}
+
+func (s *presetScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return s, nil
+	}
+
+	if s.searching {
+		switch keyMsg.Type {
+		case tea.KeyEnter:
+			s.searching = false
+			s.picker.input.Blur()
+			return s, nil
+		case tea.KeyEsc:
+			s.searching = false
+			s.picker.input.Blur()
+			s.picker.input.SetValue("")
+			s.picker.list.setQuery("")
+			return s, nil
+		}
+		var cmd tea.Cmd
+		s.picker, cmd = s.picker.update(msg)
+		return s, cmd
+	}
+
+	if key.Matches(keyMsg, keys.Back) {
+		return s, func() tea.Msg {
+			return NavigateMsg{To: models.HomeScreen}
+		}
+	}
+	if s.picker.list.move(keyMsg) {
+		return s, nil
+	}
+	switch {
+	case keyMsg.Type == tea.KeyEnter:
+		if i, ok := s.picker.list.chosen(); ok {
+			return s, s.run(s.presets[i])
+		}
+	case keyMsg.String() == "/":
+		s.searching = true
+		return s, s.picker.input.Focus()
+	}
+	return s, nil
+}
+
+// run resolves the preset's repository the way the directory screen does and
+// starts its fetch.
+func (s *presetScreen) run(p config.PresetConfig) tea.Cmd {
+	if p.Repo == "" {
+		return errorCmd(fmt.Errorf(tr("preset %s has no repo"), p.Name), "running preset")
+	}
+	dir, err := utils.ResolveDir(p.Repo)
+	if err != nil {
+		return errorCmd(err, "resolving directory path")
+	}
+	if !s.gitService.IsGitRepo(dir) {
+		return errorCmd(fmt.Errorf(tr("%s is not a Git repository"), dir), "validating repository")
+	}
+	branch, err := s.gitService.GetCurrentBranch(dir)
+	if err != nil {
+		return errorCmd(err, "getting branch name")
+	}
+	parentBranch := p.ParentBranch
+	if parentBranch == "" {
+		parentBranch = s.gitService.DetectDefaultBranch(dir)
+	}
+
+	return func() tea.Msg {
+		return runPresetMsg{
+			data: NavigateData{
+				Directory:    dir,
+				Author:       p.Author,
+				Branch:       branch,
+				ParentBranch: parentBranch,
+			},
+			formats: p.Formats,
+		}
+	}
+}
+
+func (s *presetScreen) View(width, height int) string {
+	if len(s.presets) == 0 {
+		return tr("No presets saved. Add them under \"presets\" in the config file.") + "\n\n" + modifyHelpText("", true, true, false)
+	}
+
+	var content strings.Builder
+	if s.searching || s.picker.input.Value() != "" {
+		content.WriteString(s.picker.input.View() + "\n")
+	}
+	content.WriteString(s.picker.list.view(max(height-20, 5), width))
+	content.WriteString("\n")
+	content.WriteString(pressText(
+		trf("%s to select a preset", highlightStyle.Render("↑/↓")),
+		trf("%s to search them", highlightStyle.Render("/")),
+	) + "\n")
+	content.WriteString(modifyHelpText("run it", true, true, false))
+	return content.String()
+}
+
+func (s *presetScreen) keyHelp() []helpLine {
+	return []helpLine{
+		{"↑/↓", "select a preset"},
+		{"/", "search the presets"},
+		{"Enter", "run it"},
+	}
+}
@@ -2,121 +2,910 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/leeozaka/gommits/internal/config"
 	"github.com/leeozaka/gommits/internal/git"
 	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
 )
 
 type resultsScreen struct {
 	gitService   git.GitService
+	config       config.Config
 	commits      []models.CommitInfo
 	directory    string
 	branch       string
 	parentBranch string
 	showFiles    bool
 	dotnetMode   bool
+	dco          bool // the repository enforces Signed-off-by
+
+	list    list.Model
+	listTop int // line of the rendered view the list starts on, for mouse clicks
+
+	search     textinput.Model
+	searching  bool   // the search bar has focus
+	query      string // lowercased filter currently applied
+	groupBy    string // period the list is grouped by, see utils.GroupModes
+	marked     map[string]bool
+	haystacks  []string // searchText per commit, built on the first search
+	matchCount int
+
+	// fetched is the query that produced commits; it can be changed inline and run again.
+	fetched fetchQuery
+	filter  textinput.Model
+	editing string // query field being edited in filter, empty when none
+	picker  *fuzzyPicker
+	picking string // what picker chooses: "parentBranch" or "file"
+
+	// exporting and fetching are set while an export or a new fetch runs, which
+	// progress follows.
+	exporting bool
+	fetching  bool
+	progress  progressView
 }
 
-func newResultsScreen(svc git.GitService, commits []models.CommitInfo, directory, branch, parentBranch string, showFiles, dotnetMode bool) ScreenModel {
-	return &resultsScreen{
+// fetchQuery is the part of a commit query the results screen can change inline.
+type fetchQuery struct {
+	author            string
+	maxCommits        int
+	parentBranch      string
+	currentBranchOnly bool
+	missing           bool
+	unmerged          bool
+}
+
+func newResultsScreen(svc git.GitService, cfg config.Config, commits []models.CommitInfo, directory, branch, parentBranch string, showFiles, dotnetMode bool) ScreenModel {
+	dco := utils.UsesDCO(commits)
+	search := newTextInput()
+	search.Prompt = "/"
+	search.Placeholder = tr("message, file or hash")
+	search.CharLimit = 128
+	search.Width = 40
+	filter := newTextInput()
+	filter.CharLimit = 256
+	filter.Width = 50
+	marked := make(map[string]bool)
+	s := &resultsScreen{
 		gitService:   svc,
+		config:       cfg,
 		commits:      commits,
 		directory:    directory,
 		branch:       branch,
 		parentBranch: parentBranch,
 		showFiles:    showFiles,
 		dotnetMode:   dotnetMode,
+		dco:          dco,
+		search:       search,
+		filter:       filter,
+		matchCount:   len(commits),
+		marked:       marked,
 	}
+	s.list = newCommitList(commits, s.delegate())
+	if groupBy, err := utils.ResolveGroupBy(cfg.Export.GroupBy); err == nil && groupBy != "" {
+		s.groupBy = groupBy
+		s.rebuildList()
+	}
+	return s
 }
 
 func (s *resultsScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case progressMsg:
+		s.progress.set(msg.done, msg.total)
+		return s, nil
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		s.progress, cmd = s.progress.update(msg)
+		return s, cmd
+	case models.ExportExcelMsg:
+		s.exporting = false
+		return s, nil
+	case models.FetchCommitsMsg:
+		// Only a failed fetch leaves this screen showing.
+		s.fetching = false
+		return s, nil
+	case startExportMsg:
+		if s.exporting {
+			return s, nil
+		}
+		return s, s.startExport()
+	}
+
+	if s.searching {
+		return s.updateSearch(msg)
+	}
+	if s.editing != "" {
+		return s.updateFilter(msg)
+	}
+	if s.picker != nil {
+		return s.updatePicker(msg)
+	}
+
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if s.fetching {
+			return s, nil
+		}
+		switch {
+		case key.Matches(keyMsg, keys.Export):
+			if s.exporting {
+				return s, nil
+			}
+			if existing := s.existingExports(); len(existing) > 0 {
+				names := make([]string, len(existing))
+				for i, path := range existing {
+					names[i] = filepath.Base(path)
+				}
+				return s, confirmCmd(trf("Overwrite %s?", strings.Join(names, ", ")), func() tea.Msg {
+					return startExportMsg{}
+				})
+			}
+			return s, s.startExport()
+		case key.Matches(keyMsg, keys.Back):
+			return s, func() tea.Msg {
+				return NavigateMsg{To: models.OptionsScreen}
+			}
+		}
+
 		switch keyMsg.Type {
-		case tea.KeyEnter:
-			if s.dotnetMode {
-				return s, exportDotnetExcelCmd(s.gitService, s.commits, s.directory, s.branch, s.parentBranch)
+		case tea.KeyCtrlP:
+			if len(s.commits) > 0 {
+				picker := newFuzzyPicker(tr("file path"), fileItems(s.commits))
+				s.picker, s.picking = &picker, "file"
+				return s, textinput.Blink
 			}
-			return s, exportExcelCmd(s.gitService, s.commits, s.directory)
+			return s, nil
+
+		case tea.KeySpace:
+			if c, ok := s.selected(); ok {
+				s.toggleMark(c.Hash)
+				s.updateList(tea.KeyMsg{Type: tea.KeyDown})
+			}
+			return s, nil
 
 		case tea.KeyRunes:
-			if string(keyMsg.Runes) == "b" {
+			switch string(keyMsg.Runes) {
+			case "/":
+				s.searching = true
+				return s, s.search.Focus()
+			case "a":
+				s.markAll()
+				return s, nil
+			case "e":
+				return s, s.startEditing("author", tr("Enter author(s) to filter, or leave empty for all"), s.fetched.author)
+			case "m":
+				return s, s.startEditing("maxCommits", tr("Enter maximum number of commits (0 for no limit)"), strconv.Itoa(s.fetched.maxCommits))
+			case "r":
+				if s.exporting {
+					return s, nil
+				}
+				picker, err := newBranchPicker(s.gitService, s.directory)
+				if err != nil {
+					return s, errorCmd(err, "listing branches")
+				}
+				s.picker, s.picking = &picker, "parentBranch"
+				return s, textinput.Blink
+			case "t":
+				if s.exporting {
+					return s, nil
+				}
+				q := s.fetched
+				q.currentBranchOnly = !q.currentBranchOnly
+				return s, s.refetch(q)
+			case "o":
+				if c, ok := s.selected(); ok {
+					return s, openCommitCmd(c)
+				}
+			case "y":
+				if commits := s.cherryPickSet(); len(commits) > 0 {
+					return s, copyCherryPickCmd(commits)
+				}
+			case "g":
+				s.groupBy = nextGroupMode(s.groupBy)
+				s.rebuildList()
+				return s, nil
+			case "w":
+				return s, toggleWatchCmd()
+			case "c":
+				return s, copyChangelogCmd(s.config, s.commits)
+			case "l":
 				return s, func() tea.Msg {
-					return NavigateMsg{To: models.OptionsScreen}
+					return NavigateMsg{To: models.LeaderboardScreen}
+				}
+			case "s":
+				return s, func() tea.Msg {
+					return NavigateMsg{To: models.StatsScreen}
+				}
+			case "h":
+				return s, func() tea.Msg {
+					return NavigateMsg{To: models.HeatmapScreen}
+				}
+			case "i":
+				return s, func() tea.Msg {
+					return NavigateMsg{To: models.ActivityScreen}
+				}
+			case "f":
+				return s, func() tea.Msg {
+					return NavigateMsg{To: models.HotspotsScreen}
+				}
+			case "v":
+				return s, func() tea.Msg {
+					return NavigateMsg{To: models.CompareScreen}
+				}
+			case "d":
+				if c, ok := s.selected(); ok {
+					return s, func() tea.Msg {
+						return NavigateMsg{To: models.DiffScreen, Data: NavigateData{Commit: c.Hash}}
+					}
+				}
+			case "p":
+				if c, ok := s.selected(); ok {
+					return s, openPagerCmd(s.config, s.directory, c.Hash)
 				}
 			}
 		}
 	}
-	return s, nil
+
+	if mouseMsg, ok := msg.(tea.MouseMsg); ok {
+		return s, s.updateMouse(mouseMsg)
+	}
+
+	return s, s.updateList(msg)
 }
 
-func (s *resultsScreen) View(width, height int) string {
-	var content strings.Builder
+// startEditing opens the inline field that edits one setting of the query, prefilled with value.
+func (s *resultsScreen) startEditing(field, placeholder, value string) tea.Cmd {
+	if s.exporting {
+		return nil
+	}
+	s.editing = field
+	s.filter.Placeholder = placeholder
+	s.filter.SetValue(value)
+	return s.filter.Focus()
+}
 
-	if len(s.commits) == 0 {
-		content.WriteString("No commits found for this author.\n\n")
-	} else {
-		content.WriteString(fmt.Sprintf("Found %d commits:\n\n", len(s.commits)))
+func (s *resultsScreen) stopEditing() {
+	s.editing = ""
+	s.filter.Blur()
+	s.filter.SetValue("")
+}
 
-		availableHeight := height - 15
-		if availableHeight < 10 {
-			availableHeight = 10
+// updateFilter edits one field of the query, fetching again with it on Enter.
+func (s *resultsScreen) updateFilter(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.Type {
+		case tea.KeyEnter:
+			q := s.fetched
+			val := strings.TrimSpace(s.filter.Value())
+			switch s.editing {
+			case "author":
+				q.author = val
+			case "maxCommits":
+				q.maxCommits = 0
+				fmt.Sscanf(val, "%d", &q.maxCommits)
+				q.maxCommits = max(q.maxCommits, 0)
+			}
+			s.stopEditing()
+			if q == s.fetched {
+				return s, nil
+			}
+			return s, s.refetch(q)
+		case tea.KeyEsc:
+			s.stopEditing()
+			return s, nil
 		}
+	}
 
-		linesPerCommit := 5
-		if s.showFiles {
-			linesPerCommit = 7
+	var cmd tea.Cmd
+	s.filter, cmd = s.filter.Update(msg)
+	return s, cmd
+}
+
+// updatePicker chooses a parent branch to fetch again with, or a file to filter
+// the commits by.
+func (s *resultsScreen) updatePicker(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.Type {
+		case tea.KeyEnter:
+			picked := s.picker.picked()
+			s.picker = nil
+			if picked == "" {
+				return s, nil
+			}
+			if s.picking == "file" {
+				s.search.SetValue(picked)
+				s.rebuildList()
+				return s, nil
+			}
+			q := s.fetched
+			q.parentBranch = picked
+			if q == s.fetched {
+				return s, nil
+			}
+			return s, s.refetch(q)
+		case tea.KeyEsc:
+			s.picker = nil
+			return s, nil
 		}
+	}
+
+	var cmd tea.Cmd
+	*s.picker, cmd = s.picker.update(msg)
+	return s, cmd
+}
 
-		maxDisplayCommits := availableHeight / linesPerCommit
-		if maxDisplayCommits < 1 {
-			maxDisplayCommits = 1
+// fileItems lists every file the commits touch, most often changed first.
+func fileItems(commits []models.CommitInfo) []fuzzyItem {
+	counts := make(map[string]int)
+	for _, c := range commits {
+		for _, f := range c.Files {
+			counts[f]++
 		}
-		if maxDisplayCommits > 5 {
-			maxDisplayCommits = 5
+	}
+	files := make([]string, 0, len(counts))
+	for f := range counts {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if counts[files[i]] != counts[files[j]] {
+			return counts[files[i]] > counts[files[j]]
 		}
+		return files[i] < files[j]
+	})
+
+	items := make([]fuzzyItem, len(files))
+	for i, f := range files {
+		items[i] = fuzzyItem{Label: f, Detail: trf("%d commits", counts[f])}
+	}
+	return items
+}
+
+// refetch loads the commits for q; the model replaces this screen once they arrive.
+func (s *resultsScreen) refetch(q fetchQuery) tea.Cmd {
+	s.fetching = true
+	s.progress = newProgressView("Fetching commits...", "%d of %d commits")
+	return tea.Batch(
+		fetchCommitsProgressCmd(s.gitService, s.directory, q.author, q.maxCommits, q.currentBranchOnly, q.missing, q.unmerged, q.parentBranch, s.dotnetMode),
+		s.progress.start(),
+	)
+}
 
-		displayCount := len(s.commits)
-		if displayCount > maxDisplayCommits {
-			displayCount = maxDisplayCommits
+// startExportMsg starts the export once overwriting its files has been confirmed.
+type startExportMsg struct{}
+
+// existingExports lists the files an export would overwrite.
+func (s *resultsScreen) existingExports() []string {
+	repoName := s.gitService.GetRepositoryName(s.directory)
+	if s.dotnetMode {
+		path := utils.DotnetExcelPath(utils.ExportDir(s.directory), repoName)
+		if _, err := os.Stat(path); err != nil {
+			return nil
 		}
+		return []string{path}
+	}
+	return utils.ExistingExports(s.directory, repoName, s.config)
+}
 
-		for i := 0; i < displayCount; i++ {
-			c := s.commits[i]
-			content.WriteString(commitHashStyle.Render(fmt.Sprintf("Commit: %s", c.Hash)))
-			content.WriteString("\n")
-			content.WriteString(fmt.Sprintf("  Author: %s", commitAuthorStyle.Render(c.Author)))
-			content.WriteString("\n")
-			content.WriteString(fmt.Sprintf("  Date: %s", c.Date))
-			content.WriteString("\n")
+// startExport exports the marked commits, or all of them, following its progress.
+func (s *resultsScreen) startExport() tea.Cmd {
+	commits := s.exportSet()
+	s.exporting = true
+	s.progress = newProgressView("Exporting...", "%d of %d rows")
+	export := exportExcelCmd(s.gitService, s.config, commits, s.directory, s.branch)
+	if s.dotnetMode {
+		export = exportDotnetExcelCmd(s.gitService, s.config, commits, s.directory, s.branch, s.parentBranch)
+	}
+	return tea.Batch(export, s.progress.start())
+}
 
-			message := c.Message
-			if len(message) > 60 {
-				message = message[:57] + "..."
-			}
-			content.WriteString(fmt.Sprintf("  Message: %s", message))
-			content.WriteString("\n")
+// updateMouse scrolls the list with the wheel and selects the commit clicked on.
+func (s *resultsScreen) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return s.updateList(tea.KeyMsg{Type: tea.KeyUp})
+	case tea.MouseButtonWheelDown:
+		return s.updateList(tea.KeyMsg{Type: tea.KeyDown})
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress || msg.X < 0 || msg.X >= s.list.Width() {
+			return nil
+		}
+		d := commitDelegate{}
+		row := msg.Y - s.listTop
+		slot := row / (d.Height() + d.Spacing())
+		if row < 0 || row%(d.Height()+d.Spacing()) >= d.Height() || slot >= s.list.Paginator.PerPage {
+			return nil
+		}
+		index := s.list.Paginator.Page*s.list.Paginator.PerPage + slot
+		if index >= len(s.list.Items()) {
+			return nil
+		}
+		if _, ok := s.list.Items()[index].(commitItem); ok {
+			s.list.Select(index)
+		}
+	}
+	return nil
+}
 
-			if s.showFiles && len(c.Files) > 0 {
-				fileCount := len(c.Files)
-				if fileCount > 3 {
-					content.WriteString(fmt.Sprintf("  Files: %s\n", commitFilesStyle.Render(
-						fmt.Sprintf("%s and %d more...", strings.Join(c.Files[:3], ", "), fileCount-3))))
-				} else {
-					content.WriteString(fmt.Sprintf("  Files: %s\n", commitFilesStyle.Render(strings.Join(c.Files, ", "))))
-				}
+// updateSearch edits the filter while the search bar has focus: Enter keeps the filter,
+// Esc clears it, and the arrow keys still move through the narrowed list.
+func (s *resultsScreen) updateSearch(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.Type {
+		case tea.KeyEnter:
+			s.searching = false
+			s.search.Blur()
+			return s, nil
+		case tea.KeyEsc:
+			s.searching = false
+			s.search.Blur()
+			s.search.SetValue("")
+			s.rebuildList()
+			return s, nil
+		case tea.KeyUp, tea.KeyDown, tea.KeyPgUp, tea.KeyPgDown:
+			return s, s.updateList(msg)
+		}
+	}
+
+	query := s.search.Value()
+	var cmd tea.Cmd
+	s.search, cmd = s.search.Update(msg)
+	if s.search.Value() != query {
+		s.rebuildList()
+	}
+	return s, cmd
+}
+
+func (s *resultsScreen) delegate() commitDelegate {
+	return commitDelegate{dco: s.dco, query: s.query, marked: s.marked, hyperlinks: s.config.UI.Hyperlinks && !plain}
+}
+
+// updateList forwards navigation to the list, stepping over group headers in the
+// direction the cursor moved.
+func (s *resultsScreen) updateList(msg tea.Msg) tea.Cmd {
+	before := s.list.Index()
+	var cmd tea.Cmd
+	s.list, cmd = s.list.Update(msg)
+	s.skipHeader(s.list.Index() < before)
+	return cmd
+}
+
+func (s *resultsScreen) skipHeader(up bool) {
+	if _, ok := s.list.SelectedItem().(groupHeader); !ok {
+		return
+	}
+	if up && s.list.Index() > 0 {
+		s.list.CursorUp()
+	} else {
+		s.list.CursorDown()
+	}
+}
+
+func nextGroupMode(current string) string {
+	for i, mode := range utils.GroupModes {
+		if mode == current {
+			return utils.GroupModes[(i+1)%len(utils.GroupModes)]
+		}
+	}
+	return ""
+}
+
+// rebuildList narrows the list to the commits matching the search query and groups
+// them by period, keeping the selected commit under the cursor when it still matches.
+func (s *resultsScreen) rebuildList() {
+	current, _ := s.selected()
+	query := strings.ToLower(strings.TrimSpace(s.search.Value()))
+
+	matches := s.commits
+	if query != "" {
+		if s.haystacks == nil {
+			s.haystacks = make([]string, len(s.commits))
+			for i, c := range s.commits {
+				s.haystacks[i] = searchText(c)
+			}
+		}
+		matches = nil
+		for i, c := range s.commits {
+			if strings.Contains(s.haystacks[i], query) {
+				matches = append(matches, c)
 			}
-			content.WriteString("\n")
 		}
+	}
+
+	s.query = query
+	s.matchCount = len(matches)
+	s.list.SetDelegate(s.delegate())
+	s.list.SetItems(commitItems(matches, s.groupBy))
+	s.list.Select(0)
+	s.skipHeader(false)
+	s.selectCommit(current.Hash)
+}
 
-		if len(s.commits) > displayCount {
-			content.WriteString(dimmedStyle.Render(fmt.Sprintf("...and %d more commits\n", len(s.commits)-displayCount)))
+// keepView carries the search and selection of the screen being replaced, so refreshed
+// results stay where the user was.
+func (s *resultsScreen) keepView(previous *resultsScreen) {
+	current, _ := previous.selected()
+	for _, c := range s.commits {
+		if previous.marked[c.Hash] {
+			s.marked[c.Hash] = true
 		}
 	}
+	s.search.SetValue(previous.search.Value())
+	s.groupBy = previous.groupBy
+	s.editing, s.filter = previous.editing, previous.filter
+	s.picker, s.picking = previous.picker, previous.picking
+	s.exporting, s.fetching, s.progress = previous.exporting, previous.fetching, previous.progress
+	s.rebuildList()
+	s.selectCommit(current.Hash)
+}
+
+func (s *resultsScreen) toggleMark(hash string) {
+	if s.marked[hash] {
+		delete(s.marked, hash)
+	} else {
+		s.marked[hash] = true
+	}
+}
+
+// markAll marks every listed commit, or clears the marks when all of them already are.
+func (s *resultsScreen) markAll() {
+	var listed []string
+	all := true
+	for _, item := range s.list.Items() {
+		if c, ok := item.(commitItem); ok {
+			listed = append(listed, c.commit.Hash)
+			all = all && s.marked[c.commit.Hash]
+		}
+	}
+	for _, hash := range listed {
+		if all {
+			delete(s.marked, hash)
+		} else {
+			s.marked[hash] = true
+		}
+	}
+}
+
+// exportSet is what Enter exports: the marked commits in result order, or all of them
+// when none are marked.
+func (s *resultsScreen) exportSet() []models.CommitInfo {
+	if len(s.marked) == 0 {
+		return s.commits
+	}
+	commits := make([]models.CommitInfo, 0, len(s.marked))
+	for _, c := range s.commits {
+		if s.marked[c.Hash] {
+			commits = append(commits, c)
+		}
+	}
+	return commits
+}
+
+// cherryPickSet is what Y copies: the marked commits, or else the selected one.
+func (s *resultsScreen) cherryPickSet() []models.CommitInfo {
+	if len(s.marked) > 0 {
+		return s.exportSet()
+	}
+	if c, ok := s.selected(); ok {
+		return []models.CommitInfo{c}
+	}
+	return nil
+}
+
+// selected returns the commit under the list cursor.
+func (s *resultsScreen) selected() (models.CommitInfo, bool) {
+	item, ok := s.list.SelectedItem().(commitItem)
+	return item.commit, ok
+}
+
+// position is the 1-based number of the selected commit among those listed.
+func (s *resultsScreen) position() int {
+	item, _ := s.list.SelectedItem().(commitItem)
+	return item.ordinal
+}
+
+// selectCommit moves the cursor to the commit with the given hash, if it is listed.
+func (s *resultsScreen) selectCommit(hash string) {
+	if hash == "" {
+		return
+	}
+	for i, item := range s.list.Items() {
+		if c, ok := item.(commitItem); ok && c.commit.Hash == hash {
+			s.list.Select(i)
+			return
+		}
+	}
+}
+
+func (s *resultsScreen) View(width, height int) string {
+	if s.picker != nil {
+		if s.picking == "parentBranch" {
+			return branchPickerView(*s.picker, width, height)
+		}
+		return tr("Show the commits that changed:") + "\n\n" +
+			s.picker.view(max(height-20, 5), width) + "\n" +
+			pressText(
+				trf("%s to select a file", highlightStyle.Render("↑/↓")),
+				trf("%s to filter by it", highlightStyle.Render("Enter")),
+				trf("%s to cancel", highlightStyle.Render("Esc")),
+			) + "\n"
+	}
+
+	var content strings.Builder
+
+	if len(s.commits) == 0 {
+		content.WriteString(tr("No commits found for this author.") + "\n\n")
+	} else {
+		if s.searching || s.search.Value() != "" {
+			content.WriteString(s.search.View())
+			content.WriteString("\n")
+		}
+		switch {
+		case s.matchCount == 0:
+			content.WriteString(trf("No commits match %q.", s.search.Value()) + "\n\n")
+		case s.matchCount < len(s.commits):
+			content.WriteString(trf("Commit %d of %d matching (%d loaded):", s.position(), s.matchCount, len(s.commits)) + "\n\n")
+		default:
+			content.WriteString(trf("Commit %d of %d:", s.position(), len(s.commits)) + "\n\n")
+		}
+		if s.matchCount > 0 {
+			s.listTop = strings.Count(content.String(), "\n")
+			content.WriteString(s.body(width, height))
+		}
+		hint := tr("↑/↓ select, ←/→ or PgUp/PgDn page, Home/End jump")
+		if s.config.UI.Vim {
+			hint = tr("j/k select, h/l or Ctrl+D/Ctrl+U page, gg/G jump")
+		}
+		if s.groupBy != "" {
+			hint += " · " + trf("grouped by %s", tr(s.groupBy))
+		}
+		if len(s.marked) > 0 {
+			hint += " · " + trf("%d marked for export", len(s.marked))
+		}
+		content.WriteString(dimmedStyle.Render(hint) + "\n")
+	}
 	content.WriteString("\n")
-	content.WriteString("Press " + highlightStyle.Render("Enter") + " to export to Excel.\n")
+	if s.editing != "" {
+		content.WriteString(s.filter.View() + "\n")
+		content.WriteString(dimmedStyle.Render(tr("Press Enter to confirm, Esc to cancel.")) + "\n")
+		return content.String()
+	}
+	if s.exporting || s.fetching {
+		content.WriteString(s.progress.view() + "\n")
+		content.WriteString(modifyHelpText("", true, true, false))
+		return content.String()
+	}
+	leaderboard, calendar, grouping := "L", "H", "G"
+	if s.config.UI.Vim {
+		leaderboard, calendar, grouping = "gl", "gh", "gp"
+	}
+	content.WriteString(pressText(
+		trf("%s to export to Excel", highlightStyle.Render(helpKey(keys.Export))),
+		trf("%s to mark a commit for export", highlightStyle.Render("Space")),
+		trf("%s to mark all", highlightStyle.Render("A")),
+		trf("%s to open the selected commit in the browser", highlightStyle.Render("O")),
+		trf("%s to copy a cherry-pick of the marked commits", highlightStyle.Render("Y")),
+		trf("%s to watch for new commits", highlightStyle.Render("W")),
+		trf("%s to copy a changelog", highlightStyle.Render("C")),
+		trf("%s for the leaderboard", highlightStyle.Render(leaderboard)),
+		trf("%s for author statistics", highlightStyle.Render("S")),
+		trf("%s for the activity calendar", highlightStyle.Render(calendar)),
+		trf("%s for commits by weekday and hour", highlightStyle.Render("I")),
+		trf("%s for file hotspots", highlightStyle.Render("F")),
+		trf("%s to compare two authors", highlightStyle.Render("V")),
+		trf("%s to view the selected commit's diff", highlightStyle.Render("D")),
+		trf("%s to open it in your pager", highlightStyle.Render("P")),
+		trf("%s to filter by message, file or hash", highlightStyle.Render("/")),
+		trf("%s to group by day, week or month", highlightStyle.Render(grouping)),
+		trf("%s to change the author", highlightStyle.Render("E")),
+		trf("%s to change the max commits", highlightStyle.Render("M")),
+		trf("%s to change the parent branch", highlightStyle.Render("R")),
+		trf("%s to find a file", highlightStyle.Render("Ctrl+P")),
+		trf("%s to toggle current branch only", highlightStyle.Render("T")),
+	) + "\n")
 	content.WriteString(modifyHelpText("", true, true, false))
 
 	return content.String()
 }
+
+// body lays out the list with the selected commit's record: side by side on wide
+// terminals, otherwise the record below the list.
+func (s *resultsScreen) body(width, height int) string {
+	if width >= splitMinWidth && !accessible {
+		listWidth := width * 2 / 5
+		listHeight := max(height-15, 6)
+		s.list.SetSize(listWidth, listHeight)
+		listView := s.list.View()
+		separator := strings.TrimSuffix(strings.Repeat(" │ \n", lipgloss.Height(listView)), "\n")
+		preview := s.previewView(width-listWidth-3, listHeight)
+		return lipgloss.JoinHorizontal(lipgloss.Top, listView, dimmedStyle.Render(separator), preview) + "\n\n"
+	}
+
+	detail := s.detailView()
+	s.list.SetSize(width, max(height-15-lipgloss.Height(detail), 6))
+	return s.list.View() + "\n\n" + detail
+}
+
+// detailView renders the full record of the selected commit below the list.
+func (s *resultsScreen) detailView() string {
+	c, ok := s.selected()
+	if !ok {
+		return ""
+	}
+
+	content := s.commitSummary(c)
+	if s.showFiles && len(c.Files) > 0 {
+		content += "  " + trf("Files: %s", s.filesSummary(c.Files)) + "\n"
+	}
+	return content + "\n"
+}
+
+// previewView renders the selected commit for the right-hand pane, listing its diff
+// stat and every file that fits in height lines, each cut to width.
+func (s *resultsScreen) previewView(width, height int) string {
+	c, ok := s.selected()
+	if !ok {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimSuffix(s.commitSummary(c), "\n"), "\n")
+	if c.Additions+c.Deletions > 0 || len(c.Files) > 0 {
+		lines = append(lines, "  "+trf("Changes: %s %s in %d files",
+			diffAddedStyle.Render(fmt.Sprintf("+%d", c.Additions)), diffRemovedStyle.Render(fmt.Sprintf("-%d", c.Deletions)), len(c.Files)))
+	}
+	if s.showFiles && len(c.Files) > 0 {
+		lines = append(lines, "  "+tr("Files:"))
+		room := max(height-len(lines), 1)
+		for i, f := range c.Files {
+			if i == room-1 && len(c.Files) > room {
+				lines = append(lines, commitFilesStyle.Render("    "+trf("and %d more...", len(c.Files)-i)))
+				break
+			}
+			lines = append(lines, "    "+highlightMatches(f, s.query, commitFilesStyle))
+		}
+	}
+
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "…")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commitSummary renders the header fields of c, one per line.
+func (s *resultsScreen) commitSummary(c models.CommitInfo) string {
+	var content strings.Builder
+	content.WriteString(commitHashStyle.Render(trf("Commit: %s", c.Hash)))
+	content.WriteString("\n")
+	content.WriteString("  " + trf("Author: %s <%s>", commitAuthorStyle.Render(c.Author), c.Email) + "\n")
+	content.WriteString("  " + trf("Date: %s", c.Date) + "\n")
+	content.WriteString("  " + trf("Message: %s", highlightMatches(c.Message, s.query, lipgloss.NewStyle())) + "\n")
+
+	if pr := c.PullRequest; pr != nil {
+		content.WriteString(fmt.Sprintf("  PR: %s\n", commitFilesStyle.Render(pullRequestSummary(pr))))
+	}
+	if len(c.WorkItems) > 0 {
+		content.WriteString("  " + trf("Work items: %s", commitFilesStyle.Render(workItemsSummary(c.WorkItems, "AB#"))) + "\n")
+	}
+	if len(c.Issues) > 0 {
+		content.WriteString("  " + trf("Issues: %s", commitFilesStyle.Render(workItemsSummary(c.Issues, ""))) + "\n")
+	}
+
+	if c.Unmerged {
+		content.WriteString(fmt.Sprintf("  %s\n", warningTextStyle.Render(symbol("⚠ ")+trf("not merged into %s", s.parentBranch))))
+	}
+
+	if s.dco {
+		if status := utils.DCOStatus(c); status != utils.DCOSigned {
+			content.WriteString(fmt.Sprintf("  DCO: %s\n", warningTextStyle.Render(dcoWarning(status))))
+		}
+	}
+	return content.String()
+}
+
+// filesSummary lists the first few files, preferring those matching the search so the
+// reason a commit was kept stays visible.
+func (s *resultsScreen) filesSummary(files []string) string {
+	shown := files
+	if s.query != "" {
+		var matching, rest []string
+		for _, f := range files {
+			if strings.Contains(strings.ToLower(f), s.query) {
+				matching = append(matching, f)
+			} else {
+				rest = append(rest, f)
+			}
+		}
+		shown = append(matching, rest...)
+	}
+
+	parts := make([]string, 0, 3)
+	for _, f := range shown[:min(len(shown), 3)] {
+		parts = append(parts, highlightMatches(f, s.query, commitFilesStyle))
+	}
+	summary := strings.Join(parts, commitFilesStyle.Render(", "))
+	if len(files) > 3 {
+		summary += commitFilesStyle.Render(" " + trf("and %d more...", len(files)-3))
+	}
+	return summary
+}
+
+func dcoWarning(status string) string {
+	if status == utils.DCOMismatch {
+		return symbol("✗ ") + tr("signed off by someone other than the author")
+	}
+	return symbol("✗ ") + tr("missing Signed-off-by")
+}
+
+func pullRequestSummary(pr *models.PullRequest) string {
+	details := []string{pr.State}
+	if pr.Review != "" {
+		details = append(details, trf("review: %s", pr.Review))
+	}
+	if pr.Checks != "" {
+		details = append(details, trf("checks: %s", pr.Checks))
+	}
+	return fmt.Sprintf("#%d %s (%s)", pr.Number, truncate(pr.Title, 40), strings.Join(details, ", "))
+}
+
+func workItemsSummary(items []models.WorkItem, prefix string) string {
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = prefix + item.ID
+		if item.State != "" {
+			parts[i] += " (" + item.State + ")"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (s *resultsScreen) keyHelp() []helpLine {
+	lines := []helpLine{
+		{"↑/↓", "select a commit"},
+		{"←/→, PgUp/PgDn", "page through the list"},
+		{"Home/End", "jump to the first or last commit"},
+	}
+	leaderboard, calendar, grouping := "L", "H", "G"
+	if s.config.UI.Vim {
+		lines = append(lines,
+			helpLine{"j/k", "select a commit"},
+			helpLine{"h/l, Ctrl+D/Ctrl+U", "page through the list"},
+			helpLine{"gg/G", "jump to the first or last commit"})
+		leaderboard, calendar, grouping = "gl", "gh", "gp"
+	}
+	return append(lines,
+		helpLine{"Mouse", "scroll with the wheel, click to select"},
+		helpLine{helpKey(keys.Export), "export to Excel (only the marked commits, if any)"},
+		helpLine{"Space", "mark the selected commit"},
+		helpLine{"A", "mark or unmark all listed commits"},
+		helpLine{"/", "filter by message, file or hash"},
+		helpLine{grouping, "group by day, week or month"},
+		helpLine{"D", "view the selected commit's diff"},
+		helpLine{"P", "open the selected commit in your pager"},
+		helpLine{"O", "open the selected commit in the browser"},
+		helpLine{"Y", "copy a cherry-pick of the marked commits"},
+		helpLine{"C", "copy a changelog"},
+		helpLine{"W", "watch for new commits"},
+		helpLine{leaderboard, "leaderboard"},
+		helpLine{"S", "author statistics"},
+		helpLine{calendar, "activity calendar"},
+		helpLine{"I", "commits by weekday and hour"},
+		helpLine{"F", "file hotspots"},
+		helpLine{"V", "compare two authors"},
+		helpLine{"E", "change the author filter and fetch again"},
+		helpLine{"M", "change the max commits and fetch again"},
+		helpLine{"R", "change the parent branch and fetch again"},
+		helpLine{"Ctrl+P", "find a file and show the commits that changed it"},
+		helpLine{"T", "toggle current branch only and fetch again"},
+	)
+}
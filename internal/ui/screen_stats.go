@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+type statsScreen struct {
+	commits  []models.CommitInfo
+	stats    []utils.AuthorStats // the range total first when there are several authors
+	selected int
+}
+
+func newStatsScreen(commits []models.CommitInfo) ScreenModel {
+	stats := utils.ComputeAuthorStats(commits)
+	if len(stats) > 1 {
+		stats = append([]utils.AuthorStats{utils.RangeStats(commits)}, stats...)
+	}
+	return &statsScreen{commits: commits, stats: stats}
+}
+
+func (s *statsScreen) Update(msg tea.Msg) (ScreenModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(keyMsg, keys.Back) {
+			return s, func() tea.Msg {
+				return NavigateMsg{To: models.ResultsScreen}
+			}
+		}
+
+		switch keyMsg.Type {
+		case tea.KeyUp:
+			if s.selected > 0 {
+				s.selected--
+			}
+		case tea.KeyDown:
+			if s.selected < len(s.stats)-1 {
+				s.selected++
+			}
+		}
+	}
+	return s, nil
+}
+
+func (s *statsScreen) View(width, height int) string {
+	if len(s.stats) == 0 {
+		return tr("No commits to summarize.") + "\n\n" + modifyHelpText("", true, true, false)
+	}
+
+	var content strings.Builder
+	content.WriteString(commitHashStyle.Render(fmt.Sprintf("  %-24s %8s %8s %10s  %-12s", tr("Author"), tr("Commits"), tr("Files"), tr("Avg size"), tr("Busiest day"))))
+	content.WriteString("\n")
+
+	// Keep the selection visible when there are more authors than rows.
+	maxRows := height - 26
+	if maxRows < 5 {
+		maxRows = 5
+	}
+	start := 0
+	if s.selected >= maxRows {
+		start = s.selected - maxRows + 1
+	}
+	end := min(start+maxRows, len(s.stats))
+
+	for i := start; i < end; i++ {
+		st := s.stats[i]
+		name := padRight(truncate(st.Name, 24), 24)
+		row := fmt.Sprintf("%s %8d %8d %10.1f  %-12s", name, st.Commits, st.Files, st.AvgCommitSize(), st.BusiestDay)
+		if i == s.selected {
+			content.WriteString(highlightStyle.Render("> " + row))
+		} else {
+			content.WriteString("  " + row)
+		}
+		content.WriteString("\n")
+	}
+	if end < len(s.stats) {
+		content.WriteString(dimmedStyle.Render("  " + trf("...and %d more authors", len(s.stats)-end) + "\n"))
+	}
+
+	content.WriteString("\n")
+	content.WriteString(statsDetail(s.stats[s.selected]))
+	content.WriteString("\n")
+	content.WriteString(pressText(trf("%s to select an author", highlightStyle.Render("↑/↓"))) + "\n")
+	content.WriteString(modifyHelpText("", true, true, false))
+	return content.String()
+}
+
+// statsDetail renders the card for the selected author.
+func statsDetail(st utils.AuthorStats) string {
+	var b strings.Builder
+	title := st.Name
+	if st.Email != "" {
+		title += " <" + st.Email + ">"
+	}
+	b.WriteString(commitAuthorStyle.Render(title) + "\n")
+	b.WriteString("  " + trf("Commits: %d   Files: %d   Lines: +%d / -%d", st.Commits, st.Files, st.Additions, st.Deletions) + "\n")
+	b.WriteString("  " + trf("Average commit size: %.1f lines", st.AvgCommitSize()) + "\n")
+	if !st.First.IsZero() {
+		b.WriteString("  " + trf("Active: %s to %s", st.First.Format(time.DateOnly), st.Last.Format(time.DateOnly)) + "\n")
+	}
+	if st.Commits > 0 && !st.First.IsZero() {
+		cadence := "  " + trf("Cadence: %.2f commits/day", st.CommitsPerDay)
+		if st.MedianGap > 0 {
+			cadence += ", " + trf("median %s between commits", utils.FormatGap(st.MedianGap))
+		}
+		b.WriteString(cadence + "\n")
+	}
+	if st.LongestStreak.Days > 0 {
+		b.WriteString("  " + trf("Longest streak: %s", st.LongestStreak.String()) + "\n")
+	}
+	for i, gap := range st.LongestGaps {
+		label := tr("Longest gaps:")
+		if i > 0 {
+			label = ""
+		}
+		b.WriteString(fmt.Sprintf("  %-15s %s\n", label, gap.String()))
+	}
+	if st.BusiestDay != "" {
+		b.WriteString("  " + trf("Busiest day: %s (%d commits)", st.BusiestDay, st.BusiestDayCommits) + "\n")
+	}
+	if len(st.Languages) > 0 {
+		b.WriteString("  " + trf("Languages: %s", languagesSummary(st.Languages, 4)) + "\n")
+	}
+	return b.String()
+}
+
+// languagesSummary renders "60% Go, 25% SQL, 15% YAML", folding everything past
+// limit into "other".
+func languagesSummary(languages []utils.LanguageShare, limit int) string {
+	var parts []string
+	rest := 0.0
+	for i, l := range languages {
+		if i >= limit {
+			rest += l.Share
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%.0f%% %s", l.Share*100, l.Language))
+	}
+	if rest > 0 {
+		parts = append(parts, trf("%.0f%% other", rest*100))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (s *statsScreen) keyHelp() []helpLine {
+	return []helpLine{{"↑/↓", "select an author"}}
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// statusBar sums up the query behind the current screen on one line at the bottom:
+// repository, branch, author filter, commit count and the options in effect. It is
+// blank until a repository has been chosen.
+func (m model) statusBar() string {
+	if m.directory == "" {
+		return ""
+	}
+
+	parts := []string{filepath.Base(m.directory)}
+	if m.branch != "" {
+		parts = append(parts, trf("branch %s", m.branch))
+	}
+	author := m.author
+	if author == "" {
+		author = tr("all authors")
+	}
+	parts = append(parts, trf("author %s", author))
+	if m.commits != nil {
+		parts = append(parts, trf("%d commits", len(m.commits)))
+	}
+
+	if m.currentBranchOnly {
+		parts = append(parts, tr("current branch only"))
+	}
+	if m.missing {
+		parts = append(parts, trf("missing from %s", m.parentBranch))
+	}
+	if m.unmerged {
+		parts = append(parts, trf("unmerged into %s", m.parentBranch))
+	}
+	if m.showFiles {
+		parts = append(parts, tr("files shown"))
+	}
+	if m.dotnetMode {
+		parts = append(parts, tr("dotnet mode"))
+	}
+	if m.watching {
+		parts = append(parts, tr("watching"))
+	}
+
+	separator := " · "
+	if accessible {
+		separator = ", "
+	}
+	width := max(m.width, 1)
+	return statusBarStyle.Width(width).Render(truncate(strings.Join(parts, separator), width-2))
+}
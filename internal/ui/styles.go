@@ -1,51 +1,118 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leeozaka/gommits/internal/config"
+)
+
+// plain drops colors, borders and animations; see Options.Plain. accessible also
+// linearizes layouts for screen readers; see Options.Accessible.
+var plain, accessible bool
+
+// theme is the active palette; the styles below are derived from it by applyTheme.
+var theme config.Theme
 
 var (
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
-			Padding(0, 1).
-			MarginBottom(1).
-			Align(lipgloss.Center).
-			Width(60)
+	titleStyle        lipgloss.Style
+	infoStyle         lipgloss.Style
+	errorStyle        lipgloss.Style
+	successStyle      lipgloss.Style
+	highlightStyle    lipgloss.Style
+	dimmedStyle       lipgloss.Style
+	commitHashStyle   lipgloss.Style
+	commitAuthorStyle lipgloss.Style
+	commitFilesStyle  lipgloss.Style
+	warningTextStyle  lipgloss.Style
+	searchMatchStyle  lipgloss.Style
+	helpPanelStyle    lipgloss.Style
+	statusBarStyle    lipgloss.Style
+	toastStyle        lipgloss.Style
+	toastErrorStyle   lipgloss.Style
+	diffAddedStyle    lipgloss.Style
+	diffRemovedStyle  lipgloss.Style
+	diffHunkStyle     lipgloss.Style
+	diffFileStyle     lipgloss.Style
+)
 
-	infoStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#2D3748")).
-			Padding(0, 1).
-			Align(lipgloss.Center).
-			Width(60)
+func init() {
+	applyTheme(themes[defaultTheme])
+}
 
-	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#E53E3E")).
-			Padding(0, 1).
-			Align(lipgloss.Center).
-			Width(60)
+// applyTheme rebuilds every style from t.
+func applyTheme(t config.Theme) {
+	theme = t
 
-	successStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#38A169")).
+	banner := func(background string) lipgloss.Style {
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color(t.Text)).
+			Background(lipgloss.Color(background)).
 			Padding(0, 1).
 			Align(lipgloss.Center).
 			Width(60)
+	}
+	titleStyle = banner(t.Primary).Bold(true).MarginBottom(1)
+	infoStyle = banner(t.Info)
+	errorStyle = banner(t.Error)
+	successStyle = banner(t.Success)
+
+	highlightStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Primary))
+	dimmedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Muted))
+	commitHashStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Hash)).Bold(true)
+	commitAuthorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Success))
+	commitFilesStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Primary))
+	warningTextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Error))
+	searchMatchStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.MatchText)).
+		Background(lipgloss.Color(t.Match))
 
-	highlightStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#7D56F4"))
+	helpPanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(t.Primary)).
+		Padding(1, 2)
+	if plain {
+		helpPanelStyle = lipgloss.NewStyle().Padding(1, 2)
+	}
 
-	dimmedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#9E9E9E"))
+	statusBarStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.Text)).
+		Background(lipgloss.Color(t.Muted)).
+		Padding(0, 1)
+	if plain {
+		statusBarStyle = lipgloss.NewStyle().Padding(0, 1)
+	}
 
-	commitHashStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#2D3748")).
-			Bold(true)
+	toast := func(background string) lipgloss.Style {
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color(t.Text)).
+			Background(lipgloss.Color(background)).
+			Padding(1, 3).
+			Margin(1).
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color(shade(background, toastBorderShade))).
+			Bold(true).
+			Align(lipgloss.Center)
+	}
+	toastStyle = toast(t.Success)
+	toastErrorStyle = toast(t.Error)
+	if plain {
+		toastStyle = lipgloss.NewStyle().Padding(1, 3).Margin(1).Bold(true).Align(lipgloss.Center)
+		toastErrorStyle = toastStyle
+	}
 
-	commitAuthorStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#38A169"))
+	diffAddedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Added))
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Removed))
+	diffHunkStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Hunk))
+	diffFileStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(t.File))
+}
 
-	commitFilesStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#7D56F4"))
-)
+// typeBadgeStyle colors the conventional commit badge on the results screen.
+func typeBadgeStyle(commitType string) lipgloss.Style {
+	color, ok := theme.Badges[commitType]
+	if !ok {
+		color = theme.Muted
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.Text)).
+		Background(lipgloss.Color(color)).
+		Padding(0, 1)
+}
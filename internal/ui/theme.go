@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/config"
+)
+
+const defaultTheme = "dark"
+
+// themes are the built-in palettes selected with ui.theme.
+var themes = map[string]config.Theme{
+	"dark": {
+		Primary:   "#7D56F4",
+		Text:      "#FAFAFA",
+		Info:      "#2D3748",
+		Success:   "#38A169",
+		Error:     "#E53E3E",
+		Muted:     "#9E9E9E",
+		Hash:      "#2D3748",
+		Match:     "#F6E05E",
+		MatchText: "#1A202C",
+		Added:     "#04B575",
+		Removed:   "#FF5F87",
+		Hunk:      "#00BFFF",
+		File:      "#FFD700",
+		Syntax:    "monokai",
+		Heatmap:   []string{"#3A3F47", "#0E4429", "#006D32", "#26A641", "#39D353"},
+		Badges:    defaultBadges,
+	},
+	"light": {
+		Primary:   "#5B3CC4",
+		Text:      "#FFFFFF",
+		Info:      "#4A5568",
+		Success:   "#276749",
+		Error:     "#C53030",
+		Muted:     "#5F6368",
+		Hash:      "#1A202C",
+		Match:     "#FAF089",
+		MatchText: "#1A202C",
+		Added:     "#22863A",
+		Removed:   "#CB2431",
+		Hunk:      "#005CC5",
+		File:      "#6F42C1",
+		Syntax:    "github",
+		Heatmap:   []string{"#EBEDF0", "#9BE9A8", "#40C463", "#30A14E", "#216E39"},
+		Badges:    defaultBadges,
+	},
+	"high-contrast": {
+		Primary:   "#FFFF00",
+		Text:      "#000000",
+		Info:      "#FFFFFF",
+		Success:   "#00FF00",
+		Error:     "#FF5555",
+		Muted:     "#FFFFFF",
+		Hash:      "#00FFFF",
+		Match:     "#FF00FF",
+		MatchText: "#000000",
+		Added:     "#00FF00",
+		Removed:   "#FF5555",
+		Hunk:      "#00FFFF",
+		File:      "#FFFF00",
+		Syntax:    "bw",
+		Heatmap:   []string{"#444444", "#006600", "#00AA00", "#00DD00", "#00FF00"},
+		Badges: map[string]string{
+			"feat": "#00FF00", "fix": "#FF5555", "perf": "#FFAA00",
+			"refactor": "#00FFFF", "docs": "#FF00FF", "test": "#FFFF00",
+		},
+	},
+}
+
+var defaultBadges = map[string]string{
+	"feat":     "#38A169",
+	"fix":      "#E53E3E",
+	"perf":     "#DD6B20",
+	"refactor": "#3182CE",
+	"docs":     "#805AD5",
+	"test":     "#D69E2E",
+}
+
+// resolveTheme returns the preset named in cfg with its color overrides applied.
+func resolveTheme(cfg config.UIConfig) (config.Theme, error) {
+	name := cfg.Theme
+	if name == "" {
+		name = defaultTheme
+	}
+	t, ok := themes[name]
+	if !ok {
+		names := make([]string, 0, len(themes))
+		for n := range themes {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return themes[defaultTheme], fmt.Errorf("unknown theme %q: use %s", name, strings.Join(names, ", "))
+	}
+
+	o := cfg.Colors
+	for _, c := range []struct {
+		dst *string
+		src string
+	}{
+		{&t.Primary, o.Primary}, {&t.Text, o.Text}, {&t.Info, o.Info}, {&t.Success, o.Success},
+		{&t.Error, o.Error}, {&t.Muted, o.Muted}, {&t.Hash, o.Hash}, {&t.Match, o.Match},
+		{&t.MatchText, o.MatchText}, {&t.Added, o.Added}, {&t.Removed, o.Removed},
+		{&t.Hunk, o.Hunk}, {&t.File, o.File}, {&t.Syntax, o.Syntax},
+	} {
+		if c.src != "" {
+			*c.dst = c.src
+		}
+	}
+	if len(o.Heatmap) > 0 {
+		if len(o.Heatmap) != len(t.Heatmap) {
+			return themes[defaultTheme], fmt.Errorf("heatmap needs %d colors, got %d", len(t.Heatmap), len(o.Heatmap))
+		}
+		t.Heatmap = o.Heatmap
+	}
+	if len(o.Badges) > 0 {
+		badges := make(map[string]string, len(t.Badges)+len(o.Badges))
+		for k, v := range t.Badges {
+			badges[k] = v
+		}
+		for k, v := range o.Badges {
+			badges[k] = v
+		}
+		t.Badges = badges
+	}
+	return t, nil
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -9,131 +10,152 @@ import (
 	"github.com/leeozaka/gommits/internal/models"
 )
 
-var (
-	toastStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#38A169")).
-			Padding(1, 3).
-			Margin(1).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#2F855A")).
-			Bold(true).
-			Align(lipgloss.Center)
-
-	toastErrorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#E53E3E")).
-			Padding(1, 3).
-			Margin(1).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#C53030")).
-			Bold(true).
-			Align(lipgloss.Center)
-)
-
 const (
 	toastTickInterval            = 50 * time.Millisecond
 	slideInDuration              = 300 * time.Millisecond
 	fadeInDuration               = 200 * time.Millisecond
 	fadeOutDuration              = 500 * time.Millisecond
 	toastBgR, toastBgG, toastBgB = 0x1A, 0x1A, 0x1A
+	// toastBorderShade darkens a toast's background color for its border.
+	toastBorderShade = 0.83
+	// maxToasts caps the stack; showing another dismisses the oldest.
+	maxToasts = 3
 )
 
+// ToastManager stacks toasts, oldest on top, each fading out on its own schedule.
 type ToastManager struct {
-	toast models.Toast
+	toasts  []models.Toast
+	nextID  int
+	ticking bool // an animation tick is pending
 }
 
 func NewToastManager() ToastManager {
-	return ToastManager{
-		toast: models.Toast{
-			Visible:  false,
-			Opacity:  0.0,
-			Position: 0.0,
-			Duration: 3 * time.Second,
-		},
-	}
+	return ToastManager{}
 }
 
 func (tm ToastManager) IsVisible() bool {
-	return tm.toast.Visible && tm.toast.Opacity > 0
+	for _, t := range tm.toasts {
+		if t.Visible && t.Opacity > 0 {
+			return true
+		}
+	}
+	return false
 }
 
 func (tm ToastManager) Update(msg tea.Msg) (ToastManager, tea.Cmd) {
 	switch msg := msg.(type) {
 	case models.ShowToastMsg:
-		tm.toast = models.Toast{
+		tm.nextID++
+		toast := models.Toast{
+			ID:        tm.nextID,
 			Message:   msg.Message,
 			Type:      msg.Type,
 			Visible:   true,
-			Opacity:   0.0,
-			Position:  0.0,
 			StartTime: time.Now(),
 			Duration:  msg.Duration,
+			Action:    msg.Action,
+		}
+		if plain {
+			toast.Opacity, toast.Position = 1.0, 1.0
+		}
+		tm.toasts = append(slices.Clone(tm.toasts), toast)
+		if len(tm.toasts) > maxToasts {
+			tm.toasts = tm.toasts[len(tm.toasts)-maxToasts:]
 		}
-		return tm, tea.Batch(
-			hideToastCmd(msg.Duration),
-			tickCmd(toastTickInterval),
-		)
+
+		cmds := []tea.Cmd{hideToastCmd(toast.ID, msg.Duration)}
+		if !plain && !tm.ticking {
+			tm.ticking = true
+			cmds = append(cmds, tickCmd(toastTickInterval))
+		}
+		return tm, tea.Batch(cmds...)
 
 	case models.HideToastMsg:
-		tm.toast.Visible = false
-		tm.toast.Opacity = 0.0
-		tm.toast.Position = 0.0
+		tm.toasts = slices.DeleteFunc(slices.Clone(tm.toasts), func(t models.Toast) bool {
+			return t.ID == msg.ID
+		})
 		return tm, nil
 
 	case models.TickMsg:
-		if !tm.toast.Visible {
-			return tm, nil
+		toasts := tm.toasts[:0:0]
+		for _, t := range tm.toasts {
+			if animateToast(&t, time.Since(t.StartTime)) {
+				toasts = append(toasts, t)
+			}
 		}
-
-		elapsed := time.Since(tm.toast.StartTime)
-		if elapsed >= tm.toast.Duration {
-			tm.toast.Visible = false
-			tm.toast.Opacity = 0.0
-			tm.toast.Position = 0.0
+		tm.toasts = toasts
+		if len(tm.toasts) == 0 {
+			tm.ticking = false
 			return tm, nil
 		}
-
-		if elapsed < slideInDuration {
-			p := float64(elapsed) / float64(slideInDuration)
-			tm.toast.Position = 1 - (1-p)*(1-p)*(1-p)
-		} else {
-			tm.toast.Position = 1.0
-		}
-
-		if elapsed < fadeInDuration {
-			tm.toast.Opacity = float64(elapsed) / float64(fadeInDuration)
-		} else {
-			fadeOutStart := tm.toast.Duration - fadeOutDuration
-			if elapsed >= fadeOutStart {
-				fadeProgress := float64(elapsed-fadeOutStart) / float64(fadeOutDuration)
-				tm.toast.Opacity = 1.0 - fadeProgress
-			} else {
-				tm.toast.Opacity = 1.0
-			}
-		}
-
 		return tm, tickCmd(toastTickInterval)
 	}
 
 	return tm, nil
 }
 
-func (tm ToastManager) View() string {
-	if !tm.IsVisible() {
-		return ""
+// action returns the action of the newest toast on screen bound to key.
+func (tm ToastManager) action(key string) (*models.ToastAction, int, bool) {
+	for i := len(tm.toasts) - 1; i >= 0; i-- {
+		t := tm.toasts[i]
+		if t.Action != nil && t.Action.Key == key {
+			return t.Action, t.ID, true
+		}
 	}
+	return nil, 0, false
+}
 
-	style := toastStyle
-	if tm.toast.Type == models.ToastError {
-		style = toastErrorStyle
+// animateToast slides and fades t for the time since it was shown, reporting
+// whether it is still on screen.
+func animateToast(t *models.Toast, elapsed time.Duration) bool {
+	if elapsed >= t.Duration {
+		return false
 	}
 
-	if tm.toast.Opacity < 1.0 {
-		style = tm.applyOpacity(style)
+	if elapsed < slideInDuration {
+		p := float64(elapsed) / float64(slideInDuration)
+		t.Position = 1 - (1-p)*(1-p)*(1-p)
+	} else {
+		t.Position = 1.0
 	}
 
-	return style.Render(tm.toast.Message)
+	if elapsed < fadeInDuration {
+		t.Opacity = float64(elapsed) / float64(fadeInDuration)
+	} else {
+		fadeOutStart := t.Duration - fadeOutDuration
+		if elapsed >= fadeOutStart {
+			fadeProgress := float64(elapsed-fadeOutStart) / float64(fadeOutDuration)
+			t.Opacity = 1.0 - fadeProgress
+		} else {
+			t.Opacity = 1.0
+		}
+	}
+	return true
+}
+
+func (tm ToastManager) View() string {
+	var views []string
+	for _, t := range tm.toasts {
+		if !t.Visible || t.Opacity <= 0 {
+			continue
+		}
+
+		style := toastStyle
+		if t.Type == models.ToastError {
+			style = toastErrorStyle
+		}
+
+		if t.Opacity < 1.0 {
+			style = applyOpacity(style, t)
+		}
+
+		text := t.Message
+		if t.Action != nil {
+			text += "\n" + t.Action.Hint
+		}
+		views = append(views, style.Render(text))
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, views...)
 }
 
 // toastViewModel wraps the toast rendered string as a tea.Model for use with bubbletea-overlay.
@@ -154,42 +176,49 @@ func (b backgroundViewModel) Init() tea.Cmd                       { return nil }
 func (b backgroundViewModel) Update(tea.Msg) (tea.Model, tea.Cmd) { return b, nil }
 func (b backgroundViewModel) View() string                        { return b.content }
 
-func (tm ToastManager) applyOpacity(style lipgloss.Style) lipgloss.Style {
-	opacity := tm.toast.Opacity
+func applyOpacity(style lipgloss.Style, t models.Toast) lipgloss.Style {
+	opacity := t.Opacity
 
-	var fgR, fgG, fgB int
-	var borderR, borderG, borderB int
-	if tm.toast.Type == models.ToastSuccess {
-		fgR, fgG, fgB = 0x38, 0xA1, 0x69
-		borderR, borderG, borderB = 0x2F, 0x85, 0x5A
-	} else {
-		fgR, fgG, fgB = 0xE5, 0x3E, 0x3E
-		borderR, borderG, borderB = 0xC5, 0x30, 0x30
+	background := theme.Success
+	if t.Type != models.ToastSuccess {
+		background = theme.Error
+	}
+
+	return style.
+		Background(lipgloss.Color(blend(background, opacity))).
+		BorderForeground(lipgloss.Color(blend(shade(background, toastBorderShade), opacity))).
+		Foreground(lipgloss.Color(blend(theme.Text, opacity)))
+}
+
+// blend mixes a hex color with the toast backdrop; other color forms are kept as-is.
+func blend(color string, opacity float64) string {
+	r, g, b, ok := parseHex(color)
+	if !ok {
+		return color
+	}
+	mix := func(c, bg int) int {
+		return int(float64(c)*opacity + float64(bg)*(1-opacity))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", mix(r, toastBgR), mix(g, toastBgG), mix(b, toastBgB))
+}
+
+// shade darkens a hex color by factor.
+func shade(color string, factor float64) string {
+	r, g, b, ok := parseHex(color)
+	if !ok {
+		return color
 	}
+	return fmt.Sprintf("#%02x%02x%02x", int(float64(r)*factor), int(float64(g)*factor), int(float64(b)*factor))
+}
 
-	style = style.
-		Background(lipgloss.Color(fmt.Sprintf("#%02x%02x%02x",
-			int(float64(fgR)*opacity+float64(toastBgR)*(1-opacity)),
-			int(float64(fgG)*opacity+float64(toastBgG)*(1-opacity)),
-			int(float64(fgB)*opacity+float64(toastBgB)*(1-opacity))))).
-		BorderForeground(lipgloss.Color(fmt.Sprintf("#%02x%02x%02x",
-			int(float64(borderR)*opacity+float64(toastBgR)*(1-opacity)),
-			int(float64(borderG)*opacity+float64(toastBgG)*(1-opacity)),
-			int(float64(borderB)*opacity+float64(toastBgB)*(1-opacity)))))
-
-	textR, textG, textB := 0xFA, 0xFA, 0xFA
-	style = style.
-		Foreground(lipgloss.Color(fmt.Sprintf("#%02x%02x%02x",
-			int(float64(textR)*opacity+float64(toastBgR)*(1-opacity)),
-			int(float64(textG)*opacity+float64(toastBgG)*(1-opacity)),
-			int(float64(textB)*opacity+float64(toastBgB)*(1-opacity)))))
-
-	return style
+func parseHex(color string) (r, g, b int, ok bool) {
+	_, err := fmt.Sscanf(color, "#%02x%02x%02x", &r, &g, &b)
+	return r, g, b, err == nil
 }
 
-func hideToastCmd(delay time.Duration) tea.Cmd {
+func hideToastCmd(id int, delay time.Duration) tea.Cmd {
 	return tea.Tick(delay, func(t time.Time) tea.Msg {
-		return models.HideToastMsg{}
+		return models.HideToastMsg{ID: id}
 	})
 }
 
@@ -2,15 +2,24 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/leeozaka/gommits/internal/browser"
+	"github.com/leeozaka/gommits/internal/config"
 	"github.com/leeozaka/gommits/internal/git"
 	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
+	"github.com/muesli/termenv"
 	overlay "github.com/rmhubbert/bubbletea-overlay"
 )
 
@@ -24,6 +33,7 @@ type model struct {
 	activeScreen ScreenModel
 	gitService   git.GitService
 	toastManager ToastManager
+	config       config.Config
 
 	directory         string
 	author            string
@@ -32,8 +42,23 @@ type model struct {
 	maxCommits        int
 	showFiles         bool
 	currentBranchOnly bool
+	missing           bool
+	unmerged          bool
 	dotnetMode        bool
 	commits           []models.CommitInfo
+	presetFormats     []string // export formats of the preset being run, if any
+	recent            []string // recently analyzed repositories, most recent first
+
+	vim      vimKeys
+	showHelp bool           // the help overlay replaces the active screen
+	confirm  *confirmDialog // an open confirmation, which takes every key
+
+	lastErr   *models.ErrorMsg // the error on the message line, if any
+	showError bool             // its detail panel replaces the active screen
+
+	watching   bool
+	watchGen   int
+	watchState string
 
 	message      string
 	messageStyle lipgloss.Style
@@ -43,16 +68,45 @@ type model struct {
 }
 
 func initialModel() model {
-	return model{
-		activeScreen:      newHomeScreen(),
-		gitService:        git.NewCLIGitService(),
+	cfg, cfgErr := config.Load()
+	recent, recentErr := config.LoadRecentRepos()
+	setLocale(cfg.UI.Locale)
+	t, themeErr := resolveTheme(cfg.UI)
+	applyTheme(t)
+	svc := git.NewCLIGitService()
+
+	m := model{
+		activeScreen:      newHomeScreen(svc, recent),
+		gitService:        svc,
 		toastManager:      NewToastManager(),
-		message:           "Welcome to Gommits App!",
+		config:            cfg,
+		message:           tr("Welcome to Gommits App!"),
 		messageStyle:      infoStyle,
 		showFiles:         true,
 		currentBranchOnly: true,
 		parentBranch:      git.DefaultBranchRef,
+		recent:            recent,
 	}
+
+	if cfgErr != nil {
+		m.message = trf("Error (%s): %v", tr("loading config"), cfgErr)
+		m.messageStyle = errorStyle
+	}
+	if recentErr != nil {
+		m.message = trf("Error (%s): %v", tr("loading recent repositories"), recentErr)
+		m.messageStyle = errorStyle
+	}
+	if themeErr != nil {
+		m.message = trf("Error (%s): %v", tr("loading theme"), themeErr)
+		m.messageStyle = errorStyle
+	}
+	if km, err := loadKeyMap(cfg.UI.Keys); err != nil {
+		m.message = trf("Error (%s): %v", tr("loading keys"), err)
+		m.messageStyle = errorStyle
+	} else {
+		keys = km
+	}
+	return m
 }
 
 func (m model) Init() tea.Cmd {
@@ -60,11 +114,28 @@ func (m model) Init() tea.Cmd {
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if toast, ok := msg.(models.ShowToastMsg); ok && accessible {
+		// Announce the toast without its leading emoji; the manager still keeps it
+		// for its action, but View doesn't draw it.
+		m.message = strings.TrimLeftFunc(toast.Message, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if toast.Action != nil {
+			m.message += " " + toast.Action.Hint
+		}
+		m.messageStyle = successStyle
+		if toast.Type == models.ToastError {
+			m.messageStyle = errorStyle
+		}
+	}
+
 	switch msg.(type) {
 	case models.ShowToastMsg, models.HideToastMsg, models.TickMsg:
 		var cmd tea.Cmd
 		m.toastManager, cmd = m.toastManager.Update(msg)
 		return m, cmd
+	case toggleWatchMsg, watchTickMsg, refStateMsg, watchRefreshMsg:
+		return m.updateWatch(msg)
 	}
 
 	switch msg := msg.(type) {
@@ -73,53 +144,198 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.quitting = true
 			return m, tea.Quit
 		}
-		if msg.Type == tea.KeyEsc {
-			if opts, ok := m.activeScreen.(*optionsScreen); ok && opts.editing {
+		if m.confirm != nil {
+			if cmd, ok := m.confirm.answer(msg); ok {
+				m.confirm = nil
+				return m, cmd
+			}
+			return m, nil
+		}
+		if m.showHelp || m.showError {
+			m.showHelp, m.showError = false, false
+			return m, nil
+		}
+		if msg.String() == "?" && !isTyping(m.activeScreen) {
+			m.showHelp = true
+			return m, nil
+		}
+		if msg.String() == errorDetailKey && m.lastErr != nil && !isTyping(m.activeScreen) {
+			m.showError = true
+			return m, nil
+		}
+		if action, id, ok := m.toastManager.action(msg.String()); ok && !isTyping(m.activeScreen) {
+			return m, tea.Batch(runToastActionCmd(action), func() tea.Msg {
+				return models.HideToastMsg{ID: id}
+			})
+		}
+		if key.Matches(msg, keys.Quit) {
+			if msg.Type == tea.KeyEsc && isEditing(m.activeScreen) {
 				var cmd tea.Cmd
 				m.activeScreen, cmd = m.activeScreen.Update(msg)
 				return m, cmd
 			}
+			if task := busy(m.activeScreen); task != "" {
+				m.confirm = &confirmDialog{question: task, onYes: tea.Quit}
+				return m, nil
+			}
 			m.quitting = true
 			return m, tea.Quit
 		}
 
+		if m.config.UI.Vim && takesVimKeys(m.activeScreen) {
+			var ok bool
+			if msg, ok = m.vim.translate(msg); !ok {
+				return m, nil
+			}
+		}
+
+		var cmd tea.Cmd
+		m.activeScreen, cmd = m.activeScreen.Update(msg)
+		return m, cmd
+
+	case tea.MouseMsg:
+		if m.showHelp || m.showError || m.confirm != nil {
+			return m, nil
+		}
+		// Screens see clicks relative to their own content; the wheel needs no position.
+		if !tea.MouseEvent(msg).IsWheel() {
+			x, y := m.contentOrigin(m.activeScreen.View(m.width, m.height))
+			msg.X -= x
+			msg.Y -= y
+		}
 		var cmd tea.Cmd
 		m.activeScreen, cmd = m.activeScreen.Update(msg)
 		return m, cmd
 
 	case models.ErrorMsg:
-		m.message = fmt.Sprintf("Error (%s): %v", msg.Context, msg.Err)
+		m.message = trf("Error (%s): %v", tr(msg.Context), msg.Err)
 		m.messageStyle = errorStyle
+		m.lastErr = &msg
+		if advice := git.Advice(msg.Err); advice != "" {
+			m.message += " " + tr(advice)
+		}
+		if _, ok := gitFailure(msg.Err); ok {
+			m.message += " · " + trf("%s for details", errorDetailKey)
+		}
 		return m, nil
 
 	case NavigateMsg:
 		return m.handleNavigation(msg)
 
+	case runPresetMsg:
+		m.currentBranchOnly, m.missing, m.unmerged = true, false, false
+		m, _ = m.handleNavigation(NavigateMsg{To: models.OptionsScreen, Data: msg.data})
+		m.presetFormats = msg.formats
+		return m, m.activeScreen.(*optionsScreen).fetch(0)
+
+	case confirmMsg:
+		m.confirm = &confirmDialog{question: msg.question, onYes: msg.onYes}
+		return m, nil
+
+	case contributorsMsg:
+		var cmd tea.Cmd
+		m.activeScreen, cmd = m.activeScreen.Update(msg)
+		return m, cmd
+
+	case startExportMsg:
+		var cmd tea.Cmd
+		m.activeScreen, cmd = m.activeScreen.Update(msg)
+		return m, cmd
+
+	case progressMsg:
+		var cmd tea.Cmd
+		m.activeScreen, cmd = m.activeScreen.Update(msg)
+		return m, tea.Batch(cmd, waitForProgressCmd(msg.next))
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.activeScreen, cmd = m.activeScreen.Update(msg)
+		return m, cmd
+
 	case models.FetchCommitsMsg:
 		if msg.Err != nil {
+			m.activeScreen, _ = m.activeScreen.Update(msg)
 			return m, errorCmd(msg.Err, "fetching commits")
 		}
-		m.commits = msg.Commits
+		loc, _ := m.config.Location()
+		m.commits = utils.ConvertTimezone(msg.Commits, loc)
 		m.branch = msg.Branch
 		m.parentBranch = msg.ParentBranch
 		m.dotnetMode = msg.DotnetMode
-		m.message = fmt.Sprintf("Found %d commits in branch '%s'", len(m.commits), m.branch)
+		m.author = msg.Author
+		m.maxCommits = msg.MaxCommits
+		m.currentBranchOnly = msg.CurrentBranchOnly
+		m.missing = msg.Missing
+		m.unmerged = msg.Unmerged
+		m.message = resultsMessage(len(m.commits), m.branch, m.missingFrom(), m.watching)
 		m.messageStyle = successStyle
-		m.activeScreen = newResultsScreen(m.gitService, m.commits, m.directory, m.branch, m.parentBranch, m.showFiles, m.dotnetMode)
+		m.activeScreen = m.newResults()
+		m.recent = config.AddRecentRepo(m.recent, m.directory)
+		return m, tea.Batch(enrichCommitsCmd(m.gitService, m.config, m.directory, m.commits), saveRecentReposCmd(m.recent))
+
+	case models.EnrichCommitsMsg:
+		// Drop lookups for a result set that has since been replaced.
+		if !sameCommits(m.commits, msg.Commits) {
+			return m, nil
+		}
+		m.commits = msg.Commits
+		if current, ok := m.activeScreen.(*resultsScreen); ok {
+			next := m.newResults()
+			next.keepView(current)
+			m.activeScreen = next
+		}
+		if msg.Err != nil {
+			return m, tea.Batch(
+				showToastCmd("⚠️ "+tr("Some commit details could not be loaded"), models.ToastError, 3*time.Second),
+				errorCmd(msg.Err, "enriching commits"),
+			)
+		}
 		return m, nil
 
+	case models.DiffMsg:
+		if msg.Err != nil {
+			return m, errorCmd(msg.Err, "loading diff")
+		}
+		var cmd tea.Cmd
+		m.activeScreen, cmd = m.activeScreen.Update(msg)
+		return m, cmd
+
 	case models.ExportExcelMsg:
+		m.activeScreen, _ = m.activeScreen.Update(msg)
 		if msg.Err != nil {
-			return m, showToastCmd("❌ Export failed", models.ToastError, 3*time.Second)
+			return m, tea.Batch(
+				showToastCmd("❌ "+tr("Export failed"), models.ToastError, 3*time.Second),
+				errorCmd(msg.Err, "exporting"),
+			)
+		}
+		count := len(m.commits)
+		if msg.Count > 0 {
+			count = msg.Count
+		}
+		text := "✅ " + trf("Exported %d commits to %s", count, filepath.Base(msg.Path))
+		if len(msg.Delivered) > 0 {
+			text += " → " + strings.Join(msg.Delivered, ", ")
+		}
+		if msg.Path == "" {
+			return m, showToastCmd(text, models.ToastSuccess, 3*time.Second)
+		}
+		return m, func() tea.Msg {
+			return models.ShowToastMsg{
+				Message:  text,
+				Type:     models.ToastSuccess,
+				Duration: 6 * time.Second,
+				Action: &models.ToastAction{
+					Key:  "ctrl+o",
+					Hint: pressText(trf("%s to open it", "Ctrl+O")),
+					Run:  func() error { return browser.Open(msg.Path) },
+				},
+			}
 		}
-		return m, showToastCmd(
-			fmt.Sprintf("✅ Exported %d commits to Excel", len(m.commits)),
-			models.ToastSuccess, 3*time.Second,
-		)
 
 	case models.ResetToHomeMsg:
-		m.activeScreen = newHomeScreen()
-		m.message = "Welcome to Gommits App!"
+		m.watching = false
+		m.activeScreen = newHomeScreen(m.gitService, m.recent)
+		m.message = tr("Welcome to Gommits App!")
 		m.messageStyle = infoStyle
 
 	case tea.WindowSizeMsg:
@@ -131,10 +347,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) handleNavigation(msg NavigateMsg) (model, tea.Cmd) {
+	m.lastErr = nil
 	if msg.Data.Directory != "" {
 		m.directory = msg.Data.Directory
 	}
-	m.author = msg.Data.Author
+	// Screens derived from the results carry no query data; keep the current author.
+	if !isResultsView(msg.To) {
+		m.author = msg.Data.Author
+	}
 	if msg.Data.Branch != "" {
 		m.branch = msg.Data.Branch
 	}
@@ -142,65 +362,209 @@ func (m model) handleNavigation(msg NavigateMsg) (model, tea.Cmd) {
 		m.parentBranch = msg.Data.ParentBranch
 	}
 
+	if msg.To != models.ResultsScreen {
+		m.watching = false
+	}
+
+	// A preset's formats last until a query is started by hand.
+	if msg.To == models.HomeScreen || msg.To == models.DirectoryScreen {
+		m.presetFormats = nil
+	}
+
 	switch msg.To {
 	case models.HomeScreen:
-		m.activeScreen = newHomeScreen()
-		m.message = "Welcome to Gommits App!"
+		m.activeScreen = newHomeScreen(m.gitService, m.recent)
+		m.message = tr("Welcome to Gommits App!")
 		m.messageStyle = infoStyle
 
 	case models.DirectoryScreen:
-		m.activeScreen = newDirectoryScreenWithValue(m.gitService, m.directory)
-		m.message = "Please enter the path to a Git repository"
+		m.activeScreen = newDirectoryScreenWithValue(m.gitService, m.directory, m.recent)
+		m.message = tr("Please enter the path to a Git repository")
 		m.messageStyle = infoStyle
 
 	case models.AuthorScreen:
 		m.activeScreen = newAuthorScreenWithValue(m.author)
-		m.message = "Enter author(s) to filter, or leave empty for all"
+		m.message = tr("Enter author(s) to filter, or leave empty for all")
 		m.messageStyle = infoStyle
+		return m, tea.Batch(textinput.Blink, loadContributorsCmd(m.gitService, m.directory))
 
 	case models.OptionsScreen:
 		m.activeScreen = newOptionsScreenWithValues(
 			m.gitService, m.directory, m.author, m.parentBranch,
-			m.currentBranchOnly, m.showFiles, m.dotnetMode,
+			m.currentBranchOnly, m.missing, m.unmerged, m.showFiles, m.dotnetMode,
 		)
-		m.message = "Configure additional options"
+		m.message = tr("Configure additional options")
 		m.messageStyle = infoStyle
 
 	case models.ResultsScreen:
-		m.activeScreen = newResultsScreen(m.gitService, m.commits, m.directory, m.branch, m.parentBranch, m.showFiles, m.dotnetMode)
-		m.message = fmt.Sprintf("Found %d commits in branch '%s'", len(m.commits), m.branch)
+		results := m.newResults()
+		results.selectCommit(msg.Data.Commit)
+		m.activeScreen = results
+		m.message = resultsMessage(len(m.commits), m.branch, m.missingFrom(), m.watching)
 		m.messageStyle = successStyle
+
+	case models.LeaderboardScreen:
+		m.activeScreen = newLeaderboardScreen(m.commits)
+		m.message = trf("Contributor leaderboard for %d commits", len(m.commits))
+		m.messageStyle = infoStyle
+
+	case models.StatsScreen:
+		m.activeScreen = newStatsScreen(m.commits)
+		m.message = trf("Author statistics for %d commits", len(m.commits))
+		m.messageStyle = infoStyle
+
+	case models.HeatmapScreen:
+		m.activeScreen = newHeatmapScreen(m.commits)
+		m.message = tr("Contribution calendar")
+		m.messageStyle = infoStyle
+
+	case models.ActivityScreen:
+		m.activeScreen = newActivityScreen(m.commits)
+		m.message = tr("Commits by weekday and hour")
+		m.messageStyle = infoStyle
+
+	case models.HotspotsScreen:
+		m.activeScreen = newHotspotsScreen(m.commits)
+		m.message = tr("Most frequently changed files")
+		m.messageStyle = infoStyle
+
+	case models.CompareScreen:
+		m.activeScreen = newCompareScreen(m.gitService, m.commits, m.directory)
+		m.message = tr("Author comparison")
+		m.messageStyle = infoStyle
+
+	case models.PresetScreen:
+		m.activeScreen = newPresetScreen(m.gitService, m.config.Presets)
+		m.message = tr("Choose a saved preset to run")
+		m.messageStyle = infoStyle
+
+	case models.DiffScreen:
+		m.activeScreen = newDiffScreen(m.config, m.directory, msg.Data.Commit)
+		m.message = trf("Patch of %s", shortHash(msg.Data.Commit))
+		m.messageStyle = infoStyle
+		return m, loadDiffCmd(m.gitService, m.directory, msg.Data.Commit)
 	}
 
 	return m, textinput.Blink
 }
 
+// newResults builds the results screen for the current commits and the query
+// that produced them.
+func (m model) newResults() *resultsScreen {
+	cfg := m.config
+	if m.presetFormats != nil {
+		cfg.Export.Formats = m.presetFormats
+	}
+	s := newResultsScreen(m.gitService, cfg, m.commits, m.directory, m.branch, m.parentBranch, m.showFiles, m.dotnetMode).(*resultsScreen)
+	s.fetched = fetchQuery{
+		author:            m.author,
+		maxCommits:        m.maxCommits,
+		parentBranch:      m.parentBranch,
+		currentBranchOnly: m.currentBranchOnly,
+		missing:           m.missing,
+		unmerged:          m.unmerged,
+	}
+	return s
+}
+
+// isTyping reports whether keys go to a text field, so "?" is typed rather than
+// opening the help overlay.
+func isTyping(screen ScreenModel) bool {
+	switch screen.(type) {
+	case *directoryScreen, *authorScreen:
+		return true
+	}
+	return isEditing(screen)
+}
+
+// isEditing reports whether the screen has a text field focused that uses Esc itself.
+func isEditing(screen ScreenModel) bool {
+	switch s := screen.(type) {
+	case *optionsScreen:
+		return s.editing || s.branches != nil
+	case *resultsScreen:
+		return s.searching || s.editing != "" || s.picker != nil
+	case *directoryScreen:
+		return s.browser != nil
+	case *presetScreen:
+		return s.searching
+	}
+	return false
+}
+
+func isResultsView(screen models.Screen) bool {
+	switch screen {
+	case models.ResultsScreen, models.LeaderboardScreen, models.StatsScreen, models.HeatmapScreen, models.ActivityScreen,
+		models.HotspotsScreen, models.CompareScreen, models.DiffScreen:
+		return true
+	}
+	return false
+}
+
+func sameCommits(a, b []models.CommitInfo) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Hash != b[i].Hash {
+			return false
+		}
+	}
+	return true
+}
+
+// missingFrom names the parent branch when the results are the commits the current
+// branch is missing from it.
+func (m model) missingFrom() string {
+	if m.missing {
+		return m.parentBranch
+	}
+	return ""
+}
+
+func resultsMessage(count int, branch, missingFrom string, watching bool) string {
+	msg := trf("Found %d commits in branch '%s'", count, branch)
+	if missingFrom != "" {
+		msg = trf("Found %d commits on '%s' missing from '%s'", count, missingFrom, branch)
+	}
+	if watching {
+		msg += " · " + tr("watching for new commits")
+	}
+	return msg
+}
+
 func (m model) View() string {
 	var s strings.Builder
 
-	s.WriteString(lipgloss.Place(m.width, 3, lipgloss.Center, lipgloss.Center, titleStyle.Render("Gommits - Commit Analyzer")))
+	s.WriteString(lipgloss.Place(m.width, 3, lipgloss.Center, lipgloss.Center, titleStyle.Render(tr("Gommits - Commit Analyzer"))))
 	s.WriteString("\n")
 	s.WriteString(lipgloss.Place(m.width, 2, lipgloss.Center, lipgloss.Center, m.messageStyle.Render(m.message)))
 	s.WriteString("\n\n")
 
 	content := m.activeScreen.View(m.width, m.height)
-
-	contentPlaceHeight := m.height - 8 - 3
-	if contentPlaceHeight < 5 {
-		contentPlaceHeight = 5
+	if m.showHelp {
+		content = m.helpView()
+	}
+	if m.showError {
+		content = errorDetailView(*m.lastErr, m.width, m.contentHeight())
 	}
-	s.WriteString(lipgloss.Place(m.width, contentPlaceHeight, lipgloss.Center, lipgloss.Center, content))
+	if m.confirm != nil {
+		content = m.confirm.view()
+	}
+	s.WriteString(lipgloss.Place(m.width, m.contentHeight(), lipgloss.Center, lipgloss.Center, content))
 
-	footerText := "Navigation: " +
-		highlightStyle.Render("Enter") + " to proceed, " +
-		highlightStyle.Render("B") + " for back, " +
-		highlightStyle.Render("Esc/Ctrl+C") + " to quit"
+	footerText := trf("Navigation: %s to proceed, %s for back, %s to quit, %s for help",
+		highlightStyle.Render("Enter"),
+		highlightStyle.Render(helpKey(keys.Back)),
+		highlightStyle.Render(helpKey(keys.Quit)+"/Ctrl+C"),
+		highlightStyle.Render("?"))
 	s.WriteString("\n\n")
 	s.WriteString(lipgloss.Place(m.width, 1, lipgloss.Center, lipgloss.Center, dimmedStyle.Render(footerText)))
+	s.WriteString("\n" + m.statusBar())
 
 	screen := s.String()
 
-	if m.toastManager.IsVisible() {
+	if m.toastManager.IsVisible() && !accessible {
 		bg := backgroundViewModel{content: screen}
 		fg := toastViewModel{content: m.toastManager.View()}
 		o := overlay.New(fg, bg, overlay.Center, overlay.Top, 0, 1)
@@ -210,8 +574,41 @@ func (m model) View() string {
 	return screen
 }
 
-func StartUI() {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
+// contentTop is the row where the active screen's content area starts, below the
+// title and message.
+const contentTop = 6
+
+func (m model) contentHeight() int {
+	return max(m.height-8-3-1, 5) // the status bar takes the last row
+}
+
+// contentOrigin is where View places content, centered in the content area.
+func (m model) contentOrigin(content string) (x, y int) {
+	centered := func(gap int) int {
+		gap = max(gap, 0)
+		return gap - int(math.Round(float64(gap)*0.5))
+	}
+	return centered(m.width - lipgloss.Width(content)), contentTop + centered(m.contentHeight()-lipgloss.Height(content))
+}
+
+// Options adjust how the TUI draws itself.
+type Options struct {
+	// Plain drops colors, borders and animations, for dumb terminals, logs and
+	// users who set NO_COLOR.
+	Plain bool
+	// Accessible is plain mode for screen readers: no box-drawing or symbol glyphs,
+	// single-column layouts with labeled fields, and toasts announced on the
+	// status line instead of animated over the screen.
+	Accessible bool
+}
+
+func StartUI(opts Options) {
+	accessible = opts.Accessible
+	if opts.Plain || opts.Accessible {
+		plain = true
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	p := tea.NewProgram(initialModel(), tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if err := p.Start(); err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,56 @@
+package ui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// vimKeys translates vim motions into the arrow and paging keys the list and viewport
+// screens already handle, when ui.vim is set.
+type vimKeys struct {
+	pending bool // "g" was pressed and the next key completes the motion
+}
+
+// translate returns the key the active screen should see; ok is false when the key
+// only starts a motion and is swallowed.
+func (v *vimKeys) translate(msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	if v.pending {
+		v.pending = false
+		switch msg.String() {
+		case "g":
+			return tea.KeyMsg{Type: tea.KeyHome}, true
+		case "p":
+			return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")}, true
+		}
+		// Any other key keeps its screen meaning, so gh and gl still reach the
+		// shortcuts that h and l shadow.
+		return msg, true
+	}
+
+	switch msg.String() {
+	case "g":
+		v.pending = true
+		return msg, false
+	case "G":
+		return tea.KeyMsg{Type: tea.KeyEnd}, true
+	case "j":
+		return tea.KeyMsg{Type: tea.KeyDown}, true
+	case "k":
+		return tea.KeyMsg{Type: tea.KeyUp}, true
+	case "h":
+		return tea.KeyMsg{Type: tea.KeyLeft}, true
+	case "l":
+		return tea.KeyMsg{Type: tea.KeyRight}, true
+	case "ctrl+d":
+		return tea.KeyMsg{Type: tea.KeyPgDown}, true
+	case "ctrl+u":
+		return tea.KeyMsg{Type: tea.KeyPgUp}, true
+	}
+	return msg, true
+}
+
+// takesVimKeys reports whether the screen is navigated rather than typed into.
+func takesVimKeys(screen ScreenModel) bool {
+	switch screen.(type) {
+	case *homeScreen, *optionsScreen:
+		return false
+	}
+	return !isTyping(screen)
+}
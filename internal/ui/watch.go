@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+// watchInterval is how often the repository refs are polled while watching.
+const watchInterval = 5 * time.Second
+
+// toggleWatchMsg starts or stops refreshing the results when new commits land.
+type toggleWatchMsg struct{}
+
+// Watch messages carry the generation they were started in, so a loop left over
+// from an earlier toggle stops instead of running twice.
+type watchTickMsg struct{ gen int }
+
+type refStateMsg struct {
+	gen   int
+	state string
+	err   error
+}
+
+type watchRefreshMsg struct {
+	gen   int
+	fetch models.FetchCommitsMsg
+}
+
+func toggleWatchCmd() tea.Cmd {
+	return func() tea.Msg {
+		return toggleWatchMsg{}
+	}
+}
+
+func watchTickCmd(gen int) tea.Cmd {
+	return tea.Tick(watchInterval, func(time.Time) tea.Msg {
+		return watchTickMsg{gen: gen}
+	})
+}
+
+func refStateCmd(svc git.GitService, dir string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		state, err := svc.RefState(dir)
+		return refStateMsg{gen: gen, state: state, err: err}
+	}
+}
+
+func (m model) refreshCmd(gen int) tea.Cmd {
+	fetch := fetchCommitsCmd(m.gitService, m.directory, m.author, m.maxCommits, m.currentBranchOnly, m.missing, m.unmerged, m.parentBranch, m.dotnetMode)
+	return func() tea.Msg {
+		return watchRefreshMsg{gen: gen, fetch: fetch().(models.FetchCommitsMsg)}
+	}
+}
+
+func (m model) updateWatch(msg tea.Msg) (model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case toggleWatchMsg:
+		m.watchGen++
+		m.watching = !m.watching
+		m.watchState = ""
+		if !m.watching {
+			m.message = resultsMessage(len(m.commits), m.branch, m.missingFrom(), false)
+			return m, showToastCmd(tr("Stopped watching"), models.ToastSuccess, 2*time.Second)
+		}
+		m.message = resultsMessage(len(m.commits), m.branch, m.missingFrom(), true)
+		return m, refStateCmd(m.gitService, m.directory, m.watchGen)
+
+	case watchTickMsg:
+		if !m.watching || msg.gen != m.watchGen {
+			return m, nil
+		}
+		return m, refStateCmd(m.gitService, m.directory, msg.gen)
+
+	case refStateMsg:
+		if !m.watching || msg.gen != m.watchGen {
+			return m, nil
+		}
+		// A failed poll (e.g. mid-rebase) is retried on the next tick.
+		if msg.err != nil || msg.state == m.watchState {
+			return m, watchTickCmd(msg.gen)
+		}
+		first := m.watchState == ""
+		m.watchState = msg.state
+		if first {
+			return m, watchTickCmd(msg.gen)
+		}
+		return m, tea.Batch(m.refreshCmd(msg.gen), watchTickCmd(msg.gen))
+
+	case watchRefreshMsg:
+		if !m.watching || msg.gen != m.watchGen {
+			return m, nil
+		}
+		if msg.fetch.Err != nil {
+			return m, errorCmd(msg.fetch.Err, "fetching commits")
+		}
+		// A ref that moved without changing the results (another branch, a fetch)
+		// leaves everything as it is.
+		loc, _ := m.config.Location()
+		commits := utils.ConvertTimezone(msg.fetch.Commits, loc)
+		if sameCommits(m.commits, commits) && m.branch == msg.fetch.Branch {
+			return m, nil
+		}
+		before := len(m.commits)
+		m.commits = commits
+		m.branch = msg.fetch.Branch
+		m.message = resultsMessage(len(m.commits), m.branch, m.missingFrom(), m.watching)
+		// Other screens keep showing what they were opened with; the results are
+		// rebuilt from m.commits when the user goes back to them.
+		if current, ok := m.activeScreen.(*resultsScreen); ok {
+			next := m.newResults()
+			next.keepView(current)
+			m.activeScreen = next
+		}
+		cmd := enrichCommitsCmd(m.gitService, m.config, m.directory, m.commits)
+		if len(m.commits) != before {
+			cmd = tea.Batch(cmd, showToastCmd("🔄 "+tr("Results refreshed with new commits"), models.ToastSuccess, 3*time.Second))
+		}
+		return m, cmd
+	}
+	return m, nil
+}
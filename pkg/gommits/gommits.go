@@ -0,0 +1,218 @@
+// Package gommits gathers and exports the commits of a Git repository the way the
+// gommits CLI does, for Go programs that embed the analysis. It needs git on the
+// PATH. Its types are stable; the internal packages behind it are not.
+package gommits
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/leeozaka/gommits/internal/report"
+	"github.com/leeozaka/gommits/pkg/utils"
+)
+
+// Errors Gather can fail with, for errors.Is.
+var (
+	ErrGitNotInstalled = git.ErrGitNotInstalled
+	ErrNotARepo        = git.ErrNotARepo
+	ErrNoSuchRef       = git.ErrNoSuchRef
+	ErrEmptyRepo       = git.ErrEmptyRepo
+)
+
+// Options select the commits Gather returns.
+type Options struct {
+	// Repo is a path inside the repository; empty means the current directory.
+	Repo string
+	// Authors match git's --author, by name or email; empty matches everyone.
+	Authors []string
+	// Since and Until bound the author date; zero values leave that side open.
+	Since time.Time
+	Until time.Time
+	// AllBranches gathers every branch instead of the current branch's own commits,
+	// those not on ParentBranch.
+	AllBranches bool
+	// ParentBranch is where the current branch forked from; empty detects the
+	// default branch (main, master, ...).
+	ParentBranch string
+	// MaxCommits caps the result, newest first; 0 means no limit.
+	MaxCommits int
+	// OnProgress, when set, is called as git's output is parsed, from another
+	// goroutine than Gather's but never from two at once.
+	OnProgress func(Progress)
+}
+
+// Progress is how far Gather has got.
+type Progress struct {
+	Commits int // commits parsed so far
+	Total   int // commits to parse; 0 until git has counted them
+	Files   int // changed files resolved so far
+}
+
+// Commit is one gathered commit.
+type Commit struct {
+	Hash    string
+	Author  string
+	Email   string
+	When    time.Time // author date
+	Branch  string    // ref the commit was reached from
+	Message string    // subject line
+	Files   []FileChange
+
+	Additions int
+	Deletions int
+
+	SignedOffBy []string // Signed-off-by trailer values ("Name <email>")
+	// Unmerged marks a commit on another branch whose change has not landed on the
+	// parent branch. Only set with AllBranches.
+	Unmerged bool
+}
+
+// FileChange is a file a commit touched, with its line counts.
+type FileChange struct {
+	Path      string
+	Additions int
+	Deletions int
+}
+
+// Format is an export format.
+type Format string
+
+const (
+	CSV  Format = "csv"
+	JSON Format = "json"
+	XLSX Format = "xlsx"
+)
+
+// Gather returns the commits opts select, newest first. Cancelling ctx stops git
+// and makes Gather return ctx.Err().
+func Gather(ctx context.Context, opts Options) ([]Commit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	dir, err := filepath.Abs(opts.Repo)
+	if err != nil {
+		return nil, err
+	}
+	svc := git.NewServiceWithRunner(git.CLIRunner{Ctx: ctx})
+	if !svc.IsGitRepo(dir) {
+		return nil, fmt.Errorf("%s: %w", dir, ErrNotARepo)
+	}
+
+	q := report.Query{
+		Dir:               dir,
+		Author:            strings.Join(opts.Authors, ","),
+		MaxCommits:        opts.MaxCommits,
+		CurrentBranchOnly: !opts.AllBranches,
+		ParentBranch:      opts.ParentBranch,
+		Since:             opts.Since,
+		Until:             opts.Until,
+	}
+	if q.ParentBranch == "" {
+		q.ParentBranch = svc.DetectDefaultBranch(dir)
+	}
+	if opts.OnProgress != nil {
+		q.OnProgress = func(p git.Progress) {
+			opts.OnProgress(Progress{Commits: p.Commits, Total: p.Total, Files: p.Files})
+		}
+	}
+
+	type result struct {
+		res report.Result
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		res, err := report.Gather(svc, q)
+		done <- result{res, err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		commits := make([]Commit, len(r.res.Commits))
+		for i, c := range r.res.Commits {
+			commits[i] = fromModel(c)
+		}
+		return commits, nil
+	}
+}
+
+// Export writes commits to w in format, laid out as the CLI's exports with the
+// default configuration.
+func Export(w io.Writer, format Format, commits []Commit) error {
+	infos := make([]models.CommitInfo, len(commits))
+	for i, c := range commits {
+		infos[i] = toModel(c)
+	}
+	cfg := config.Default()
+
+	switch format {
+	case CSV:
+		return utils.WriteCSV(w, infos, cfg.Export)
+	case JSON:
+		data, err := utils.EncodeJSONExport(infos, "")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case XLSX:
+		return utils.WriteExcelTo(w, infos, "", "", cfg)
+	}
+	return fmt.Errorf("unsupported export format %q", format)
+}
+
+// gitDateLayout matches git's default %ad output, which the exports fall back to.
+const gitDateLayout = "Mon Jan 2 15:04:05 2006 -0700"
+
+func fromModel(c models.CommitInfo) Commit {
+	commit := Commit{
+		Hash:        c.Hash,
+		Author:      c.Author,
+		Email:       c.Email,
+		When:        c.When,
+		Branch:      c.Branch,
+		Message:     c.Message,
+		Additions:   c.Additions,
+		Deletions:   c.Deletions,
+		SignedOffBy: c.SignedOff,
+		Unmerged:    c.Unmerged,
+	}
+	for _, f := range c.FileChanges {
+		commit.Files = append(commit.Files, FileChange{Path: f.Path, Additions: f.Additions, Deletions: f.Deletions})
+	}
+	return commit
+}
+
+func toModel(c Commit) models.CommitInfo {
+	info := models.CommitInfo{
+		Hash:      c.Hash,
+		Author:    c.Author,
+		Email:     c.Email,
+		When:      c.When,
+		Branch:    c.Branch,
+		Message:   c.Message,
+		Additions: c.Additions,
+		Deletions: c.Deletions,
+		SignedOff: c.SignedOffBy,
+		Unmerged:  c.Unmerged,
+	}
+	if !c.When.IsZero() {
+		info.Date = c.When.Format(gitDateLayout)
+	}
+	for _, f := range c.Files {
+		info.Files = append(info.Files, f.Path)
+		info.FileChanges = append(info.FileChanges, models.FileChange{Path: f.Path, Additions: f.Additions, Deletions: f.Deletions})
+	}
+	return info
+}
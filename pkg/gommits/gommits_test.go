@@ -0,0 +1,156 @@
+package gommits_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/leeozaka/gommits/pkg/gommits"
+)
+
+// newRepo commits one file per entry, in order, as the given author on the given
+// day, and returns the repository's path.
+func newRepo(t *testing.T, commits ...[2]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(env []string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git(nil, "init", "-q", "-b", "main")
+	for i, c := range commits {
+		name := filepath.Join(dir, c[0]+string(rune('a'+i))+".txt")
+		if err := os.WriteFile(name, []byte(c[1]+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git(nil, "add", ".")
+		date := c[1] + "T12:00:00Z"
+		git([]string{
+			"GIT_AUTHOR_NAME=" + c[0], "GIT_AUTHOR_EMAIL=" + c[0] + "@example.com", "GIT_AUTHOR_DATE=" + date,
+			"GIT_COMMITTER_NAME=" + c[0], "GIT_COMMITTER_EMAIL=" + c[0] + "@example.com", "GIT_COMMITTER_DATE=" + date,
+		}, "commit", "-q", "-m", "Change by "+c[0]+" on "+c[1])
+	}
+	return dir
+}
+
+func TestGatherKeepsNewestAcrossAuthors(t *testing.T) {
+	dir := newRepo(t,
+		[2]string{"ana", "2024-01-01"},
+		[2]string{"ana", "2024-01-02"},
+		[2]string{"bo", "2024-01-03"},
+		[2]string{"bo", "2024-01-04"},
+		[2]string{"ana", "2024-01-05"},
+	)
+
+	commits, err := gommits.Gather(context.Background(), gommits.Options{
+		Repo: dir, Authors: []string{"ana", "bo"}, AllBranches: true, MaxCommits: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range commits {
+		got = append(got, c.Author+" "+c.When.UTC().Format("2006-01-02"))
+	}
+	want := []string{"ana 2024-01-05", "bo 2024-01-04", "bo 2024-01-03"}
+	if len(got) != len(want) {
+		t.Fatalf("Gather = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Gather = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestGatherExportRoundTrip(t *testing.T) {
+	dir := newRepo(t,
+		[2]string{"ana", "2024-01-01"},
+		[2]string{"bo", "2024-01-02"},
+	)
+	commits, err := gommits.Gather(context.Background(), gommits.Options{Repo: dir, AllBranches: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 || len(commits[0].Files) != 1 || commits[0].Additions != 1 {
+		t.Fatalf("Gather = %+v, want 2 commits adding one line to one file each", commits)
+	}
+
+	var out bytes.Buffer
+	if err := gommits.Export(&out, gommits.JSON, commits); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		SchemaVersion int `json:"schemaVersion"`
+		Commits       []struct {
+			Hash   string `json:"hash"`
+			Author string `json:"author"`
+		} `json:"commits"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.SchemaVersion != 1 || len(doc.Commits) != 2 {
+		t.Fatalf("JSON export = %s", out.String())
+	}
+	for i, c := range doc.Commits {
+		if c.Hash != commits[i].Hash || c.Author != commits[i].Author {
+			t.Errorf("JSON commit %d = %s by %s, want %s by %s", i, c.Hash, c.Author, commits[i].Hash, commits[i].Author)
+		}
+	}
+
+	out.Reset()
+	if err := gommits.Export(&out, gommits.CSV, commits); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Errorf("CSV export has %d rows, want a header and 2 commits", len(rows))
+	}
+
+	out.Reset()
+	if err := gommits.Export(&out, gommits.XLSX, commits); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(out.Bytes(), []byte("PK")) {
+		t.Error("XLSX export is not a zip archive")
+	}
+
+	if err := gommits.Export(&out, "pdf", commits); err == nil {
+		t.Error("Export to an unknown format succeeded")
+	}
+}
+
+func TestGatherCancelled(t *testing.T) {
+	dir := newRepo(t, [2]string{"ana", "2024-01-01"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := gommits.Gather(ctx, gommits.Options{Repo: dir}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Gather with a cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestGatherNotARepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	if _, err := gommits.Gather(context.Background(), gommits.Options{Repo: t.TempDir()}); !errors.Is(err, gommits.ErrNotARepo) {
+		t.Errorf("Gather outside a repository = %v, want ErrNotARepo", err)
+	}
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// Working hours used to flag after-hours commits: weekdays from 09:00 to 18:00.
+const (
+	WorkdayStartHour = 9
+	WorkdayEndHour   = 18
+)
+
+// ActivityMatrix counts commits by weekday (Monday first) and hour of day.
+type ActivityMatrix [7][24]int
+
+// WeekdayHourMatrix buckets commits by their author time; convert to the reporting
+// timezone first (see ConvertTimezone).
+func WeekdayHourMatrix(commits []models.CommitInfo) ActivityMatrix {
+	var m ActivityMatrix
+	for _, c := range commits {
+		if c.When.IsZero() {
+			continue
+		}
+		m[isoWeekday(c.When)][c.When.Hour()]++
+	}
+	return m
+}
+
+// Max returns the busiest cell.
+func (m ActivityMatrix) Max() int {
+	busiest := 0
+	for _, row := range m {
+		for _, n := range row {
+			busiest = max(busiest, n)
+		}
+	}
+	return busiest
+}
+
+// WeekdayTotal sums one weekday (0 is Monday).
+func (m ActivityMatrix) WeekdayTotal(day int) int {
+	total := 0
+	for _, n := range m[day] {
+		total += n
+	}
+	return total
+}
+
+// AfterHours sums commits on weekends or outside WorkdayStartHour–WorkdayEndHour.
+func (m ActivityMatrix) AfterHours() int {
+	total := 0
+	for day, row := range m {
+		for hour, n := range row {
+			if day >= 5 || hour < WorkdayStartHour || hour >= WorkdayEndHour {
+				total += n
+			}
+		}
+	}
+	return total
+}
+
+// isoWeekday maps time.Weekday to 0 for Monday through 6 for Sunday.
+func isoWeekday(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
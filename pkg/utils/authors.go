@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// FormatAuthors writes an AUTHORS file listing everyone in the gathered history.
+const FormatAuthors = "authors"
+
+// Contributor is one AUTHORS entry, keyed by the .mailmap-resolved email.
+type Contributor struct {
+	Name    string
+	Email   string
+	Commits int
+	First   time.Time
+	Last    time.Time
+}
+
+// CollectContributors groups commits by canonical identity, earliest contributor first.
+func CollectContributors(commits []models.CommitInfo) []Contributor {
+	index := make(map[string]int)
+	var contributors []Contributor
+	for _, c := range commits {
+		name, email := canonicalIdentity(c)
+		key := strings.ToLower(email)
+		if key == "" {
+			key = strings.ToLower(name)
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(contributors)
+			index[key] = i
+			contributors = append(contributors, Contributor{Name: name, Email: email})
+		}
+		ct := &contributors[i]
+		ct.Commits++
+		if c.When.IsZero() {
+			continue
+		}
+		if ct.First.IsZero() || c.When.Before(ct.First) {
+			ct.First = c.When
+		}
+		if c.When.After(ct.Last) {
+			// The most recent commit decides the name, so renames settle on the current one.
+			ct.Last = c.When
+			ct.Name = name
+		}
+	}
+
+	sort.SliceStable(contributors, func(i, j int) bool {
+		if !contributors[i].First.Equal(contributors[j].First) {
+			return contributors[i].First.Before(contributors[j].First)
+		}
+		return contributors[i].Name < contributors[j].Name
+	})
+	return contributors
+}
+
+func canonicalIdentity(c models.CommitInfo) (name, email string) {
+	name, email = c.CanonicalAuthor, c.CanonicalEmail
+	if name == "" {
+		name = c.Author
+	}
+	if email == "" {
+		email = c.Email
+	}
+	return name, email
+}
+
+// RenderAuthors formats contributors as an aligned AUTHORS file.
+func RenderAuthors(repoName string, contributors []Contributor) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Authors of %s, generated by gommits from the commit history.\n", repoName)
+	b.WriteString("# Names and emails follow .mailmap when the repository has one.\n\n")
+
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "# Name <email>\tCommits\tFirst\tLast")
+	for _, c := range contributors {
+		fmt.Fprintf(w, "%s <%s>\t%d\t%s\t%s\n", c.Name, c.Email, c.Commits, authorsDate(c.First), authorsDate(c.Last))
+	}
+	w.Flush()
+	return b.String()
+}
+
+func authorsDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
+
+func AuthorsPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_AUTHORS.txt")
+}
+
+func exportAuthors(req ExportRequest) ([]string, error) {
+	path := AuthorsPath(req.Dir, req.RepoName)
+	if file := req.Config.Export.AuthorsFile; file != "" {
+		path = file
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(req.RepoPath, path)
+		}
+	}
+	content := RenderAuthors(req.RepoName, CollectContributors(req.Commits))
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write authors file: %v", err)
+	}
+	return []string{path}, nil
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+)
+
+// BillingLine is the time one author spent on one project in one week, priced at
+// their hourly rate.
+type BillingLine struct {
+	Week    string // Monday of the ISO week, time.DateOnly
+	Project string
+	Author  string
+	Email   string
+	Hours   float64
+	Rate    float64
+}
+
+func (b BillingLine) Amount() float64 {
+	return b.Hours * b.Rate
+}
+
+// HourlyRate looks an author up in cfg.Rates by email, then name, falling back to
+// cfg.DefaultRate.
+func HourlyRate(cfg config.TimesheetConfig, name, email string) float64 {
+	for _, id := range []string{email, name} {
+		if id == "" {
+			continue
+		}
+		for key, rate := range cfg.Rates {
+			if strings.EqualFold(key, id) {
+				return rate
+			}
+		}
+	}
+	return cfg.DefaultRate
+}
+
+// Billing spreads each worklog day's hours over the projects it touched, in proportion
+// to the files changed in each, and totals them per week, project and author.
+func Billing(days []WorklogDay, cfg config.TimesheetConfig) []BillingLine {
+	depth := cfg.ProjectDepth
+	if depth <= 0 {
+		depth = 1
+	}
+
+	index := make(map[[3]string]int)
+	var lines []BillingLine
+	for _, d := range days {
+		week := d.Start.AddDate(0, 0, -isoWeekday(d.Start)).Format(time.DateOnly)
+		files := make(map[string]int)
+		total := 0
+		for _, c := range d.Commits {
+			for _, file := range touchedFiles(c) {
+				files[directoryOf(file, depth)]++
+				total++
+			}
+		}
+		if total == 0 {
+			files[RootDirectory] = 1
+			total = 1
+		}
+
+		for project, n := range files {
+			key := [3]string{week, project, strings.ToLower(d.Email)}
+			i, ok := index[key]
+			if !ok {
+				i = len(lines)
+				index[key] = i
+				lines = append(lines, BillingLine{
+					Week:    week,
+					Project: project,
+					Author:  d.Author,
+					Email:   d.Email,
+					Rate:    HourlyRate(cfg, d.Author, d.Email),
+				})
+			}
+			lines[i].Hours += d.Hours * float64(n) / float64(total)
+		}
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		a, b := lines[i], lines[j]
+		if a.Week != b.Week {
+			return a.Week < b.Week
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return a.Author < b.Author
+	})
+	return lines
+}
+
+// ProjectTotal is the billed time on one project across the whole range.
+type ProjectTotal struct {
+	Project string
+	Hours   float64
+	Amount  float64
+}
+
+// ProjectTotals sums billing lines per project, highest amount first.
+func ProjectTotals(lines []BillingLine) []ProjectTotal {
+	index := make(map[string]int)
+	var totals []ProjectTotal
+	for _, l := range lines {
+		i, ok := index[l.Project]
+		if !ok {
+			i = len(totals)
+			index[l.Project] = i
+			totals = append(totals, ProjectTotal{Project: l.Project})
+		}
+		totals[i].Hours += l.Hours
+		totals[i].Amount += l.Amount()
+	}
+	sort.SliceStable(totals, func(i, j int) bool {
+		if totals[i].Amount != totals[j].Amount {
+			return totals[i].Amount > totals[j].Amount
+		}
+		if totals[i].Hours != totals[j].Hours {
+			return totals[i].Hours > totals[j].Hours
+		}
+		return totals[i].Project < totals[j].Project
+	})
+	return totals
+}
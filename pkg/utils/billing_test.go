@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/leeozaka/gommits/internal/config"
+)
+
+func TestHourlyRate(t *testing.T) {
+	cfg := config.TimesheetConfig{
+		DefaultRate: 50,
+		Rates:       map[string]float64{"ana@x.com": 100, "Ana": 80, "Bruno": 70},
+	}
+	tests := []struct {
+		name, email string
+		want        float64
+	}{
+		{"Ana", "ana@x.com", 100},
+		{"Ana", "ANA@X.COM", 100},
+		{"Ana", "ana@elsewhere.com", 80},
+		{"bruno", "bruno@x.com", 70},
+		{"Carla", "carla@x.com", 50},
+		{"", "", 50},
+	}
+	for _, tt := range tests {
+		// Map order varies between runs, so check each case more than once.
+		for range 20 {
+			if got := HourlyRate(cfg, tt.name, tt.email); got != tt.want {
+				t.Fatalf("HourlyRate(%q, %q) = %v, want %v", tt.name, tt.email, got, tt.want)
+			}
+		}
+	}
+}
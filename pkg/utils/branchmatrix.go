@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// Presence of a change on one branch of the matrix.
+const (
+	BranchMissing    = iota
+	BranchSame       // the very same commit is on the branch
+	BranchEquivalent // a commit with the same patch, e.g. a cherry-pick or rebase
+)
+
+// BranchMatrixRow is one change and where it landed.
+type BranchMatrixRow struct {
+	Commit models.CommitInfo // the oldest commit carrying the change
+	// Hashes holds, per branch in matrix order, the commit carrying the change there;
+	// empty when the branch lacks it.
+	Hashes []string
+}
+
+// Presence reports how the change is on branch i.
+func (r BranchMatrixRow) Presence(i int) int {
+	switch r.Hashes[i] {
+	case "":
+		return BranchMissing
+	case r.Commit.Hash:
+		return BranchSame
+	}
+	return BranchEquivalent
+}
+
+// BuildBranchMatrix matches the commits of each branch (in branch order, as returned
+// by git.BranchCommits) by patch ID, newest change first.
+func BuildBranchMatrix(perBranch [][]models.CommitInfo) []BranchMatrixRow {
+	index := make(map[string]int)
+	var rows []BranchMatrixRow
+	for b, commits := range perBranch {
+		for _, c := range commits {
+			key := c.PatchID
+			if key == "" {
+				key = c.Hash
+			}
+			i, ok := index[key]
+			if !ok {
+				i = len(rows)
+				index[key] = i
+				rows = append(rows, BranchMatrixRow{Commit: c, Hashes: make([]string, len(perBranch))})
+			}
+			if rows[i].Hashes[b] == "" {
+				rows[i].Hashes[b] = c.Hash
+			}
+			if c.When.Before(rows[i].Commit.When) {
+				rows[i].Commit = c
+			}
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Commit.When.After(rows[j].Commit.When) })
+	return rows
+}
+
+func BranchMatrixPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_branches.xlsx")
+}
+
+// ExportBranchMatrix writes one row per change and one column per branch holding the
+// commit that carries it there, colored by presence.
+func ExportBranchMatrix(branches []string, rows []BranchMatrixRow, path string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "#FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %v", err)
+	}
+	presenceStyles := make(map[int]int)
+	for presence, color := range map[int]string{BranchMissing: "#F8CBAD", BranchSame: "#C6EFCE", BranchEquivalent: "#FFEB9C"} {
+		if presenceStyles[presence], err = f.NewStyle(&excelize.Style{
+			Fill: excelize.Fill{Type: "pattern", Color: []string{color}, Pattern: 1},
+		}); err != nil {
+			return fmt.Errorf("failed to create branch style: %v", err)
+		}
+	}
+
+	sheet := "Branches"
+	f.SetSheetName("Sheet1", sheet)
+	header := []any{"Date", "Author", "Message"}
+	for _, b := range branches {
+		header = append(header, b)
+	}
+	f.SetSheetRow(sheet, "A1", &header)
+	last, _ := excelize.CoordinatesToCellName(len(header), 1)
+	f.SetCellStyle(sheet, "A1", last, headerStyle)
+
+	for i, r := range rows {
+		values := []any{r.Commit.When.Format("2006-01-02 15:04"), r.Commit.Author, r.Commit.Message}
+		for _, h := range r.Hashes {
+			if len(h) > 7 {
+				h = h[:7]
+			}
+			values = append(values, h)
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		f.SetSheetRow(sheet, cell, &values)
+		for b := range branches {
+			cell, _ := excelize.CoordinatesToCellName(4+b, i+2)
+			f.SetCellStyle(sheet, cell, cell, presenceStyles[r.Presence(b)])
+		}
+	}
+
+	f.SetColWidth(sheet, "A", "A", 18)
+	f.SetColWidth(sheet, "B", "B", 24)
+	f.SetColWidth(sheet, "C", "C", 60)
+	if len(branches) > 0 {
+		lastCol, _ := excelize.ColumnNumberToName(3 + len(branches))
+		f.SetColWidth(sheet, "D", lastCol, 16)
+	}
+	f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to save branch matrix: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// DefaultBusFactorThreshold is the share of commits the key authors must cover.
+const DefaultBusFactorThreshold = 0.5
+
+// RootDirectory groups files that live at the top of the repository.
+const RootDirectory = "."
+
+// DirectoryOwnership describes how concentrated authorship of one directory is.
+type DirectoryOwnership struct {
+	Directory string
+	Commits   int
+	Authors   int
+	TopAuthor string
+	// TopShare is the fraction of Commits made by TopAuthor.
+	TopShare float64
+	// BusFactor is the fewest authors that together made more than the threshold
+	// share of Commits.
+	BusFactor int
+}
+
+// BusFactors computes ownership per top-level directory, riskiest (lowest bus factor,
+// then most concentrated) first. A commit counts once per directory it touches.
+func BusFactors(commits []models.CommitInfo, threshold float64) []DirectoryOwnership {
+	if threshold <= 0 || threshold >= 1 {
+		threshold = DefaultBusFactorThreshold
+	}
+
+	perDir := make(map[string]map[string]int) // directory -> author email -> commits
+	names := make(map[string]string)
+	for _, c := range commits {
+		name, email := canonicalIdentity(c)
+		key := strings.ToLower(email)
+		names[key] = name
+
+		seen := make(map[string]bool)
+		for _, f := range touchedFiles(c) {
+			dir := topDirectory(f)
+			if seen[dir] {
+				continue
+			}
+			seen[dir] = true
+			if perDir[dir] == nil {
+				perDir[dir] = make(map[string]int)
+			}
+			perDir[dir][key]++
+		}
+	}
+
+	result := make([]DirectoryOwnership, 0, len(perDir))
+	for dir, authors := range perDir {
+		type authorCommits struct {
+			key string
+			n   int
+		}
+		counts := make([]authorCommits, 0, len(authors))
+		total := 0
+		for key, n := range authors {
+			counts = append(counts, authorCommits{key, n})
+			total += n
+		}
+		sort.Slice(counts, func(i, j int) bool {
+			if counts[i].n != counts[j].n {
+				return counts[i].n > counts[j].n
+			}
+			return counts[i].key < counts[j].key
+		})
+
+		o := DirectoryOwnership{
+			Directory: dir,
+			Commits:   total,
+			Authors:   len(counts),
+			TopAuthor: names[counts[0].key],
+			TopShare:  float64(counts[0].n) / float64(total),
+		}
+		covered := 0
+		for _, c := range counts {
+			covered += c.n
+			o.BusFactor++
+			if float64(covered) > threshold*float64(total) {
+				break
+			}
+		}
+		result = append(result, o)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].BusFactor != result[j].BusFactor {
+			return result[i].BusFactor < result[j].BusFactor
+		}
+		if result[i].TopShare != result[j].TopShare {
+			return result[i].TopShare > result[j].TopShare
+		}
+		return result[i].Directory < result[j].Directory
+	})
+	return result
+}
+
+// topDirectory returns the first path segment, or RootDirectory for top-level files.
+func topDirectory(path string) string {
+	if dir, _, ok := strings.Cut(path, "/"); ok {
+		return dir
+	}
+	return RootDirectory
+}
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// FormatChangelog renders a CHANGELOG section grouped by conventional type.
+const FormatChangelog = "changelog"
+
+// DefaultChangelogTypes are the types listed when config.ChangelogConfig.Types is empty.
+var DefaultChangelogTypes = []string{"feat", "fix", "perf", "revert"}
+
+var changelogTitles = map[string]string{
+	"feat":     "Features",
+	"fix":      "Bug Fixes",
+	"perf":     "Performance",
+	"refactor": "Refactoring",
+	"revert":   "Reverts",
+	"docs":     "Documentation",
+	"test":     "Tests",
+	"build":    "Build System",
+	"ci":       "Continuous Integration",
+	"style":    "Styles",
+	"chore":    "Chores",
+	OtherType:  "Other Changes",
+}
+
+const changelogHeader = "# Changelog\n\n"
+
+func ChangelogPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_CHANGELOG.md")
+}
+
+// ChangeSection is one heading of a changelog or release notes.
+type ChangeSection struct {
+	Heading string
+	Entries []ChangeEntry
+}
+
+type ChangeEntry struct {
+	Scope       string
+	Description string
+	Hash        string // abbreviated
+	URL         string
+	Author      string
+}
+
+// groupChanges sorts commits into sections: breaking changes first whatever their
+// type, then one section per entry of types that has commits (OtherType covers
+// non-conventional subjects).
+func groupChanges(commits []models.CommitInfo, types []string) []ChangeSection {
+	var breaking []ChangeEntry
+	grouped := make(map[string][]ChangeEntry)
+	for _, c := range commits {
+		cc, ok := ParseConventional(c.Message)
+		if !ok {
+			cc = ConventionalCommit{Type: OtherType, Description: c.Message}
+		}
+		short := c.Hash
+		if len(short) > 7 {
+			short = short[:7]
+		}
+		entry := ChangeEntry{Scope: cc.Scope, Description: cc.Description, Hash: short, URL: c.URL, Author: c.Author}
+		if cc.Breaking {
+			breaking = append(breaking, entry)
+			continue
+		}
+		grouped[cc.Type] = append(grouped[cc.Type], entry)
+	}
+
+	var sections []ChangeSection
+	if len(breaking) > 0 {
+		sections = append(sections, ChangeSection{Heading: "Breaking Changes", Entries: breaking})
+	}
+	for _, t := range types {
+		t = strings.ToLower(t)
+		if len(grouped[t]) == 0 {
+			continue
+		}
+		heading, ok := changelogTitles[t]
+		if !ok {
+			heading = t
+		}
+		sections = append(sections, ChangeSection{Heading: heading, Entries: grouped[t]})
+	}
+	return sections
+}
+
+// markdownEntry renders "- **scope:** description (hash)", linking the hash when possible.
+func markdownEntry(e ChangeEntry) string {
+	ref := e.Hash
+	if e.URL != "" {
+		ref = fmt.Sprintf("[%s](%s)", e.Hash, e.URL)
+	}
+	if e.Scope != "" {
+		return fmt.Sprintf("- **%s:** %s (%s)", e.Scope, e.Description, ref)
+	}
+	return fmt.Sprintf("- %s (%s)", e.Description, ref)
+}
+
+// RenderChangelog builds one Markdown release section from the types in opts.
+func RenderChangelog(commits []models.CommitInfo, opts config.ChangelogConfig, date time.Time) string {
+	title := opts.Title
+	if title == "" {
+		title = "Unreleased"
+	}
+	types := opts.Types
+	if len(types) == 0 {
+		types = DefaultChangelogTypes
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s (%s)\n", title, date.Format(time.DateOnly))
+	for _, section := range groupChanges(commits, types) {
+		fmt.Fprintf(&b, "\n### %s\n\n", section.Heading)
+		for _, e := range section.Entries {
+			b.WriteString(markdownEntry(e) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// WriteChangelog inserts section into the changelog at path above the newest release,
+// creating the file with a "# Changelog" heading when it does not exist.
+func WriteChangelog(path, section string) error {
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return os.WriteFile(path, []byte(changelogHeader+section), 0o644)
+	}
+	if err != nil {
+		return err
+	}
+
+	content := string(existing)
+	var updated string
+	switch i := strings.Index("\n"+content, "\n## "); {
+	case i >= 0:
+		updated = content[:i] + section + "\n" + content[i:]
+	default:
+		updated = strings.TrimRight(content, "\n") + "\n\n" + section
+	}
+	return os.WriteFile(path, []byte(updated), 0o644)
+}
+
+func exportChangelog(req ExportRequest) ([]string, error) {
+	opts := req.Config.Export.Changelog
+	path := ChangelogPath(req.Dir, req.RepoName)
+	if opts.File != "" {
+		path = opts.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(req.RepoPath, path)
+		}
+	}
+	if err := WriteChangelog(path, RenderChangelog(req.Commits, opts, time.Now())); err != nil {
+		return nil, fmt.Errorf("failed to write changelog: %v", err)
+	}
+	return []string{path}, nil
+}
@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// Column keys accepted in config.ExportConfig.Columns.
+const (
+	ColumnHash      = "hash"
+	ColumnAuthor    = "author"
+	ColumnEmail     = "email"
+	ColumnDate      = "date"
+	ColumnMessage   = "message"
+	ColumnFiles     = "files"
+	ColumnAdditions = "additions"
+	ColumnDeletions = "deletions"
+	ColumnLines     = "lines"
+	ColumnBranch    = "branch"
+	ColumnPR        = "pr"
+	ColumnReview    = "review"
+	ColumnChecks    = "checks"
+	ColumnWorkItems = "workitems"
+	ColumnIssues    = "issues"
+	ColumnType      = "type"
+	ColumnScope     = "scope"
+	ColumnDCO       = "dco"
+	ColumnUnmerged  = "unmerged"
+	ColumnPeriod    = "period"
+)
+
+// DefaultColumns is the layout used when no columns are configured.
+var DefaultColumns = []string{ColumnHash, ColumnAuthor, ColumnEmail, ColumnDate, ColumnMessage, ColumnFiles}
+
+// enrichmentColumns are appended to the default layout once any commit carries the data.
+var enrichmentColumns = []struct {
+	keys []string
+	has  func(c models.CommitInfo) bool
+}{
+	{[]string{ColumnPR, ColumnReview, ColumnChecks}, func(c models.CommitInfo) bool { return c.PullRequest != nil }},
+	{[]string{ColumnWorkItems}, func(c models.CommitInfo) bool { return len(c.WorkItems) > 0 }},
+	{[]string{ColumnIssues}, func(c models.CommitInfo) bool { return len(c.Issues) > 0 }},
+	{[]string{ColumnType, ColumnScope}, isConventional},
+	{[]string{ColumnDCO}, hasSignOff},
+	{[]string{ColumnUnmerged}, func(c models.CommitInfo) bool { return c.Unmerged }},
+}
+
+// csvHeaders keeps the machine-friendly CSV header names stable across locales.
+var csvHeaders = map[string]string{
+	ColumnHash:      "commit_hash",
+	ColumnAuthor:    "author_name",
+	ColumnEmail:     "author_email",
+	ColumnDate:      "commit_date",
+	ColumnMessage:   "commit_message",
+	ColumnFiles:     "file_path",
+	ColumnAdditions: "additions",
+	ColumnDeletions: "deletions",
+	ColumnLines:     "lines_changed",
+	ColumnBranch:    "branch",
+	ColumnPR:        "pull_request",
+	ColumnReview:    "review_state",
+	ColumnChecks:    "checks_status",
+	ColumnWorkItems: "work_items",
+	ColumnIssues:    "issues",
+	ColumnType:      "commit_type",
+	ColumnScope:     "commit_scope",
+	ColumnDCO:       "dco_status",
+	ColumnUnmerged:  "unmerged",
+	ColumnPeriod:    "period",
+}
+
+// ResolveColumns validates configured column keys, falling back to DefaultColumns.
+func ResolveColumns(keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return DefaultColumns, nil
+	}
+	seen := make(map[string]bool, len(keys))
+	resolved := make([]string, 0, len(keys))
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, ok := csvHeaders[key]; !ok {
+			return nil, fmt.Errorf("unknown export column %q", key)
+		}
+		if !seen[key] {
+			seen[key] = true
+			resolved = append(resolved, key)
+		}
+	}
+	return resolved, nil
+}
+
+// exportColumns resolves the configured columns for commits. Without an explicit
+// layout, enrichment columns are added when at least one commit has the data, and
+// a period column follows the date when opts.GroupBy is set.
+func exportColumns(opts config.ExportConfig, commits []models.CommitInfo) ([]string, error) {
+	if _, err := ResolveGroupBy(opts.GroupBy); err != nil {
+		return nil, err
+	}
+	columns, err := ResolveColumns(opts.Columns)
+	if err != nil || len(opts.Columns) > 0 {
+		return columns, err
+	}
+	columns = append([]string{}, columns...)
+	if opts.GroupBy != "" {
+		at := len(columns)
+		for i, key := range columns {
+			if key == ColumnDate {
+				at = i + 1
+			}
+		}
+		columns = append(columns[:at], append([]string{ColumnPeriod}, columns[at:]...)...)
+	}
+	for _, extra := range enrichmentColumns {
+		for _, c := range commits {
+			if extra.has(c) {
+				columns = append(columns, extra.keys...)
+				break
+			}
+		}
+	}
+	return columns, nil
+}
+
+// isNumericColumn reports whether a column holds integers rather than text.
+func isNumericColumn(key string) bool {
+	return key == ColumnAdditions || key == ColumnDeletions || key == ColumnLines
+}
+
+// columnText renders a column's plain-text value; files are joined with sep, dates
+// use dateLayout (see DateLayout) and periods groupBy (see PeriodLabel).
+func columnText(key string, c models.CommitInfo, sep, dateLayout, groupBy string) string {
+	switch key {
+	case ColumnHash:
+		return c.Hash
+	case ColumnAuthor:
+		return c.Author
+	case ColumnEmail:
+		return c.Email
+	case ColumnDate:
+		return FormatCommitDate(c, dateLayout)
+	case ColumnMessage:
+		return c.Message
+	case ColumnFiles:
+		return strings.Join(c.Files, sep)
+	case ColumnAdditions:
+		return strconv.Itoa(c.Additions)
+	case ColumnDeletions:
+		return strconv.Itoa(c.Deletions)
+	case ColumnLines:
+		return strconv.Itoa(c.LinesChanged())
+	case ColumnBranch:
+		return c.Branch
+	case ColumnPR:
+		if c.PullRequest != nil {
+			return fmt.Sprintf("#%d %s", c.PullRequest.Number, c.PullRequest.Title)
+		}
+	case ColumnReview:
+		if c.PullRequest != nil {
+			return c.PullRequest.Review
+		}
+	case ColumnChecks:
+		if c.PullRequest != nil {
+			return c.PullRequest.Checks
+		}
+	case ColumnWorkItems:
+		return workItemsText(c.WorkItems, "AB#")
+	case ColumnIssues:
+		return workItemsText(c.Issues, "")
+	case ColumnType:
+		if cc, ok := ParseConventional(c.Message); ok {
+			if cc.Breaking {
+				return cc.Type + "!"
+			}
+			return cc.Type
+		}
+	case ColumnScope:
+		if cc, ok := ParseConventional(c.Message); ok {
+			return cc.Scope
+		}
+	case ColumnDCO:
+		return DCOStatus(c)
+	case ColumnUnmerged:
+		if c.Unmerged {
+			return "yes"
+		}
+	case ColumnPeriod:
+		return PeriodLabel(c.When, groupBy)
+	}
+	return ""
+}
+
+// workItemsText renders items as "AB#12 Title [State]; AB#15" using prefix before each ID.
+func workItemsText(items []models.WorkItem, prefix string) string {
+	parts := make([]string, len(items))
+	for i, item := range items {
+		part := prefix + item.ID
+		if item.Title != "" {
+			part += " " + item.Title
+		}
+		if item.State != "" {
+			part += " [" + item.State + "]"
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, "; ")
+}
+
+// columnNumber returns the integer value of a numeric column.
+func columnNumber(key string, c models.CommitInfo) int {
+	switch key {
+	case ColumnAdditions:
+		return c.Additions
+	case ColumnDeletions:
+		return c.Deletions
+	case ColumnLines:
+		return c.LinesChanged()
+	}
+	return 0
+}
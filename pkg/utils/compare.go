@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// AuthorComparison puts two authors' activity over the same range side by side.
+type AuthorComparison struct {
+	Left  AuthorStats
+	Right AuthorStats
+	// Files lists every file either author touched, shared files first.
+	Files []FileOverlap
+}
+
+// FileOverlap counts the commits each compared author made to one file.
+type FileOverlap struct {
+	Path  string
+	Left  int
+	Right int
+}
+
+func (f FileOverlap) Shared() bool {
+	return f.Left > 0 && f.Right > 0
+}
+
+// SharedFiles counts the files both authors touched.
+func (c AuthorComparison) SharedFiles() int {
+	n := 0
+	for _, f := range c.Files {
+		if f.Shared() {
+			n++
+		}
+	}
+	return n
+}
+
+// Overlap is the share of all touched files that both authors worked on.
+func (c AuthorComparison) Overlap() float64 {
+	if len(c.Files) == 0 {
+		return 0
+	}
+	return float64(c.SharedFiles()) / float64(len(c.Files))
+}
+
+// FindAuthor returns the stats whose name or email matches query exactly
+// (case-insensitive), or else the first one containing it.
+func FindAuthor(stats []AuthorStats, query string) (AuthorStats, bool) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	for _, s := range stats {
+		if strings.ToLower(s.Name) == query || strings.ToLower(s.Email) == query {
+			return s, true
+		}
+	}
+	for _, s := range stats {
+		if strings.Contains(strings.ToLower(s.Name), query) || strings.Contains(strings.ToLower(s.Email), query) {
+			return s, true
+		}
+	}
+	return AuthorStats{}, false
+}
+
+// CompareAuthors compares two of the authors returned by ComputeAuthorStats.
+func CompareAuthors(commits []models.CommitInfo, left, right AuthorStats) AuthorComparison {
+	cmp := AuthorComparison{Left: left, Right: right}
+	index := make(map[string]int)
+	for _, c := range commits {
+		_, email := canonicalIdentity(c)
+		isLeft := strings.EqualFold(email, left.Email)
+		isRight := strings.EqualFold(email, right.Email)
+		if !isLeft && !isRight {
+			continue
+		}
+		for _, file := range touchedFiles(c) {
+			i, ok := index[file]
+			if !ok {
+				i = len(cmp.Files)
+				index[file] = i
+				cmp.Files = append(cmp.Files, FileOverlap{Path: file})
+			}
+			if isLeft {
+				cmp.Files[i].Left++
+			}
+			if isRight {
+				cmp.Files[i].Right++
+			}
+		}
+	}
+	sort.SliceStable(cmp.Files, func(i, j int) bool {
+		a, b := cmp.Files[i], cmp.Files[j]
+		if a.Shared() != b.Shared() {
+			return a.Shared()
+		}
+		if a.Left+a.Right != b.Left+b.Right {
+			return a.Left+a.Right > b.Left+b.Right
+		}
+		return a.Path < b.Path
+	})
+	return cmp
+}
+
+func ComparisonPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_compare.xlsx")
+}
+
+// ExportComparison writes the side-by-side metrics and the files each author touched.
+func ExportComparison(cmp AuthorComparison, path string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "#FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %v", err)
+	}
+	labelStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return fmt.Errorf("failed to create label style: %v", err)
+	}
+
+	sheet := "Comparison"
+	f.SetSheetName("Sheet1", sheet)
+	l, r := cmp.Left, cmp.Right
+	rows := [][]any{
+		{"Metric", l.Name, r.Name},
+		{"Email", l.Email, r.Email},
+		{"Commits", l.Commits, r.Commits},
+		{"Files Touched", l.Files, r.Files},
+		{"Additions", l.Additions, r.Additions},
+		{"Deletions", l.Deletions, r.Deletions},
+		{"Lines Changed", l.LinesChanged(), r.LinesChanged()},
+		{"Avg Commit Size", math.Round(l.AvgCommitSize()*10) / 10, math.Round(r.AvgCommitSize()*10) / 10},
+		{"Commits per Day", math.Round(l.CommitsPerDay*100) / 100, math.Round(r.CommitsPerDay*100) / 100},
+		{"First Commit", dateOnly(l.First), dateOnly(r.First)},
+		{"Last Commit", dateOnly(l.Last), dateOnly(r.Last)},
+		{"Busiest Day", l.BusiestDay, r.BusiestDay},
+		{"Longest Streak (days)", l.LongestStreak.Days, r.LongestStreak.Days},
+		{},
+		{"Files Touched by Both", cmp.SharedFiles()},
+		{"File Overlap", fmt.Sprintf("%.0f%%", cmp.Overlap()*100)},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		f.SetSheetRow(sheet, cell, &row)
+	}
+	f.SetCellStyle(sheet, "A1", "C1", headerStyle)
+	f.SetCellStyle(sheet, "A2", fmt.Sprintf("A%d", len(rows)), labelStyle)
+	f.SetColWidth(sheet, "A", "A", 24)
+	f.SetColWidth(sheet, "B", "C", 28)
+
+	files := "Files"
+	if _, err := f.NewSheet(files); err != nil {
+		return fmt.Errorf("failed to create sheet: %v", err)
+	}
+	f.SetSheetRow(files, "A1", &[]any{"File", l.Name, r.Name, "Shared"})
+	f.SetCellStyle(files, "A1", "D1", headerStyle)
+	for i, fo := range cmp.Files {
+		shared := ""
+		if fo.Shared() {
+			shared = "yes"
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		f.SetSheetRow(files, cell, &[]any{fo.Path, fo.Left, fo.Right, shared})
+	}
+	f.SetColWidth(files, "A", "A", 60)
+	f.SetColWidth(files, "B", "D", 16)
+	f.SetPanes(files, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to save comparison: %v", err)
+	}
+	return nil
+}
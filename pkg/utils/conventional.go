@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// ConventionalCommit is a subject parsed per the Conventional Commits spec:
+// type(scope)!: description.
+type ConventionalCommit struct {
+	Type        string // lowercased, e.g. "feat"
+	Scope       string
+	Breaking    bool
+	Description string
+}
+
+// OtherType groups commits whose subject is not a conventional commit.
+const OtherType = "other"
+
+var conventionalPattern = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?:\s+(.+)$`)
+
+// ParseConventional parses subject, reporting false when it does not follow the spec.
+func ParseConventional(subject string) (ConventionalCommit, bool) {
+	m := conventionalPattern.FindStringSubmatch(strings.TrimSpace(subject))
+	if m == nil {
+		return ConventionalCommit{}, false
+	}
+	return ConventionalCommit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       strings.TrimSpace(m[2]),
+		Breaking:    m[3] == "!",
+		Description: m[4],
+	}, true
+}
+
+func isConventional(c models.CommitInfo) bool {
+	_, ok := ParseConventional(c.Message)
+	return ok
+}
+
+type TypeCount struct {
+	Type     string
+	Commits  int
+	Breaking int
+}
+
+// CountByType tallies commits per conventional type, most frequent first, with
+// non-conventional subjects counted under OtherType.
+func CountByType(commits []models.CommitInfo) []TypeCount {
+	index := make(map[string]int)
+	var counts []TypeCount
+	for _, c := range commits {
+		cc, ok := ParseConventional(c.Message)
+		if !ok {
+			cc.Type = OtherType
+		}
+		i, seen := index[cc.Type]
+		if !seen {
+			i = len(counts)
+			index[cc.Type] = i
+			counts = append(counts, TypeCount{Type: cc.Type})
+		}
+		counts[i].Commits++
+		if cc.Breaking {
+			counts[i].Breaking++
+		}
+	}
+
+	sort.SliceStable(counts, func(i, j int) bool {
+		if counts[i].Commits != counts[j].Commits {
+			return counts[i].Commits > counts[j].Commits
+		}
+		return counts[i].Type < counts[j].Type
+	})
+	return counts
+}
@@ -2,41 +2,75 @@ package utils
 
 import (
 	"encoding/csv"
+	"io"
 	"os"
 
+	"github.com/leeozaka/gommits/internal/config"
 	"github.com/leeozaka/gommits/internal/models"
 )
 
-func ExportToCSV(commits []models.CommitInfo, csvPath string) error {
+// ExportToCSV writes one row per changed file when the files column is selected,
+// otherwise one row per commit.
+func ExportToCSV(commits []models.CommitInfo, csvPath string, opts config.ExportConfig) error {
+	if _, err := ResolveColumns(opts.Columns); err != nil {
+		return err
+	}
+
 	file, err := os.Create(csvPath)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	writer := csv.NewWriter(file)
+	return WriteCSV(file, commits, opts)
+}
+
+// WriteCSV writes the same rows as ExportToCSV to w.
+func WriteCSV(w io.Writer, commits []models.CommitInfo, opts config.ExportConfig) error {
+	columns, err := exportColumns(opts, commits)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
 	defer writer.Flush()
 
-	header := []string{"commit_hash", "author_name", "author_email", "commit_date", "commit_message", "file_path"}
+	header := make([]string, len(columns))
+	filesIdx := -1
+	for i, key := range columns {
+		header[i] = csvHeaders[key]
+		if key == ColumnFiles {
+			filesIdx = i
+		}
+	}
 	if err := writer.Write(header); err != nil {
 		return err
 	}
 
+	dateLayout := DateLayout(opts.DateFormat)
+
 	for _, c := range commits {
-		if len(c.Files) > 0 {
-			for _, f := range c.Files {
-				row := []string{c.Hash, c.Author, c.Email, c.Date, c.Message, f}
-				if err := writer.Write(row); err != nil {
-					return err
-				}
+		row := make([]string, len(columns))
+		for i, key := range columns {
+			if i != filesIdx {
+				row[i] = columnText(key, c, "", dateLayout, opts.GroupBy)
 			}
-		} else {
-			row := []string{c.Hash, c.Author, c.Email, c.Date, c.Message, ""}
+		}
+
+		if filesIdx == -1 || len(c.Files) == 0 {
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, f := range c.Files {
+			row[filesIdx] = f
 			if err := writer.Write(row); err != nil {
 				return err
 			}
 		}
 	}
 
+	writer.Flush()
 	return writer.Error()
 }
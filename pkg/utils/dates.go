@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// Named date formats accepted in config.ExportConfig.DateFormat. Any other value is
+// used as a Go time layout.
+const (
+	DateFormatISO8601  = "iso8601"
+	DateFormatDate     = "date"
+	DateFormatDateTime = "datetime"
+	DateFormatRFC2822  = "rfc2822"
+	// DateFormatGit keeps git's own %ad string untouched.
+	DateFormatGit = "git"
+)
+
+var namedDateLayouts = map[string]string{
+	DateFormatISO8601:  "2006-01-02T15:04:05Z07:00",
+	DateFormatDate:     "2006-01-02",
+	DateFormatDateTime: "2006-01-02 15:04:05",
+	DateFormatRFC2822:  time.RFC1123Z,
+	DateFormatGit:      DateFormatGit,
+}
+
+// DateLayout resolves a configured date format to a Go layout, defaulting to ISO 8601.
+func DateLayout(format string) string {
+	if format == "" {
+		format = DateFormatISO8601
+	}
+	if layout, ok := namedDateLayouts[strings.ToLower(format)]; ok {
+		return layout
+	}
+	return format
+}
+
+// FormatCommitDate renders c's author date with layout, falling back to git's raw
+// string when the layout is DateFormatGit or the date could not be parsed.
+func FormatCommitDate(c models.CommitInfo, layout string) string {
+	if layout == DateFormatGit || c.When.IsZero() {
+		return c.Date
+	}
+	return c.When.Format(layout)
+}
+
+// gitDateLayout matches git's default %ad output.
+const gitDateLayout = "Mon Jan 2 15:04:05 2006 -0700"
+
+// ConvertTimezone returns a copy of commits with author dates moved into loc, keeping
+// Date in git's layout so displays stay consistent. A nil loc returns commits unchanged.
+func ConvertTimezone(commits []models.CommitInfo, loc *time.Location) []models.CommitInfo {
+	if loc == nil {
+		return commits
+	}
+	converted := make([]models.CommitInfo, len(commits))
+	copy(converted, commits)
+	for i, c := range converted {
+		if c.When.IsZero() {
+			continue
+		}
+		converted[i].When = c.When.In(loc)
+		converted[i].Date = converted[i].When.Format(gitDateLayout)
+	}
+	return converted
+}
+
+// goToExcelDateTokens maps Go layout tokens to Excel number-format tokens, longest first.
+// Zone tokens map to nothing since Excel datetimes carry no offset.
+var goToExcelDateTokens = []struct{ goTok, excelTok string }{
+	{"2006", "yyyy"}, {"January", "mmmm"}, {"Monday", "dddd"},
+	{"Z07:00", ""}, {"-07:00", ""}, {"-0700", ""}, {"MST", ""},
+	{"Jan", "mmm"}, {"Mon", "ddd"}, {"01", "mm"}, {"02", "dd"},
+	{"15", "hh"}, {"03", "hh"}, {"04", "mm"}, {"05", "ss"},
+	{"06", "yy"}, {"PM", "AM/PM"}, {"_2", "d"}, {"1", "m"}, {"2", "d"},
+	{"3", "h"}, {"4", "m"}, {"5", "s"},
+}
+
+// excelDateNumFmt converts a Go layout to an Excel number format, quoting literal text.
+func excelDateNumFmt(layout string) string {
+	if layout == DateFormatGit {
+		return excelDateFormat
+	}
+
+	var b strings.Builder
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			b.WriteString(`"` + literal.String() + `"`)
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(layout); {
+		matched := false
+		for _, t := range goToExcelDateTokens {
+			if strings.HasPrefix(layout[i:], t.goTok) {
+				flush()
+				b.WriteString(t.excelTok)
+				i += len(t.goTok)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		switch c := layout[i]; c {
+		case '-', '/', ':', ' ', '.', ',':
+			flush()
+			b.WriteByte(c)
+		default:
+			literal.WriteByte(c)
+		}
+		i++
+	}
+	flush()
+
+	return strings.TrimRight(b.String(), " ")
+}
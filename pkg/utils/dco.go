@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// DCO sign-off states reported by DCOStatus.
+const (
+	DCOSigned   = "signed"
+	DCOMismatch = "mismatch" // signed off, but not by the author
+	DCOMissing  = "missing"
+)
+
+// DCOStatus checks c for a Signed-off-by trailer from its author, matched by email
+// or, when the trailer has no address, by name.
+func DCOStatus(c models.CommitInfo) string {
+	if len(c.SignedOff) == 0 {
+		return DCOMissing
+	}
+	for _, s := range c.SignedOff {
+		name, email := parseSignOff(s)
+		if email != "" && strings.EqualFold(email, c.Email) {
+			return DCOSigned
+		}
+		if email == "" && strings.EqualFold(name, c.Author) {
+			return DCOSigned
+		}
+	}
+	return DCOMismatch
+}
+
+func parseSignOff(s string) (name, email string) {
+	if addr, err := mail.ParseAddress(s); err == nil {
+		return addr.Name, addr.Address
+	}
+	return strings.TrimSpace(s), ""
+}
+
+// UsesDCO reports whether any commit is signed off, which is taken to mean the
+// repository follows the DCO and unsigned commits are worth flagging.
+func UsesDCO(commits []models.CommitInfo) bool {
+	return slices.ContainsFunc(commits, hasSignOff)
+}
+
+func hasSignOff(c models.CommitInfo) bool {
+	return len(c.SignedOff) > 0
+}
+
+// CountSignedOff returns how many commits carry their author's sign-off.
+func CountSignedOff(commits []models.CommitInfo) int {
+	n := 0
+	for _, c := range commits {
+		if DCOStatus(c) == DCOSigned {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// DeltaSuffix is appended to the repository name in the file names of a delta
+// export, so it never overwrites the export it was compared with.
+const DeltaSuffix = "_delta"
+
+// ExportedHashes reads the commit hashes recorded in a previous JSON (.json) or Excel
+// (.xlsx) export. password opens an encrypted workbook.
+func ExportedHashes(path, password string) (map[string]bool, error) {
+	hashes := make(map[string]bool)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read previous export: %v", err)
+		}
+		var doc jsonExport
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse previous export: %v", err)
+		}
+		for _, c := range doc.Commits {
+			hashes[c.Hash] = true
+		}
+	case ".xlsx":
+		commits, err := readWorkbookCommits(path, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read previous export: %v", err)
+		}
+		for _, c := range commits {
+			if c.Hash != "" {
+				hashes[c.Hash] = true
+			}
+		}
+		// Without a hash column every commit would look new.
+		if len(hashes) == 0 {
+			return nil, fmt.Errorf("no commit hashes in previous export %s: it needs a hash column", filepath.Base(path))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported previous export %s: use a .json or .xlsx file", filepath.Base(path))
+	}
+	return hashes, nil
+}
+
+// NewCommits keeps the commits that are not in previous.
+func NewCommits(commits []models.CommitInfo, previous map[string]bool) []models.CommitInfo {
+	var fresh []models.CommitInfo
+	for _, c := range commits {
+		if !previous[c.Hash] {
+			fresh = append(fresh, c)
+		}
+	}
+	return fresh
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+func TestExportedHashesFromWorkbook(t *testing.T) {
+	when := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+	commits := []models.CommitInfo{
+		{Hash: "abc1234", Author: "Ana", Email: "ana@example.com", When: when, Message: "Add parser"},
+		{Hash: "def4567", Author: "Bo", Email: "bo@example.com", When: when, Message: "Fix parser"},
+	}
+	dir := t.TempDir()
+
+	cfg := config.Default()
+	cfg.Export.Columns = []string{ColumnHash, ColumnAuthor, ColumnMessage}
+	withHash := filepath.Join(dir, "with_hash.xlsx")
+	if err := saveExcel(commits, withHash, dir, "repo", cfg, nil); err != nil {
+		t.Fatal(err)
+	}
+	hashes, err := ExportedHashes(withHash, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hashes) != 2 || !hashes["abc1234"] || !hashes["def4567"] {
+		t.Errorf("ExportedHashes = %v, want abc1234 and def4567", hashes)
+	}
+
+	cfg.Export.Columns = []string{ColumnAuthor, ColumnMessage}
+	withoutHash := filepath.Join(dir, "without_hash.xlsx")
+	if err := saveExcel(commits, withoutHash, dir, "repo", cfg, nil); err != nil {
+		t.Fatal(err)
+	}
+	if hashes, err := ExportedHashes(withoutHash, ""); err == nil {
+		t.Errorf("ExportedHashes without a hash column = %v, want an error", hashes)
+	}
+}
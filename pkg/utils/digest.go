@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// FormatDigest writes the Markdown or HTML digest (see export.digestFormat).
+const FormatDigest = "digest"
+
+// DigestSource is the commits gathered from one repository for a digest.
+type DigestSource struct {
+	Repository string
+	Commits    []models.CommitInfo
+}
+
+// Digest summarizes a period of work across repositories, grouped by author and
+// then by repository, for team newsletters.
+type Digest struct {
+	From    time.Time
+	To      time.Time
+	Authors []DigestAuthor
+	Repos   int
+	Totals  CommitTotals
+}
+
+// DigestAuthor is one author's commits in the digest, per repository.
+type DigestAuthor struct {
+	Name      string
+	Commits   int
+	Additions int
+	Deletions int
+	Repos     []DigestRepo
+}
+
+// DigestRepo is one repository's entries for an author, oldest first.
+type DigestRepo struct {
+	Repository string
+	Entries    []ChangeEntry
+}
+
+// BuildDigest groups the sources by author (through .mailmap), busiest author first.
+func BuildDigest(sources []DigestSource, from, to time.Time) Digest {
+	d := Digest{From: from, To: to}
+	index := make(map[string]int)
+	var all []models.CommitInfo
+	for _, src := range sources {
+		if len(src.Commits) > 0 {
+			d.Repos++
+		}
+		all = append(all, src.Commits...)
+
+		sorted := make([]models.CommitInfo, len(src.Commits))
+		copy(sorted, src.Commits)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].When.Before(sorted[j].When) })
+		for _, c := range sorted {
+			name, email := canonicalIdentity(c)
+			key := strings.ToLower(email)
+			i, ok := index[key]
+			if !ok {
+				i = len(d.Authors)
+				index[key] = i
+				d.Authors = append(d.Authors, DigestAuthor{Name: name})
+			}
+			a := &d.Authors[i]
+			a.Commits++
+			a.Additions += c.Additions
+			a.Deletions += c.Deletions
+			if n := len(a.Repos); n == 0 || a.Repos[n-1].Repository != src.Repository {
+				a.Repos = append(a.Repos, DigestRepo{Repository: src.Repository})
+			}
+			short := c.Hash
+			if len(short) > 7 {
+				short = short[:7]
+			}
+			r := &a.Repos[len(a.Repos)-1]
+			r.Entries = append(r.Entries, ChangeEntry{Description: c.Message, Hash: short, URL: c.URL, Author: name})
+		}
+	}
+
+	sort.SliceStable(d.Authors, func(i, j int) bool {
+		if d.Authors[i].Commits != d.Authors[j].Commits {
+			return d.Authors[i].Commits > d.Authors[j].Commits
+		}
+		return d.Authors[i].Name < d.Authors[j].Name
+	})
+	d.Totals = SummarizeCommits(all)
+	return d
+}
+
+// DigestPath names the file after the last day covered, e.g. api_digest_2024-06-07.md.
+func DigestPath(dir, name string, to time.Time, ext string) string {
+	return filepath.Join(dir, name+"_digest_"+to.Format(time.DateOnly)+"."+ext)
+}
+
+func (d Digest) Title() string {
+	return fmt.Sprintf("Digest: %s – %s", d.From.Format(time.DateOnly), d.To.Format(time.DateOnly))
+}
+
+func (d Digest) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", d.Title())
+	fmt.Fprintf(&b, "_%d commits · %d authors · %d repositories · +%d / -%d lines_\n",
+		d.Totals.Commits, len(d.Authors), d.Repos, d.Totals.Additions, d.Totals.Deletions)
+
+	for _, a := range d.Authors {
+		fmt.Fprintf(&b, "\n## %s\n\n_%d commits · +%d / -%d lines_\n", a.Name, a.Commits, a.Additions, a.Deletions)
+		for _, r := range a.Repos {
+			fmt.Fprintf(&b, "\n### %s\n\n", r.Repository)
+			for _, e := range r.Entries {
+				b.WriteString(markdownEntry(e) + "\n")
+			}
+		}
+	}
+	if len(d.Authors) == 0 {
+		b.WriteString("\nNo commits this period.\n")
+	}
+	return b.String()
+}
+
+var digestHTML = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 50rem; margin: 2rem auto; padding: 0 1rem; line-height: 1.5; }
+.meta { color: #666; }
+code { background: #f3f3f3; padding: 0 .2rem; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">{{.Totals.Commits}} commits · {{len .Authors}} authors · {{.Repos}} repositories · +{{.Totals.Additions}} / -{{.Totals.Deletions}} lines</p>
+{{range .Authors}}
+<h2>{{.Name}}</h2>
+<p class="meta">{{.Commits}} commits · +{{.Additions}} / -{{.Deletions}} lines</p>
+{{- range .Repos}}
+<h3>{{.Repository}}</h3>
+<ul>
+{{- range .Entries}}
+<li>{{.Description}} ({{if .URL}}<a href="{{.URL}}"><code>{{.Hash}}</code></a>{{else}}<code>{{.Hash}}</code>{{end}})</li>
+{{- end}}
+</ul>
+{{- end}}
+{{else}}
+<p>No commits this period.</p>
+{{end -}}
+</body>
+</html>
+`))
+
+func (d Digest) HTML() (string, error) {
+	var b strings.Builder
+	if err := digestHTML.Execute(&b, d); err != nil {
+		return "", fmt.Errorf("failed to render digest: %v", err)
+	}
+	return b.String(), nil
+}
+
+// RenderDigest renders d as "md" or "html".
+func RenderDigest(d Digest, format string) (string, error) {
+	switch format {
+	case "", "md":
+		return d.Markdown(), nil
+	case "html":
+		return d.HTML()
+	}
+	return "", fmt.Errorf("unknown digest format %q: use md or html", format)
+}
+
+// exportDigest covers the gathered commits of one repository, from the oldest to now.
+func exportDigest(req ExportRequest) ([]string, error) {
+	to := time.Now()
+	from := to
+	for _, c := range req.Commits {
+		if !c.When.IsZero() && c.When.Before(from) {
+			from = c.When
+		}
+	}
+	d := BuildDigest([]DigestSource{{Repository: req.RepoName, Commits: req.Commits}}, from, to)
+
+	format := req.Config.Export.DigestFormat
+	content, err := RenderDigest(d, format)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = "md"
+	}
+	path := DigestPath(req.Dir, req.RepoName, to, format)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write digest: %v", err)
+	}
+	return []string{path}, nil
+}
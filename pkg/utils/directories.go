@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// DefaultDirectoryDepth is how many path segments identify a component.
+const DefaultDirectoryDepth = 2
+
+// DirectoryActivity is the work done in one directory, by one author or by everyone
+// (Author is AllAuthors).
+type DirectoryActivity struct {
+	Directory string
+	Author    string
+	Email     string
+	Commits   int
+	Additions int
+	Deletions int
+}
+
+func (d DirectoryActivity) LinesChanged() int {
+	return d.Additions + d.Deletions
+}
+
+// DirectoryBreakdown groups changed files into directories cut to depth segments and
+// returns the totals per directory followed by each author's share, both by commits.
+// A commit counts once per directory it touches.
+func DirectoryBreakdown(commits []models.CommitInfo, depth int) []DirectoryActivity {
+	if depth <= 0 {
+		depth = DefaultDirectoryDepth
+	}
+
+	totals := make(map[string]*DirectoryActivity)
+	perAuthor := make(map[[2]string]*DirectoryActivity)
+	for _, c := range commits {
+		name, email := canonicalIdentity(c)
+		seen := make(map[string]bool)
+		for _, fc := range fileChanges(c) {
+			dir := directoryOf(fc.Path, depth)
+			key := [2]string{strings.ToLower(email), dir}
+
+			t := totals[dir]
+			if t == nil {
+				t = &DirectoryActivity{Directory: dir, Author: AllAuthors}
+				totals[dir] = t
+			}
+			a := perAuthor[key]
+			if a == nil {
+				a = &DirectoryActivity{Directory: dir, Author: name, Email: email}
+				perAuthor[key] = a
+			}
+			for _, d := range []*DirectoryActivity{t, a} {
+				d.Additions += fc.Additions
+				d.Deletions += fc.Deletions
+				if !seen[dir] {
+					d.Commits++
+				}
+			}
+			seen[dir] = true
+		}
+	}
+
+	result := make([]DirectoryActivity, 0, len(totals)+len(perAuthor))
+	for _, t := range totals {
+		result = append(result, *t)
+	}
+	sortDirectoryActivity(result)
+	authors := make([]DirectoryActivity, 0, len(perAuthor))
+	for _, a := range perAuthor {
+		authors = append(authors, *a)
+	}
+	sortDirectoryActivity(authors)
+	return append(result, authors...)
+}
+
+// sortDirectoryActivity orders by author, then most commits and lines first.
+func sortDirectoryActivity(list []DirectoryActivity) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Author != list[j].Author {
+			return list[i].Author < list[j].Author
+		}
+		if list[i].Commits != list[j].Commits {
+			return list[i].Commits > list[j].Commits
+		}
+		if list[i].LinesChanged() != list[j].LinesChanged() {
+			return list[i].LinesChanged() > list[j].LinesChanged()
+		}
+		return list[i].Directory < list[j].Directory
+	})
+}
+
+// directoryOf returns the directory of file cut to depth segments, or RootDirectory.
+func directoryOf(file string, depth int) string {
+	dir := path.Dir(file)
+	if dir == "." || dir == "/" {
+		return RootDirectory
+	}
+	segments := strings.Split(dir, "/")
+	if len(segments) > depth {
+		segments = segments[:depth]
+	}
+	return strings.Join(segments, "/")
+}
@@ -2,45 +2,174 @@ package utils
 
 import (
 	"fmt"
-	"path/filepath"
+	"io"
+	"math"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/git"
 	"github.com/leeozaka/gommits/internal/models"
 	"github.com/xuri/excelize/v2"
 )
 
-func ExportToExcel(commits []models.CommitInfo, repoPath, repoName string) error {
-	f := excelize.NewFile()
+// excelDateFormat is the number format used when no layout can be converted.
+const excelDateFormat = "yyyy-mm-dd hh:mm:ss"
+
+// excelWallTime re-anchors t's wall clock in UTC. Excel datetimes carry no zone and
+// excelize converts through UTC, so without this the author's local time would shift.
+func excelWallTime(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+const (
+	minColumnWidth = 8
+	maxColumnWidth = 60
+	// columnPadding leaves room for the table filter button and cell margins.
+	columnPadding = 3
+)
+
+// columnWidths tracks the widest line seen per column so widths can follow content.
+type columnWidths []int
+
+func newColumnWidths(headers []string) columnWidths {
+	w := make(columnWidths, len(headers))
+	w.observe(headers...)
+	return w
+}
+
+func (w columnWidths) observe(values ...string) {
+	for i, v := range values {
+		if i >= len(w) {
+			break
+		}
+		for line := range strings.SplitSeq(v, "\n") {
+			if n := utf8.RuneCountInString(line); n > w[i] {
+				w[i] = n
+			}
+		}
+	}
+}
+
+// apply sets each column's width to its content width, clamped to [minColumnWidth, maxColumnWidth].
+func (w columnWidths) apply(f *excelize.File, sheet string) error {
+	for i, width := range w {
+		width += columnPadding
+		width = max(minColumnWidth, min(width, maxColumnWidth))
+		col, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return err
+		}
+		if err := f.SetColWidth(sheet, col, col, float64(width)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isLargeCommit reports whether c exceeds either configured threshold.
+func isLargeCommit(c models.CommitInfo, opts config.ExcelConfig) bool {
+	if opts.LargeCommitFiles > 0 && len(c.Files) > opts.LargeCommitFiles {
+		return true
+	}
+	return opts.LargeCommitLines > 0 && c.LinesChanged() > opts.LargeCommitLines
+}
+
+// ExportToExcel saves the commits workbook next to the repository. progress, when
+// not nil, is told each commit row written.
+func ExportToExcel(commits []models.CommitInfo, repoPath, repoName string, cfg config.Config, progress func(written, total int)) error {
+	return saveExcel(commits, ExcelPath(repoPath, repoName), repoPath, repoName, cfg, progress)
+}
+
+// saveExcel saves the commits workbook of the repository at repoPath to path.
+func saveExcel(commits []models.CommitInfo, path, repoPath, repoName string, cfg config.Config, progress func(written, total int)) error {
+	opts := cfg.Excel
 
+	if opts.Append {
+		merged, err := mergeWithExistingWorkbook(commits, path, opts.Password)
+		if err != nil {
+			return err
+		}
+		commits = merged
+	}
+
+	f, err := buildCommitsWorkbook(commits, repoPath, repoName, cfg, progress)
+	if err != nil {
+		return err
+	}
 	defer func() {
 		if err := f.Close(); err != nil {
 			fmt.Println(err)
 		}
 	}()
 
-	fileName := fmt.Sprintf("%s_commits.xlsx", repoName)
+	if err := f.SaveAs(path, excelize.Options{Password: opts.Password}); err != nil {
+		return fmt.Errorf("failed to save Excel file: %v", err)
+	}
+	return nil
+}
+
+// WriteExcelTo streams the commits workbook to w instead of saving it next to the
+// repository. Append mode does not apply since there is no previous file to merge.
+func WriteExcelTo(w io.Writer, commits []models.CommitInfo, repoPath, repoName string, cfg config.Config) error {
+	f, err := buildCommitsWorkbook(commits, repoPath, repoName, cfg, nil)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := f.Write(w, excelize.Options{Password: cfg.Excel.Password}); err != nil {
+		return fmt.Errorf("failed to write Excel file: %v", err)
+	}
+	return nil
+}
+
+func buildCommitsWorkbook(commits []models.CommitInfo, repoPath, repoName string, cfg config.Config, progress func(written, total int)) (_ *excelize.File, err error) {
+	opts := cfg.Excel
+
+	columns, err := exportColumns(cfg.Export, commits)
+	if err != nil {
+		return nil, err
+	}
+	sheets, err := resolveSheets(opts.Sheets)
+	if err != nil {
+		return nil, err
+	}
+
+	f := excelize.NewFile()
+	defer func() {
+		if err != nil {
+			f.Close()
+		}
+	}()
 
 	sheetName := "Commits"
 	index, err := f.NewSheet(sheetName)
 	if err != nil {
-		return fmt.Errorf("failed to create sheet: %v", err)
+		return nil, fmt.Errorf("failed to create sheet: %v", err)
 	}
 
 	f.SetActiveSheet(index)
 
 	f.DeleteSheet("Sheet1")
 
-	headers := []string{"Commit Hash", "Author Name", "Author Email", "Commit Date", "Commit Message", "Files Changed"}
+	labels := labelsFor(opts.Locale)
+
+	theme := opts.Theme
 
 	headerStyle, err := f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{
-			Bold:  true,
-			Size:  12,
-			Color: "#FFFFFF",
+			Bold:   true,
+			Size:   theme.HeaderFontSize,
+			Color:  theme.HeaderFontColor,
+			Family: theme.FontFamily,
 		},
 		Fill: excelize.Fill{
 			Type:    "pattern",
-			Color:   []string{"#4472C4"},
+			Color:   []string{theme.HeaderFill},
 			Pattern: 1,
 		},
 		Border: []excelize.Border{
@@ -55,10 +184,11 @@ func ExportToExcel(commits []models.CommitInfo, repoPath, repoName string) error
 		},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create header style: %v", err)
+		return nil, fmt.Errorf("failed to create header style: %v", err)
 	}
 
 	dataStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Family: theme.FontFamily},
 		Border: []excelize.Border{
 			{Type: "left", Color: "#000000", Style: 1},
 			{Type: "top", Color: "#000000", Style: 1},
@@ -71,83 +201,232 @@ func ExportToExcel(commits []models.CommitInfo, repoPath, repoName string) error
 		},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create data style: %v", err)
+		return nil, fmt.Errorf("failed to create data style: %v", err)
 	}
 
-	for i, header := range headers {
-		cell := string(rune('A'+i)) + "1"
-		f.SetCellValue(sheetName, cell, header)
-		f.SetCellStyle(sheetName, cell, cell, headerStyle)
+	dateLayout := DateLayout(cfg.Export.DateFormat)
+	dateFmt := excelDateNumFmt(dateLayout)
+	dateStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Family: theme.FontFamily},
+		Border: []excelize.Border{
+			{Type: "left", Color: "#000000", Style: 1},
+			{Type: "top", Color: "#000000", Style: 1},
+			{Type: "bottom", Color: "#000000", Style: 1},
+			{Type: "right", Color: "#000000", Style: 1},
+		},
+		Alignment: &excelize.Alignment{
+			Vertical: "top",
+		},
+		CustomNumFmt: &dateFmt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create date style: %v", err)
+	}
+
+	largeFill := excelize.Fill{Type: "pattern", Color: []string{"#FFC7CE"}, Pattern: 1}
+	largeFont := &excelize.Font{Color: "#9C0006", Family: theme.FontFamily}
+
+	largeStyle, err := f.NewStyle(&excelize.Style{
+		Font: largeFont,
+		Fill: largeFill,
+		Border: []excelize.Border{
+			{Type: "left", Color: "#000000", Style: 1},
+			{Type: "top", Color: "#000000", Style: 1},
+			{Type: "bottom", Color: "#000000", Style: 1},
+			{Type: "right", Color: "#000000", Style: 1},
+		},
+		Alignment: &excelize.Alignment{
+			Vertical: "top",
+			WrapText: true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create large commit style: %v", err)
+	}
+
+	largeDateStyle, err := f.NewStyle(&excelize.Style{
+		Font: largeFont,
+		Fill: largeFill,
+		Border: []excelize.Border{
+			{Type: "left", Color: "#000000", Style: 1},
+			{Type: "top", Color: "#000000", Style: 1},
+			{Type: "bottom", Color: "#000000", Style: 1},
+			{Type: "right", Color: "#000000", Style: 1},
+		},
+		Alignment: &excelize.Alignment{
+			Vertical: "top",
+		},
+		CustomNumFmt: &dateFmt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create large commit date style: %v", err)
 	}
 
-	columnWidths := map[string]float64{
-		"A": 15, // Commit Hash
-		"B": 20, // Author Name
-		"C": 25, // Author Email
-		"D": 18, // Commit Date
-		"E": 40, // Commit Message
-		"F": 35, // Files Changed
+	lastCol, err := excelize.ColumnNumberToName(len(columns))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve columns: %v", err)
 	}
 
-	for col, width := range columnWidths {
-		f.SetColWidth(sheetName, col, col, width)
+	headers := make([]string, len(columns))
+	for i, key := range columns {
+		headers[i] = labels.Headers[key]
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheetName, cell, headers[i])
+		f.SetCellStyle(sheetName, cell, cell, headerStyle)
 	}
 
+	widths := newColumnWidths(headers)
+
 	row := 2
 	for _, commit := range commits {
-		filesStr := ""
-		if len(commit.Files) > 0 {
-			for i, file := range commit.Files {
-				if i > 0 {
-					filesStr += "\n"
-				}
-				filesStr += file
-			}
-		} else {
-			filesStr = "No files changed"
+		rowStyle, rowDateStyle := dataStyle, dateStyle
+		if isLargeCommit(commit, opts) {
+			rowStyle, rowDateStyle = largeStyle, largeDateStyle
 		}
 
-		f.SetCellValue(sheetName, "A"+strconv.Itoa(row), commit.Hash)
-		f.SetCellValue(sheetName, "B"+strconv.Itoa(row), commit.Author)
-		f.SetCellValue(sheetName, "C"+strconv.Itoa(row), commit.Email)
-		f.SetCellValue(sheetName, "D"+strconv.Itoa(row), commit.Date)
-		f.SetCellValue(sheetName, "E"+strconv.Itoa(row), commit.Message)
-		f.SetCellValue(sheetName, "F"+strconv.Itoa(row), filesStr)
-
-		for col := 'A'; col <= 'F'; col++ {
-			cell := string(col) + strconv.Itoa(row)
-			f.SetCellStyle(sheetName, cell, cell, dataStyle)
+		values := make([]string, len(columns))
+		for i, key := range columns {
+			cell, _ := excelize.CoordinatesToCellName(i+1, row)
+			style := rowStyle
+
+			switch {
+			case key == ColumnDate && !commit.When.IsZero() && dateLayout != DateFormatGit:
+				values[i] = FormatCommitDate(commit, dateLayout)
+				f.SetCellValue(sheetName, cell, excelWallTime(commit.When))
+				style = rowDateStyle
+			case key == ColumnFiles && len(commit.Files) == 0:
+				values[i] = labels.NoFiles
+				f.SetCellValue(sheetName, cell, values[i])
+			case isNumericColumn(key):
+				values[i] = columnText(key, commit, "\n", dateLayout, cfg.Export.GroupBy)
+				f.SetCellValue(sheetName, cell, columnNumber(key, commit))
+			default:
+				values[i] = columnText(key, commit, "\n", dateLayout, cfg.Export.GroupBy)
+				f.SetCellValue(sheetName, cell, values[i])
+			}
+			f.SetCellStyle(sheetName, cell, cell, style)
 		}
+		widths.observe(values...)
 
+		if progress != nil {
+			progress(row-1, len(commits))
+		}
 		row++
 	}
 
+	if err := widths.apply(f, sheetName); err != nil {
+		return nil, fmt.Errorf("failed to set column widths: %v", err)
+	}
+
+	if err := f.SetPanes(sheetName, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return nil, fmt.Errorf("failed to freeze header row: %v", err)
+	}
+
+	// The table carries its own autofilter; a sheet-level one overlapping it would
+	// corrupt the workbook, so only add one when there is no table.
+	if len(commits) == 0 {
+		if err := f.AutoFilter(sheetName, "A1:"+lastCol+"1", nil); err != nil {
+			return nil, fmt.Errorf("failed to add autofilter: %v", err)
+		}
+	}
+
 	if len(commits) > 0 {
-		tableRange := fmt.Sprintf("A1:F%d", len(commits)+1)
+		tableRange := fmt.Sprintf("A1:%s%d", lastCol, len(commits)+1)
 		err = f.AddTable(sheetName, &excelize.Table{
 			Range:             tableRange,
 			Name:              "CommitsTable",
-			StyleName:         "TableStyleMedium2",
+			StyleName:         theme.TableStyle,
 			ShowFirstColumn:   false,
 			ShowLastColumn:    false,
 			ShowRowStripes:    &[]bool{true}[0],
 			ShowColumnStripes: false,
 		})
 		if err != nil {
-			return fmt.Errorf("failed to create table: %v", err)
+			return nil, fmt.Errorf("failed to create table: %v", err)
+		}
+	}
+
+	totals := SummarizeCommits(commits)
+
+	totalsStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Family: theme.FontFamily},
+		Border: []excelize.Border{
+			{Type: "top", Color: "#000000", Style: 2},
+		},
+		Alignment: &excelize.Alignment{Vertical: "top"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create totals style: %v", err)
+	}
+
+	totalsRow := len(commits) + 2
+	labelCol, countCol := totalsLabelColumns(columns)
+	for i, key := range columns {
+		cell, _ := excelize.CoordinatesToCellName(i+1, totalsRow)
+		switch {
+		case i == labelCol && countCol < 0:
+			f.SetCellValue(sheetName, cell, labels.Totals+": "+fmt.Sprintf(labels.CommitsFmt, totals.Commits))
+		case i == labelCol:
+			f.SetCellValue(sheetName, cell, labels.Totals)
+		case i == countCol:
+			f.SetCellValue(sheetName, cell, fmt.Sprintf(labels.CommitsFmt, totals.Commits))
+		case key == ColumnMessage:
+			f.SetCellValue(sheetName, cell, fmt.Sprintf(labels.LinesChangedFmt, totals.LinesChanged(), totals.Additions, totals.Deletions))
+		case key == ColumnFiles:
+			f.SetCellValue(sheetName, cell, fmt.Sprintf(labels.DistinctFilesFmt, totals.DistinctFiles))
+		case key == ColumnAdditions:
+			f.SetCellValue(sheetName, cell, totals.Additions)
+		case key == ColumnDeletions:
+			f.SetCellValue(sheetName, cell, totals.Deletions)
+		case key == ColumnLines:
+			f.SetCellValue(sheetName, cell, totals.LinesChanged())
 		}
 	}
+	f.SetCellStyle(sheetName, "A"+strconv.Itoa(totalsRow), lastCol+strconv.Itoa(totalsRow), totalsStyle)
 
 	summarySheet := "Summary"
 	summaryIndex, err := f.NewSheet(summarySheet)
 	if err == nil {
-		f.SetCellValue(summarySheet, "A1", "Repository Summary")
-		f.SetCellValue(summarySheet, "A2", "Repository Name:")
-		f.SetCellValue(summarySheet, "B2", repoName)
-		f.SetCellValue(summarySheet, "A3", "Total Commits:")
-		f.SetCellValue(summarySheet, "B3", len(commits))
-		f.SetCellValue(summarySheet, "A4", "Repository Path:")
-		f.SetCellValue(summarySheet, "B4", repoPath)
+		f.SetCellValue(summarySheet, "A1", labels.SummaryTitle)
+		metrics := [][2]any{
+			{labels.RepoName, repoName},
+			{labels.TotalCommits, len(commits)},
+			{labels.RepoPath, repoPath},
+			{labels.DistinctFiles, totals.DistinctFiles},
+			{labels.LinesChanged, totals.LinesChanged()},
+		}
+		dco := UsesDCO(commits)
+		if dco {
+			metrics = append(metrics, [2]any{labels.DCOCompliance, fmt.Sprintf("%d / %d", CountSignedOff(commits), len(commits))})
+		}
+		if rs := RangeStats(commits); rs.Commits > 0 {
+			metrics = append(metrics,
+				[2]any{labels.CommitsPerDay, math.Round(rs.CommitsPerDay*100) / 100},
+				[2]any{labels.AvgCommitSize, math.Round(rs.AvgCommitSize()*10) / 10},
+			)
+			if rs.MedianGap > 0 {
+				metrics = append(metrics, [2]any{labels.MedianGap, FormatGap(rs.MedianGap)})
+			}
+			if rs.LongestStreak.Days > 0 {
+				metrics = append(metrics, [2]any{labels.LongestStreak, rs.LongestStreak.String()})
+			}
+			if len(rs.LongestGaps) > 0 {
+				metrics = append(metrics, [2]any{labels.LongestGap, rs.LongestGaps[0].String()})
+			}
+		}
+		for i, m := range metrics {
+			row := strconv.Itoa(i + 2)
+			f.SetCellValue(summarySheet, "A"+row, m[0])
+			f.SetCellValue(summarySheet, "B"+row, m[1])
+		}
+		lastMetric := "A" + strconv.Itoa(len(metrics)+1)
+		// Tables start after a blank row below the metrics.
+		top := len(metrics) + 3
 
 		titleStyle, _ := f.NewStyle(&excelize.Style{
 			Font: &excelize.Font{
@@ -162,25 +441,135 @@ func ExportToExcel(commits []models.CommitInfo, repoPath, repoName string) error
 				Bold: true,
 			},
 		})
-		f.SetCellStyle(summarySheet, "A2", "A4", labelStyle)
+		f.SetCellStyle(summarySheet, "A2", lastMetric, labelStyle)
+
+		// Tables sit side by side, each starting one blank column after the previous.
+		table := summaryTable{f: f, sheet: summarySheet, top: top, col: 1, titleStyle: titleStyle, labelStyle: labelStyle}
+
+		authors := CountByAuthor(commits)
+		authorHeaders := []string{labels.Author, labels.Email, labels.Commits}
+		authorWidths := []float64{30, 40, 10}
+		if dco {
+			authorHeaders = append(authorHeaders, labels.SignedOff)
+			authorWidths = append(authorWidths, 10)
+		}
+		// First and last activity matter for onboarding/offboarding audits across several authors.
+		activity := len(authors) > 1
+		if activity {
+			authorHeaders = append(authorHeaders, labels.FirstCommit, labels.LastCommit)
+			authorWidths = append(authorWidths, 14, 14)
+		}
+		authorRows := make([][]any, len(authors))
+		for i, a := range authors {
+			row := []any{a.Author, a.Email, a.Commits}
+			if dco {
+				row = append(row, a.SignedOff)
+			}
+			if activity {
+				row = append(row, dateOnly(a.First), dateOnly(a.Last))
+			}
+			authorRows[i] = row
+		}
+		table.write(labels.ByAuthor, authorHeaders, authorWidths, authorRows)
+
+		if slices.ContainsFunc(commits, isConventional) {
+			var rows [][]any
+			for _, t := range CountByType(commits) {
+				rows = append(rows, []any{t.Type, t.Commits, t.Breaking})
+			}
+			table.write(labels.ByType, []string{labels.Type, labels.Commits, labels.Breaking}, []float64{16, 12, 12}, rows)
+		}
 
-		f.SetColWidth(summarySheet, "A", "A", 20)
-		f.SetColWidth(summarySheet, "B", "B", 40)
+		if languages := LanguageBreakdown(commits); len(languages) > 0 {
+			var rows [][]any
+			for _, l := range languages {
+				rows = append(rows, []any{l.Language, l.Lines, l.Share})
+			}
+			col := table.write(labels.ByLanguage, []string{labels.Language, labels.Headers[ColumnLines], labels.Share}, []float64{18, 12, 12}, rows)
+			percentStyle, _ := f.NewStyle(&excelize.Style{NumFmt: 10})
+			first, _ := excelize.CoordinatesToCellName(col+2, top+2)
+			last, _ := excelize.CoordinatesToCellName(col+2, top+1+len(rows))
+			f.SetCellStyle(summarySheet, first, last, percentStyle)
+		}
 
 		f.SetActiveSheet(summaryIndex)
 	}
 
-	fullPath := filepath.Join(repoPath, fileName)
-	if err := f.SaveAs(fullPath); err != nil {
-		return fmt.Errorf("failed to save Excel file: %v", err)
+	for _, key := range sheets {
+		if err := analysisSheets[key](f, commits, labels, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Password != "" && opts.ProtectSheets {
+		if err := protectSheets(f, opts.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+// summaryTable places titled tables side by side on the Summary sheet.
+type summaryTable struct {
+	f                      *excelize.File
+	sheet                  string
+	top, col               int // title row, and the column the next table starts at
+	titleStyle, labelStyle int
+}
+
+// write lays out one table at the next free column and returns that column.
+func (t *summaryTable) write(title string, headers []string, widths []float64, rows [][]any) int {
+	col := t.col
+	titleCell, _ := excelize.CoordinatesToCellName(col, t.top)
+	t.f.SetCellValue(t.sheet, titleCell, title)
+	t.f.SetCellStyle(t.sheet, titleCell, titleCell, t.titleStyle)
+
+	headerCell, _ := excelize.CoordinatesToCellName(col, t.top+1)
+	t.f.SetSheetRow(t.sheet, headerCell, &headers)
+	lastHeader, _ := excelize.CoordinatesToCellName(col+len(headers)-1, t.top+1)
+	t.f.SetCellStyle(t.sheet, headerCell, lastHeader, t.labelStyle)
+
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(col, t.top+2+i)
+		t.f.SetSheetRow(t.sheet, cell, &row)
 	}
+	for i, w := range widths {
+		name, _ := excelize.ColumnNumberToName(col + i)
+		t.f.SetColWidth(t.sheet, name, name, w)
+	}
+	t.col = col + len(headers) + 1
+	return col
+}
+
+func dateOnly(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
 
+// protectSheets locks every sheet while still letting readers filter and sort.
+func protectSheets(f *excelize.File, password string) error {
+	for _, sheet := range f.GetSheetList() {
+		err := f.ProtectSheet(sheet, &excelize.SheetProtectionOptions{
+			AlgorithmName:       "SHA-512",
+			Password:            password,
+			AutoFilter:          true,
+			Sort:                true,
+			SelectLockedCells:   true,
+			SelectUnlockedCells: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to protect sheet %s: %v", sheet, err)
+		}
+	}
 	return nil
 }
 
 func WriteExcel(svc interface {
 	IsGitRepo(string) bool
-	GatherCommits(string, string, string, bool) ([]models.CommitInfo, string, error)
+	GatherCommits(string, git.GatherOptions) ([]models.CommitInfo, string, error)
 	GetRepositoryName(string) string
 }) {
 	repoPath := "."
@@ -190,7 +579,7 @@ func WriteExcel(svc interface {
 		return
 	}
 
-	commits, _, err := svc.GatherCommits(repoPath, "", "main", true)
+	commits, _, err := svc.GatherCommits(repoPath, git.GatherOptions{ParentBranch: "main", CurrentBranchOnly: true})
 	if err != nil {
 		fmt.Printf("Error gathering commits: %v\n", err)
 		return
@@ -201,7 +590,7 @@ func WriteExcel(svc interface {
 	}
 
 	repoName := svc.GetRepositoryName(repoPath)
-	err = ExportToExcel(commits, repoPath, repoName)
+	err = ExportToExcel(commits, repoPath, repoName, config.Default(), nil)
 	if err != nil {
 		fmt.Printf("Error creating Excel file: %v\n", err)
 		return
@@ -283,8 +672,6 @@ func ExportDotnetExcel(services []models.DotnetEntry, up, down []models.DBAEntry
 		}
 	}()
 
-	fileName := fmt.Sprintf("%s_dotnet.xlsx", repoName)
-
 	sheetName := "Serviços"
 	index, err := f.NewSheet(sheetName)
 	if err != nil {
@@ -385,8 +772,7 @@ func ExportDotnetExcel(services []models.DotnetEntry, up, down []models.DBAEntry
 		return err
 	}
 
-	fullPath := filepath.Join(repoPath, fileName)
-	if err := f.SaveAs(fullPath); err != nil {
+	if err := f.SaveAs(DotnetExcelPath(repoPath, repoName)); err != nil {
 		return fmt.Errorf("failed to save Excel file: %v", err)
 	}
 
@@ -532,3 +918,23 @@ func writeDBASheet(f *excelize.File, up, down []models.DBAEntry) error {
 
 	return nil
 }
+
+// totalsLabelColumns picks the columns of the totals row that hold the "Totals"
+// label and the commit count: the first two whose key has no total of its own. When
+// only one is free it holds both, and -1 means there is none.
+func totalsLabelColumns(columns []string) (label, count int) {
+	label, count = -1, -1
+	for i, key := range columns {
+		switch key {
+		case ColumnMessage, ColumnFiles, ColumnAdditions, ColumnDeletions, ColumnLines:
+			continue
+		}
+		if label < 0 {
+			label = i
+		} else {
+			count = i
+			break
+		}
+	}
+	return label, count
+}
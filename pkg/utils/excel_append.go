@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// mergeWithExistingWorkbook returns commits plus every commit already recorded in the
+// workbook at path that is not in commits, newest first. Rows are matched by hash and
+// freshly gathered data wins. Line counts are only recovered when the additions and
+// deletions columns were exported; otherwise workbook-only commits count as zero lines.
+func mergeWithExistingWorkbook(commits []models.CommitInfo, path, password string) ([]models.CommitInfo, error) {
+	existing, err := readWorkbookCommits(path, password)
+	if errors.Is(err, fs.ErrNotExist) {
+		return commits, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read existing workbook: %v", err)
+	}
+
+	seen := make(map[string]bool, len(commits))
+	merged := make([]models.CommitInfo, 0, len(commits)+len(existing))
+	for _, c := range commits {
+		seen[c.Hash] = true
+		merged = append(merged, c)
+	}
+	for _, c := range existing {
+		if !seen[c.Hash] {
+			seen[c.Hash] = true
+			merged = append(merged, c)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].When.After(merged[j].When)
+	})
+	return merged, nil
+}
+
+func readWorkbookCommits(path, password string) ([]models.CommitInfo, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	f, err := excelize.OpenFile(path, excelize.Options{Password: password})
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows("Commits", excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, len(rows[0]))
+	for i, header := range rows[0] {
+		keys[i] = columnKeyForHeader(header)
+	}
+
+	var commits []models.CommitInfo
+	for _, row := range rows[1:] {
+		var c models.CommitInfo
+		for i, value := range row {
+			if i >= len(keys) {
+				break
+			}
+			switch keys[i] {
+			case ColumnHash:
+				c.Hash = value
+			case ColumnAuthor:
+				c.Author = value
+			case ColumnEmail:
+				c.Email = value
+			case ColumnMessage:
+				c.Message = value
+			case ColumnBranch:
+				c.Branch = value
+			case ColumnAdditions:
+				c.Additions, _ = strconv.Atoi(value)
+			case ColumnDeletions:
+				c.Deletions, _ = strconv.Atoi(value)
+			case ColumnDate:
+				c.Date = value
+				if serial, err := strconv.ParseFloat(value, 64); err == nil {
+					if when, err := excelize.ExcelDateToTime(serial, false); err == nil {
+						c.When = when
+						c.Date = when.Format("2006-01-02 15:04:05")
+					}
+				}
+			case ColumnFiles:
+				if isNoFilesLabel(value) {
+					continue
+				}
+				for file := range strings.SplitSeq(value, "\n") {
+					if file = strings.TrimSpace(file); file != "" {
+						c.Files = append(c.Files, file)
+					}
+				}
+			}
+		}
+		// Skip anything that is not a commit row, such as the totals row.
+		if !isCommitHash(c.Hash) {
+			continue
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+func isCommitHash(s string) bool {
+	if len(s) < 7 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNoFilesLabel(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, labels := range exportLocales {
+		if s == labels.NoFiles {
+			return true
+		}
+	}
+	return false
+}
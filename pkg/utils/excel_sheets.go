@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// Analysis sheets accepted in config.ExcelConfig.Sheets.
+const (
+	SheetActivity    = "activity"
+	SheetHotspots    = "hotspots"
+	SheetBusFactor   = "busfactor"
+	SheetDirectories = "directories"
+)
+
+// analysisSheets writes an optional sheet after Summary.
+var analysisSheets = map[string]func(f *excelize.File, commits []models.CommitInfo, labels exportLabels, opts config.ExcelConfig) error{
+	SheetActivity:    writeActivitySheet,
+	SheetHotspots:    writeHotspotsSheet,
+	SheetBusFactor:   writeBusFactorSheet,
+	SheetDirectories: writeDirectoriesSheet,
+}
+
+// resolveSheets validates configured sheet keys, dropping duplicates.
+func resolveSheets(keys []string) ([]string, error) {
+	var resolved []string
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, ok := analysisSheets[key]; !ok {
+			return nil, fmt.Errorf("unknown excel sheet %q", key)
+		}
+		if !seen[key] {
+			seen[key] = true
+			resolved = append(resolved, key)
+		}
+	}
+	return resolved, nil
+}
+
+// writeActivitySheet lays out the weekday × hour matrix with a color scale so
+// after-hours clusters stand out.
+func writeActivitySheet(f *excelize.File, commits []models.CommitInfo, labels exportLabels, opts config.ExcelConfig) error {
+	sheet := labels.ActivitySheet
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %v", err)
+	}
+	m := WeekdayHourMatrix(commits)
+
+	boldStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	titleStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true, Size: 14}})
+	if err != nil {
+		return err
+	}
+
+	f.SetCellValue(sheet, "A1", labels.ActivityTitle)
+	f.SetCellStyle(sheet, "A1", "A1", titleStyle)
+
+	header := []any{labels.Weekday}
+	for hour := 0; hour < 24; hour++ {
+		header = append(header, fmt.Sprintf("%02d", hour))
+	}
+	header = append(header, labels.Totals)
+	f.SetSheetRow(sheet, "A3", &header)
+	f.SetCellStyle(sheet, "A3", "Z3", boldStyle)
+
+	for day := 0; day < 7; day++ {
+		row := []any{labels.Weekdays[day]}
+		for hour := 0; hour < 24; hour++ {
+			row = append(row, m[day][hour])
+		}
+		row = append(row, m.WeekdayTotal(day))
+		cell, _ := excelize.CoordinatesToCellName(1, day+4)
+		f.SetSheetRow(sheet, cell, &row)
+	}
+	f.SetCellStyle(sheet, "A4", "A10", boldStyle)
+
+	if err := f.SetConditionalFormat(sheet, "B4:Y10", []excelize.ConditionalFormatOptions{{
+		Type:     "2_color_scale",
+		Criteria: "=",
+		MinType:  "min",
+		MaxType:  "max",
+		MinColor: "#FFFFFF",
+		MaxColor: "#26A641",
+	}}); err != nil {
+		return fmt.Errorf("failed to add color scale: %v", err)
+	}
+
+	after := m.AfterHours()
+	f.SetCellValue(sheet, "A12", fmt.Sprintf(labels.AfterHoursFmt, WorkdayStartHour, WorkdayEndHour))
+	f.SetCellValue(sheet, "B12", after)
+	f.SetCellStyle(sheet, "A12", "A12", boldStyle)
+	if len(commits) > 0 {
+		percent, err := f.NewStyle(&excelize.Style{NumFmt: 9})
+		if err != nil {
+			return err
+		}
+		f.SetCellValue(sheet, "C12", float64(after)/float64(len(commits)))
+		f.SetCellStyle(sheet, "C12", "C12", percent)
+	}
+
+	f.SetColWidth(sheet, "A", "A", 14)
+	f.SetColWidth(sheet, "B", "Y", 5)
+	f.SetColWidth(sheet, "Z", "Z", 10)
+	return nil
+}
+
+// writeHotspotsSheet lists every changed file, most frequently modified first.
+func writeHotspotsSheet(f *excelize.File, commits []models.CommitInfo, labels exportLabels, opts config.ExcelConfig) error {
+	sheet := labels.HotspotsSheet
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %v", err)
+	}
+
+	boldStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	f.SetSheetRow(sheet, "A1", &[]any{labels.File, labels.Commits, labels.Authors,
+		labels.Headers[ColumnAdditions], labels.Headers[ColumnDeletions], labels.Headers[ColumnLines]})
+	f.SetCellStyle(sheet, "A1", "F1", boldStyle)
+
+	spots := Hotspots(commits)
+	for i, h := range spots {
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		f.SetSheetRow(sheet, cell, &[]any{h.Path, h.Commits, h.Authors, h.Additions, h.Deletions, h.Churn()})
+	}
+	if len(spots) > 0 {
+		ref := fmt.Sprintf("B2:B%d", len(spots)+1)
+		if err := f.SetConditionalFormat(sheet, ref, []excelize.ConditionalFormatOptions{{
+			Type:     "data_bar",
+			Criteria: "=",
+			MinType:  "min",
+			MaxType:  "max",
+			BarColor: "#E53E3E",
+		}}); err != nil {
+			return fmt.Errorf("failed to add data bars: %v", err)
+		}
+		f.AutoFilter(sheet, fmt.Sprintf("A1:F%d", len(spots)+1), nil)
+	}
+
+	f.SetColWidth(sheet, "A", "A", 60)
+	f.SetColWidth(sheet, "B", "F", 14)
+	f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+	return nil
+}
+
+// writeBusFactorSheet lists top-level directories by ownership risk, highlighting
+// those a single author dominates.
+func writeBusFactorSheet(f *excelize.File, commits []models.CommitInfo, labels exportLabels, opts config.ExcelConfig) error {
+	sheet := labels.BusFactorSheet
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %v", err)
+	}
+
+	boldStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	percentStyle, err := f.NewStyle(&excelize.Style{NumFmt: 9})
+	if err != nil {
+		return err
+	}
+	riskStyle, err := f.NewStyle(&excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FED7D7"}, Pattern: 1},
+	})
+	if err != nil {
+		return err
+	}
+
+	f.SetSheetRow(sheet, "A1", &[]any{labels.Directory, labels.Commits, labels.Authors, labels.TopAuthor, labels.TopShare, labels.BusFactor})
+	f.SetCellStyle(sheet, "A1", "F1", boldStyle)
+	for i, o := range BusFactors(commits, DefaultBusFactorThreshold) {
+		row := i + 2
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		f.SetSheetRow(sheet, cell, &[]any{o.Directory, o.Commits, o.Authors, o.TopAuthor, o.TopShare, o.BusFactor})
+		if o.BusFactor == 1 {
+			last, _ := excelize.CoordinatesToCellName(6, row)
+			f.SetCellStyle(sheet, cell, last, riskStyle)
+		}
+		share, _ := excelize.CoordinatesToCellName(5, row)
+		f.SetCellStyle(sheet, share, share, percentStyle)
+	}
+
+	f.SetColWidth(sheet, "A", "A", 30)
+	f.SetColWidth(sheet, "B", "C", 10)
+	f.SetColWidth(sheet, "D", "D", 28)
+	f.SetColWidth(sheet, "E", "F", 12)
+	return nil
+}
+
+// writeDirectoriesSheet shows commits and lines per directory, totals first and then
+// per author, with a filter so one author's components can be picked out.
+func writeDirectoriesSheet(f *excelize.File, commits []models.CommitInfo, labels exportLabels, opts config.ExcelConfig) error {
+	sheet := labels.DirectoriesSheet
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %v", err)
+	}
+
+	boldStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	f.SetSheetRow(sheet, "A1", &[]any{labels.Author, labels.Directory, labels.Commits,
+		labels.Headers[ColumnAdditions], labels.Headers[ColumnDeletions], labels.Headers[ColumnLines]})
+	f.SetCellStyle(sheet, "A1", "F1", boldStyle)
+
+	rows := DirectoryBreakdown(commits, opts.DirectoryDepth)
+	for i, d := range rows {
+		author := d.Author
+		if author == AllAuthors {
+			author = labels.AllAuthors
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		f.SetSheetRow(sheet, cell, &[]any{author, d.Directory, d.Commits, d.Additions, d.Deletions, d.LinesChanged()})
+	}
+	if len(rows) > 0 {
+		f.AutoFilter(sheet, fmt.Sprintf("A1:F%d", len(rows)+1), nil)
+	}
+
+	f.SetColWidth(sheet, "A", "A", 24)
+	f.SetColWidth(sheet, "B", "B", 40)
+	f.SetColWidth(sheet, "C", "F", 14)
+	f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+	return nil
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTotalsLabelColumns(t *testing.T) {
+	tests := []struct {
+		columns      []string
+		label, count int
+	}{
+		{[]string{ColumnHash, ColumnAuthor, ColumnDate, ColumnMessage}, 0, 1},
+		{[]string{ColumnMessage, ColumnFiles, ColumnHash, ColumnAuthor}, 2, 3},
+		{[]string{ColumnLines, ColumnAuthor, ColumnAdditions, ColumnDate}, 1, 3},
+		{[]string{ColumnMessage, ColumnHash}, 1, -1},
+		{[]string{ColumnHash}, 0, -1},
+		{[]string{ColumnMessage, ColumnFiles}, -1, -1},
+	}
+	for _, tt := range tests {
+		label, count := totalsLabelColumns(tt.columns)
+		if label != tt.label || count != tt.count {
+			t.Errorf("totalsLabelColumns(%s) = %d, %d, want %d, %d", strings.Join(tt.columns, ","), label, count, tt.label, tt.count)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// Export formats accepted in config.ExportConfig.Formats.
+const (
+	FormatXLSX = "xlsx"
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	// FormatTemplate renders config.ExportConfig.Template.
+	FormatTemplate = "template"
+)
+
+// Artifact paths are derived from the repository name so repeated runs overwrite in place.
+
+func ExcelPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_commits.xlsx")
+}
+
+func DotnetExcelPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_dotnet.xlsx")
+}
+
+func CSVPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_commits.csv")
+}
+
+func JSONPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_commits.json")
+}
+
+// ExistingExports lists the files ExportCommits would overwrite for repoPath. An
+// appended workbook is merged rather than overwritten, so it is left out.
+func ExistingExports(repoPath, repoName string, cfg config.Config) []string {
+	dir := ExportDir(repoPath)
+	formats := cfg.Export.Formats
+	if len(formats) == 0 {
+		formats = []string{FormatXLSX}
+	}
+
+	var existing []string
+	for _, format := range formats {
+		var path string
+		switch format {
+		case FormatXLSX:
+			if cfg.Excel.Append {
+				continue
+			}
+			path = ExcelPath(dir, repoName)
+		case FormatCSV:
+			path = CSVPath(dir, repoName)
+		case FormatJSON:
+			path = JSONPath(dir, repoName)
+		default:
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			existing = append(existing, path)
+		}
+	}
+	return existing
+}
+
+// ExportCommits writes commits in every configured format to ExportDir(repoPath) and,
+// when cfg.Export.Zip is set, bundles the results into a timestamped zip. It returns
+// the paths written. progress, when not nil, follows the rows written across all formats.
+func ExportCommits(commits []models.CommitInfo, repoPath, repoName string, cfg config.Config, progress func(written, total int)) ([]string, error) {
+	formats := cfg.Export.Formats
+	if len(formats) == 0 {
+		formats = []string{FormatXLSX}
+	}
+
+	req := ExportRequest{Commits: commits, RepoPath: repoPath, Dir: ExportDir(repoPath), RepoName: repoName, Config: cfg}
+	rows, total := len(commits), len(commits)*len(formats)
+
+	var artifacts []string
+	for i, format := range formats {
+		exporter, err := LookupExporter(format, cfg.Export)
+		if err != nil {
+			return artifacts, err
+		}
+		if progress != nil {
+			before := i * rows
+			req.Progress = func(written, _ int) {
+				progress(before+min(written, rows), total)
+			}
+		}
+		paths, err := exporter.Export(req)
+		artifacts = append(artifacts, paths...)
+		if err != nil {
+			return artifacts, err
+		}
+		if progress != nil {
+			progress((i+1)*rows, total)
+		}
+	}
+
+	return FinalizeArtifacts(artifacts, req.Dir, repoName, cfg.Export)
+}
+
+// FinalizeArtifacts applies the post-export steps shared by every exporter, such as zipping.
+func FinalizeArtifacts(artifacts []string, dir, repoName string, cfg config.ExportConfig) ([]string, error) {
+	if !cfg.Zip || len(artifacts) == 0 {
+		return artifacts, nil
+	}
+	zipPath, err := BundleArtifacts(artifacts, dir, repoName, time.Now())
+	if err != nil {
+		return artifacts, err
+	}
+	return []string{zipPath}, nil
+}
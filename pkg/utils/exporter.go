@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// ExportRequest carries everything an Exporter needs for one run.
+type ExportRequest struct {
+	Commits  []models.CommitInfo
+	RepoPath string
+	// Dir is where artifacts are written: RepoPath, unless it is read-only (see ExportDir).
+	Dir      string
+	RepoName string
+	Config   config.Config
+	// Progress, when set, is told how many rows are written out of the total as
+	// the export goes. Exporters that can't tell leave it uncalled.
+	Progress func(written, total int)
+}
+
+// Exporter writes commits in one output format and returns the paths it created.
+type Exporter interface {
+	Export(req ExportRequest) ([]string, error)
+}
+
+// ExporterFunc adapts a plain function to Exporter.
+type ExporterFunc func(req ExportRequest) ([]string, error)
+
+func (f ExporterFunc) Export(req ExportRequest) ([]string, error) {
+	return f(req)
+}
+
+var (
+	exportersMu sync.RWMutex
+	exporters   = make(map[string]Exporter)
+)
+
+// RegisterExporter makes an exporter available under format, replacing any previous one.
+func RegisterExporter(format string, e Exporter) {
+	exportersMu.Lock()
+	defer exportersMu.Unlock()
+	exporters[format] = e
+}
+
+// LookupExporter returns the exporter for format. Formats without a registered exporter
+// resolve to an exec plugin when one is configured or found on PATH.
+func LookupExporter(format string, cfg config.ExportConfig) (Exporter, error) {
+	exportersMu.RLock()
+	e, ok := exporters[format]
+	exportersMu.RUnlock()
+	if ok {
+		return e, nil
+	}
+	if plugin, ok := findPlugin(format, cfg); ok {
+		return plugin, nil
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// RegisteredFormats lists the built-in and registered formats, sorted.
+func RegisteredFormats() []string {
+	exportersMu.RLock()
+	defer exportersMu.RUnlock()
+	formats := make([]string, 0, len(exporters))
+	for f := range exporters {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+func init() {
+	RegisterExporter(FormatXLSX, ExporterFunc(func(req ExportRequest) ([]string, error) {
+		path := ExcelPath(req.Dir, req.RepoName)
+		if err := saveExcel(req.Commits, path, req.RepoPath, req.RepoName, req.Config, req.Progress); err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}))
+
+	RegisterExporter(FormatCSV, ExporterFunc(func(req ExportRequest) ([]string, error) {
+		path := CSVPath(req.Dir, req.RepoName)
+		if err := ExportToCSV(req.Commits, path, req.Config.Export); err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}))
+
+	RegisterExporter(FormatJSON, ExporterFunc(func(req ExportRequest) ([]string, error) {
+		path := JSONPath(req.Dir, req.RepoName)
+		if err := ExportToJSON(req.Commits, req.RepoName, path); err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}))
+
+	RegisterExporter(FormatChangelog, ExporterFunc(exportChangelog))
+	RegisterExporter(FormatAuthors, ExporterFunc(exportAuthors))
+	RegisterExporter(FormatDigest, ExporterFunc(exportDigest))
+
+	RegisterExporter(FormatLint, ExporterFunc(func(req ExportRequest) ([]string, error) {
+		path := LintPath(req.Dir, req.RepoName)
+		if err := ExportLintReport(req.Commits, path, req.Config.Lint); err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}))
+
+	RegisterExporter(FormatLeaderboard, ExporterFunc(func(req ExportRequest) ([]string, error) {
+		path := LeaderboardPath(req.Dir, req.RepoName)
+		if err := ExportLeaderboard(req.Commits, path); err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}))
+
+	RegisterExporter(FormatTimesheet, ExporterFunc(func(req ExportRequest) ([]string, error) {
+		path := TimesheetPath(req.Dir, req.RepoName)
+		if err := ExportTimesheet(req.Commits, path, req.Config); err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}))
+
+	RegisterExporter(FormatTemplate, ExporterFunc(func(req ExportRequest) ([]string, error) {
+		tmpl := req.Config.Export.Template
+		if tmpl == "" {
+			return nil, fmt.Errorf("export format %q needs export.template to be set", FormatTemplate)
+		}
+		path := TemplatePath(req.Dir, req.RepoName, tmpl)
+		if err := ExportWithTemplate(req.Commits, req.RepoName, tmpl, path, DateLayout(req.Config.Export.DateFormat)); err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}))
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// PeriodDay groups by calendar day; weeks and months use PeriodWeek and PeriodMonth.
+const PeriodDay = "day"
+
+// GroupModes are the values accepted in config.ExportConfig.GroupBy, in the order the
+// results screen cycles through them; "" leaves commits ungrouped.
+var GroupModes = []string{"", PeriodDay, PeriodWeek, PeriodMonth}
+
+// ResolveGroupBy validates a grouping mode.
+func ResolveGroupBy(by string) (string, error) {
+	for _, mode := range GroupModes {
+		if by == mode {
+			return by, nil
+		}
+	}
+	return "", fmt.Errorf("unknown grouping %q: use day, week or month", by)
+}
+
+// PeriodLabel names the day (2024-05-13), ISO week (2024-W20) or month (2024-05)
+// containing t; an empty mode means day.
+func PeriodLabel(t time.Time, by string) string {
+	if t.IsZero() {
+		return ""
+	}
+	switch by {
+	case PeriodWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case PeriodMonth:
+		return t.Format("2006-01")
+	}
+	return t.Format(time.DateOnly)
+}
+
+// CommitGroup is a run of consecutive commits sharing a period label.
+type CommitGroup struct {
+	Label   string
+	Commits []models.CommitInfo
+}
+
+// GroupCommits splits date-ordered commits into runs by period, keeping their order.
+func GroupCommits(commits []models.CommitInfo, by string) []CommitGroup {
+	var groups []CommitGroup
+	for _, c := range commits {
+		label := PeriodLabel(c.When, by)
+		if n := len(groups); n > 0 && groups[n-1].Label == label {
+			groups[n-1].Commits = append(groups[n-1].Commits, c)
+			continue
+		}
+		groups = append(groups, CommitGroup{Label: label, Commits: []models.CommitInfo{c}})
+	}
+	return groups
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// Hotspot aggregates how often and how much one file changed.
+type Hotspot struct {
+	Path      string
+	Commits   int
+	Authors   int
+	Additions int
+	Deletions int
+}
+
+// Churn is the total number of lines added and deleted.
+func (h Hotspot) Churn() int {
+	return h.Additions + h.Deletions
+}
+
+// Hotspots aggregates changes per file, most frequently modified first and ties
+// broken by churn.
+func Hotspots(commits []models.CommitInfo) []Hotspot {
+	index := make(map[string]int)
+	var spots []Hotspot
+	var authors []map[string]bool
+	for _, c := range commits {
+		_, email := canonicalIdentity(c)
+		for _, fc := range fileChanges(c) {
+			i, ok := index[fc.Path]
+			if !ok {
+				i = len(spots)
+				index[fc.Path] = i
+				spots = append(spots, Hotspot{Path: fc.Path})
+				authors = append(authors, make(map[string]bool))
+			}
+			spots[i].Commits++
+			spots[i].Additions += fc.Additions
+			spots[i].Deletions += fc.Deletions
+			authors[i][strings.ToLower(email)] = true
+		}
+	}
+	for i := range spots {
+		spots[i].Authors = len(authors[i])
+	}
+
+	sort.SliceStable(spots, func(i, j int) bool {
+		if spots[i].Commits != spots[j].Commits {
+			return spots[i].Commits > spots[j].Commits
+		}
+		if spots[i].Churn() != spots[j].Churn() {
+			return spots[i].Churn() > spots[j].Churn()
+		}
+		return spots[i].Path < spots[j].Path
+	})
+	return spots
+}
+
+// fileChanges returns c's per-file counts, falling back to its file list with zero
+// lines for commits that only came from a workbook.
+func fileChanges(c models.CommitInfo) []models.FileChange {
+	if c.FileChanges != nil {
+		return c.FileChanges
+	}
+	files := touchedFiles(c)
+	changes := make([]models.FileChange, len(files))
+	for i, f := range files {
+		changes[i] = models.FileChange{Path: f}
+	}
+	return changes
+}
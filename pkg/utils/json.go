@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// jsonExport is the versioned JSON contract described by JSONSchema. Changing or
+// removing a field requires bumping JSONSchemaVersion and publishing a new schema.
+type jsonExport struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	Repository    string       `json:"repository"`
+	GeneratedAt   time.Time    `json:"generatedAt"`
+	Commits       []jsonCommit `json:"commits"`
+}
+
+type jsonCommit struct {
+	Hash      string    `json:"hash"`
+	Author    string    `json:"author"`
+	Email     string    `json:"email"`
+	Date      time.Time `json:"date"`
+	Message   string    `json:"message"`
+	Files     []string  `json:"files"`
+	Additions int       `json:"additions"`
+	Deletions int       `json:"deletions"`
+	Branch    string    `json:"branch"`
+
+	PullRequest *jsonPullRequest `json:"pullRequest,omitempty"`
+	WorkItems   []jsonWorkItem   `json:"workItems,omitempty"`
+	Issues      []jsonWorkItem   `json:"issues,omitempty"`
+	SignedOffBy []string         `json:"signedOffBy,omitempty"`
+	Unmerged    bool             `json:"unmerged,omitempty"`
+}
+
+type jsonWorkItem struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	State string `json:"state"`
+	URL   string `json:"url"`
+}
+
+type jsonPullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	State  string `json:"state"`
+	Review string `json:"review"`
+	Checks string `json:"checks"`
+}
+
+func ExportToJSON(commits []models.CommitInfo, repoName, jsonPath string) error {
+	data, err := EncodeJSONExport(commits, repoName)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(jsonPath, data, 0o644)
+}
+
+// EncodeJSONExport builds the versioned JSON document described by JSONSchema.
+func EncodeJSONExport(commits []models.CommitInfo, repoName string) ([]byte, error) {
+	out := jsonExport{
+		SchemaVersion: JSONSchemaVersion,
+		Repository:    repoName,
+		GeneratedAt:   time.Now(),
+		Commits:       make([]jsonCommit, 0, len(commits)),
+	}
+	for _, c := range commits {
+		files := c.Files
+		if files == nil {
+			files = []string{}
+		}
+		out.Commits = append(out.Commits, jsonCommit{
+			Hash:      c.Hash,
+			Author:    c.Author,
+			Email:     c.Email,
+			Date:      c.When,
+			Message:   c.Message,
+			Files:     files,
+			Additions: c.Additions,
+			Deletions: c.Deletions,
+			Branch:    c.Branch,
+
+			PullRequest: jsonPullRequestFrom(c.PullRequest),
+			WorkItems:   jsonWorkItemsFrom(c.WorkItems),
+			Issues:      jsonWorkItemsFrom(c.Issues),
+			SignedOffBy: c.SignedOff,
+			Unmerged:    c.Unmerged,
+		})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func jsonPullRequestFrom(pr *models.PullRequest) *jsonPullRequest {
+	if pr == nil {
+		return nil
+	}
+	return &jsonPullRequest{
+		Number: pr.Number,
+		Title:  pr.Title,
+		URL:    pr.URL,
+		State:  pr.State,
+		Review: pr.Review,
+		Checks: pr.Checks,
+	}
+}
+
+func jsonWorkItemsFrom(items []models.WorkItem) []jsonWorkItem {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]jsonWorkItem, len(items))
+	for i, item := range items {
+		out[i] = jsonWorkItem{ID: item.ID, Title: item.Title, State: item.State, URL: item.URL}
+	}
+	return out
+}
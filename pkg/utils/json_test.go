@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+func TestEncodeJSONExportMatchesSchema(t *testing.T) {
+	published, err := os.ReadFile("schema/commits.v1.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(published) != string(JSONSchema) {
+		t.Fatal("embedded JSONSchema differs from schema/commits.v1.schema.json")
+	}
+
+	when := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		commits []models.CommitInfo
+	}{
+		{"no commits", nil},
+		{"plain commit", []models.CommitInfo{{
+			Hash: "abc123", Author: "Ana", Email: "ana@example.com", When: when,
+			Message: "Add parser", Branch: "main",
+		}}},
+		{"every optional field", []models.CommitInfo{{
+			Hash: "def456", Author: "Bo", Email: "bo@example.com", When: when,
+			Message: "Fix AB#12 and PROJ-7", Files: []string{"a.go", "b.go"},
+			Additions: 10, Deletions: 2, Branch: "feature",
+			PullRequest: &models.PullRequest{Number: 42, Title: "Fix", URL: "https://example.com/pr/42", State: "merged", Review: "approved", Checks: "success"},
+			WorkItems:   []models.WorkItem{{ID: "12", Title: "Bug", State: "Done", URL: "https://example.com/12"}},
+			Issues:      []models.WorkItem{{ID: "PROJ-7", Title: "Crash", State: "Open"}},
+			SignedOff:   []string{"Bo <bo@example.com>"},
+			Unmerged:    true,
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncodeJSONExport(tt.commits, "repo")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ValidateJSONExport(data); err != nil {
+				t.Errorf("export does not match the schema: %v\n%s", err, data)
+			}
+		})
+	}
+}
+
+func TestValidateJSONExportRejects(t *testing.T) {
+	data, err := EncodeJSONExport([]models.CommitInfo{{Hash: "abc123", Author: "Ana", When: time.Now()}}, "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(doc map[string]any)
+		want   string
+	}{
+		{"missing schemaVersion", func(doc map[string]any) { delete(doc, "schemaVersion") }, "schemaVersion"},
+		{"wrong schemaVersion", func(doc map[string]any) { doc["schemaVersion"] = 2 }, "schemaVersion"},
+		{"wrong type", func(doc map[string]any) { commit(doc)["additions"] = "ten" }, "additions"},
+		{"files not an array", func(doc map[string]any) { commit(doc)["files"] = "a.go" }, "files"},
+		{"unknown field", func(doc map[string]any) { commit(doc)["reviewer"] = "Bo" }, "reviewer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc map[string]any
+			if err := json.Unmarshal(data, &doc); err != nil {
+				t.Fatal(err)
+			}
+			tt.mutate(doc)
+			mutated, err := json.Marshal(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ValidateJSONExport(mutated)
+			if err == nil {
+				t.Fatal("ValidateJSONExport accepted an invalid export")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func commit(doc map[string]any) map[string]any {
+	return doc["commits"].([]any)[0].(map[string]any)
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// JSONSchemaVersion is the schemaVersion written into every JSON export.
+const JSONSchemaVersion = 1
+
+// JSONSchema is the published JSON Schema for the JSON export (schema/commits.v1.schema.json).
+//
+//go:embed schema/commits.v1.schema.json
+var JSONSchema []byte
+
+// schemaNode is the subset of JSON Schema the export contract relies on.
+type schemaNode struct {
+	Type                 string                 `json:"type"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	Const                any                    `json:"const"`
+	Minimum              *float64               `json:"minimum"`
+	Format               string                 `json:"format"`
+}
+
+// ValidateJSONExport checks an encoded JSON export against JSONSchema.
+func ValidateJSONExport(data []byte) error {
+	var root schemaNode
+	if err := json.Unmarshal(JSONSchema, &root); err != nil {
+		return fmt.Errorf("invalid embedded schema: %v", err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	return root.validate(doc, "$")
+}
+
+func (n *schemaNode) validate(v any, path string) error {
+	if n.Const != nil && !reflect.DeepEqual(n.Const, v) {
+		return fmt.Errorf("%s: expected %v, got %v", path, n.Const, v)
+	}
+
+	switch n.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected object", path)
+		}
+		for _, key := range n.Required {
+			if _, ok := obj[key]; !ok {
+				return fmt.Errorf("%s: missing required field %q", path, key)
+			}
+		}
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child, ok := n.Properties[key]
+			if !ok {
+				if n.AdditionalProperties != nil && !*n.AdditionalProperties {
+					return fmt.Errorf("%s: unexpected field %q", path, key)
+				}
+				continue
+			}
+			if err := child.validate(obj[key], path+"."+key); err != nil {
+				return err
+			}
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected array", path)
+		}
+		if n.Items != nil {
+			for i, item := range arr {
+				if err := n.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected string", path)
+		}
+		if n.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				return fmt.Errorf("%s: expected RFC 3339 date-time, got %q", path, s)
+			}
+		}
+	case "integer", "number":
+		num, ok := v.(float64)
+		if !ok || (n.Type == "integer" && num != math.Trunc(num)) {
+			return fmt.Errorf("%s: expected %s", path, n.Type)
+		}
+		if n.Minimum != nil && num < *n.Minimum {
+			return fmt.Errorf("%s: %v is below minimum %v", path, num, *n.Minimum)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean", path)
+		}
+	}
+	return nil
+}
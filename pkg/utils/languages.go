@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// OtherLanguage collects files with an unrecognized extension.
+const OtherLanguage = "Other"
+
+var languagesByExtension = map[string]string{
+	".go": "Go", ".sql": "SQL", ".yml": "YAML", ".yaml": "YAML", ".json": "JSON",
+	".md": "Markdown", ".js": "JavaScript", ".jsx": "JavaScript", ".mjs": "JavaScript",
+	".ts": "TypeScript", ".tsx": "TypeScript", ".py": "Python", ".java": "Java",
+	".kt": "Kotlin", ".cs": "C#", ".csproj": "MSBuild", ".sln": "MSBuild", ".rb": "Ruby",
+	".rs": "Rust", ".c": "C", ".h": "C", ".cpp": "C++", ".cc": "C++", ".hpp": "C++",
+	".php": "PHP", ".swift": "Swift", ".sh": "Shell", ".bash": "Shell", ".ps1": "PowerShell",
+	".html": "HTML", ".css": "CSS", ".scss": "SCSS", ".xml": "XML", ".toml": "TOML",
+	".proto": "Protocol Buffers", ".tf": "Terraform", ".vue": "Vue", ".dart": "Dart",
+	".scala": "Scala", ".lua": "Lua", ".r": "R",
+}
+
+var languagesByName = map[string]string{
+	"Dockerfile": "Dockerfile", "Makefile": "Makefile", "go.mod": "Go", "go.sum": "Go",
+}
+
+// LanguageOf classifies a file path by its name or extension.
+func LanguageOf(file string) string {
+	base := path.Base(file)
+	if lang, ok := languagesByName[base]; ok {
+		return lang
+	}
+	if lang, ok := languagesByExtension[strings.ToLower(path.Ext(base))]; ok {
+		return lang
+	}
+	return OtherLanguage
+}
+
+type LanguageShare struct {
+	Language string
+	Lines    int
+	Files    int // file changes, counting a file once per commit
+	// Share of all lines changed, or of file changes when no line counts are known.
+	Share float64
+}
+
+// LanguageBreakdown weighs each language by lines changed, largest first.
+func LanguageBreakdown(commits []models.CommitInfo) []LanguageShare {
+	index := make(map[string]int)
+	var shares []LanguageShare
+	totalLines, totalFiles := 0, 0
+	for _, c := range commits {
+		for _, fc := range fileChanges(c) {
+			lang := LanguageOf(fc.Path)
+			i, ok := index[lang]
+			if !ok {
+				i = len(shares)
+				index[lang] = i
+				shares = append(shares, LanguageShare{Language: lang})
+			}
+			lines := fc.Additions + fc.Deletions
+			shares[i].Lines += lines
+			shares[i].Files++
+			totalLines += lines
+			totalFiles++
+		}
+	}
+	for i := range shares {
+		if totalLines > 0 {
+			shares[i].Share = float64(shares[i].Lines) / float64(totalLines)
+		} else if totalFiles > 0 {
+			shares[i].Share = float64(shares[i].Files) / float64(totalFiles)
+		}
+	}
+
+	sort.SliceStable(shares, func(i, j int) bool {
+		if shares[i].Share != shares[j].Share {
+			return shares[i].Share > shares[j].Share
+		}
+		return shares[i].Language < shares[j].Language
+	})
+	return shares
+}
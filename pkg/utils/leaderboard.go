@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// FormatLeaderboard writes the contributor leaderboard workbook.
+const FormatLeaderboard = "leaderboard"
+
+// Leaderboard rankings.
+const (
+	RankByCommits = "commits"
+	RankByFiles   = "files"
+	RankByLines   = "lines"
+)
+
+// LeaderboardRankings lists the rankings in display order.
+var LeaderboardRankings = []string{RankByCommits, RankByFiles, RankByLines}
+
+type LeaderboardEntry struct {
+	Name      string
+	Email     string
+	Commits   int
+	Files     int // distinct files touched
+	Additions int
+	Deletions int
+}
+
+func (e LeaderboardEntry) Lines() int {
+	return e.Additions + e.Deletions
+}
+
+func (e LeaderboardEntry) metric(by string) int {
+	switch by {
+	case RankByFiles:
+		return e.Files
+	case RankByLines:
+		return e.Lines()
+	}
+	return e.Commits
+}
+
+// Leaderboard totals commits per .mailmap-resolved author and ranks them by the
+// given metric, highest first.
+func Leaderboard(commits []models.CommitInfo, by string) []LeaderboardEntry {
+	index := make(map[string]int)
+	var entries []LeaderboardEntry
+	var files []map[string]bool
+	for _, c := range commits {
+		name, email := canonicalIdentity(c)
+		key := strings.ToLower(email)
+		i, ok := index[key]
+		if !ok {
+			i = len(entries)
+			index[key] = i
+			entries = append(entries, LeaderboardEntry{Name: name, Email: email})
+			files = append(files, make(map[string]bool))
+		}
+		entries[i].Commits++
+		entries[i].Additions += c.Additions
+		entries[i].Deletions += c.Deletions
+		for _, f := range touchedFiles(c) {
+			files[i][f] = true
+		}
+	}
+	for i := range entries {
+		entries[i].Files = len(files[i])
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if a, b := entries[i].metric(by), entries[j].metric(by); a != b {
+			return a > b
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// touchedFiles returns the paths c changed; RawFiles keeps them when .NET mode has
+// rewritten Files to project names.
+func touchedFiles(c models.CommitInfo) []string {
+	if c.RawFiles != nil {
+		return c.RawFiles
+	}
+	return c.Files
+}
+
+func LeaderboardPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_leaderboard.xlsx")
+}
+
+// ExportLeaderboard writes one sheet per ranking.
+func ExportLeaderboard(commits []models.CommitInfo, path string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "#FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %v", err)
+	}
+
+	for i, by := range LeaderboardRankings {
+		sheet := "By " + strings.ToUpper(by[:1]) + by[1:]
+		if i == 0 {
+			f.SetSheetName("Sheet1", sheet)
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			return fmt.Errorf("failed to create sheet: %v", err)
+		}
+
+		f.SetSheetRow(sheet, "A1", &[]any{"Rank", "Author", "Email", "Commits", "Files Touched", "Additions", "Deletions", "Lines Changed"})
+		f.SetCellStyle(sheet, "A1", "H1", headerStyle)
+		for j, e := range Leaderboard(commits, by) {
+			cell, _ := excelize.CoordinatesToCellName(1, j+2)
+			f.SetSheetRow(sheet, cell, &[]any{j + 1, e.Name, e.Email, e.Commits, e.Files, e.Additions, e.Deletions, e.Lines()})
+		}
+		f.SetColWidth(sheet, "A", "A", 8)
+		f.SetColWidth(sheet, "B", "C", 28)
+		f.SetColWidth(sheet, "D", "H", 14)
+		f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to save leaderboard: %v", err)
+	}
+	return nil
+}
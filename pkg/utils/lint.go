@@ -0,0 +1,245 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// FormatLint writes the commit message compliance workbook.
+const FormatLint = "lint"
+
+// Lint rule names, as reported in violations.
+const (
+	RuleSubjectLength = "subject-length"
+	RuleImperative    = "imperative"
+	RuleTicket        = "ticket"
+)
+
+const defaultTicketPattern = `AB#\d+|\b[A-Z][A-Z0-9_]+-\d+\b|(^|\s|\()#\d+\b`
+
+// LintResult scores one commit: Score is the percentage of enabled rules it passes.
+type LintResult struct {
+	Violations []string
+	Score      int
+}
+
+// Linter checks commit subjects against config.LintConfig.
+type Linter struct {
+	cfg    config.LintConfig
+	ticket *regexp.Regexp
+	rules  int
+}
+
+func NewLinter(cfg config.LintConfig) (*Linter, error) {
+	l := &Linter{cfg: cfg}
+	if cfg.MaxSubjectLength > 0 {
+		l.rules++
+	}
+	if cfg.Imperative {
+		l.rules++
+	}
+	if cfg.RequireTicket {
+		l.rules++
+		pattern := cfg.TicketPattern
+		if pattern == "" {
+			pattern = defaultTicketPattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lint.ticketPattern: %v", err)
+		}
+		l.ticket = re
+	}
+	return l, nil
+}
+
+func (l *Linter) Lint(c models.CommitInfo) LintResult {
+	subject := strings.TrimSpace(c.Message)
+	var violations []string
+
+	if l.cfg.MaxSubjectLength > 0 && len([]rune(subject)) > l.cfg.MaxSubjectLength {
+		violations = append(violations, RuleSubjectLength)
+	}
+	if l.cfg.Imperative && !isImperative(subject) {
+		violations = append(violations, RuleImperative)
+	}
+	if l.ticket != nil && !l.ticket.MatchString(subject) {
+		violations = append(violations, RuleTicket)
+	}
+
+	score := 100
+	if l.rules > 0 {
+		score = 100 * (l.rules - len(violations)) / l.rules
+	}
+	return LintResult{Violations: violations, Score: score}
+}
+
+// commonVerbs are checked for inflected forms ("adds", "added", "adding").
+var commonVerbs = []string{
+	"add", "fix", "update", "remove", "change", "refactor", "implement", "improve",
+	"create", "delete", "rename", "move", "bump", "use", "make", "allow", "support",
+	"handle", "clean", "merge", "revert", "drop", "replace", "introduce", "document",
+	"test", "ensure", "prevent", "avoid", "enable", "disable", "upgrade", "correct",
+	"adjust", "simplify", "optimize", "extract", "convert", "restore", "release",
+}
+
+// imperativeExceptions end in -ed/-ing but are fine as the first word of a subject.
+var imperativeExceptions = map[string]bool{
+	"bring": true, "embed": true, "exceed": true, "feed": true, "need": true,
+	"proceed": true, "ring": true, "seed": true, "shred": true, "speed": true,
+	"spring": true, "string": true, "succeed": true, "swing": true, "ping": true,
+}
+
+// isImperative is a heuristic: the first word of the description must not look like
+// past tense, a gerund or a third-person verb.
+func isImperative(subject string) bool {
+	if cc, ok := ParseConventional(subject); ok {
+		subject = cc.Description
+	}
+	fields := strings.Fields(subject)
+	if len(fields) == 0 {
+		return false
+	}
+	word := strings.ToLower(strings.Trim(fields[0], ".,:;!?\"'`"))
+	if imperativeExceptions[word] {
+		return true
+	}
+	for _, verb := range commonVerbs {
+		stem := strings.TrimSuffix(verb, "e")
+		switch word {
+		case verb + "s", verb + "es", verb + "d", verb + "ed", stem + "ing", verb + "ing":
+			return false
+		}
+	}
+	return !strings.HasSuffix(word, "ed") && !strings.HasSuffix(word, "ing")
+}
+
+type AuthorLint struct {
+	Author    string
+	Email     string
+	Commits   int
+	Compliant int            // commits without violations
+	Score     int            // average score
+	ByRule    map[string]int // violations per rule
+}
+
+func (a AuthorLint) ComplianceRate() float64 {
+	if a.Commits == 0 {
+		return 0
+	}
+	return float64(a.Compliant) / float64(a.Commits)
+}
+
+// LintByAuthor aggregates lint results per author email, least compliant first.
+func LintByAuthor(commits []models.CommitInfo, results []LintResult) []AuthorLint {
+	index := make(map[string]int)
+	var authors []AuthorLint
+	totals := make([]int, 0)
+	for i, c := range commits {
+		key := strings.ToLower(c.Email)
+		j, ok := index[key]
+		if !ok {
+			j = len(authors)
+			index[key] = j
+			authors = append(authors, AuthorLint{Author: c.Author, Email: c.Email, ByRule: make(map[string]int)})
+			totals = append(totals, 0)
+		}
+		a := &authors[j]
+		a.Commits++
+		totals[j] += results[i].Score
+		if len(results[i].Violations) == 0 {
+			a.Compliant++
+		}
+		for _, v := range results[i].Violations {
+			a.ByRule[v]++
+		}
+	}
+	for j := range authors {
+		authors[j].Score = totals[j] / authors[j].Commits
+	}
+
+	sort.SliceStable(authors, func(i, j int) bool {
+		if authors[i].ComplianceRate() != authors[j].ComplianceRate() {
+			return authors[i].ComplianceRate() < authors[j].ComplianceRate()
+		}
+		return authors[i].Author < authors[j].Author
+	})
+	return authors
+}
+
+func LintPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_lint.xlsx")
+}
+
+// ExportLintReport writes a workbook with every commit's violations and a per-author
+// compliance sheet.
+func ExportLintReport(commits []models.CommitInfo, path string, cfg config.LintConfig) error {
+	linter, err := NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+	results := make([]LintResult, len(commits))
+	for i, c := range commits {
+		results[i] = linter.Lint(c)
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "#FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %v", err)
+	}
+	percentStyle, err := f.NewStyle(&excelize.Style{NumFmt: 9})
+	if err != nil {
+		return fmt.Errorf("failed to create percent style: %v", err)
+	}
+
+	authorSheet := "By Author"
+	f.SetSheetName("Sheet1", authorSheet)
+	rules := []string{RuleSubjectLength, RuleImperative, RuleTicket}
+	f.SetSheetRow(authorSheet, "A1", &[]any{"Author", "Email", "Commits", "Compliant", "Compliance", "Average Score", rules[0], rules[1], rules[2]})
+	f.SetCellStyle(authorSheet, "A1", "I1", headerStyle)
+	for i, a := range LintByAuthor(commits, results) {
+		row := i + 2
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		f.SetSheetRow(authorSheet, cell, &[]any{a.Author, a.Email, a.Commits, a.Compliant, a.ComplianceRate(), a.Score,
+			a.ByRule[rules[0]], a.ByRule[rules[1]], a.ByRule[rules[2]]})
+		pct, _ := excelize.CoordinatesToCellName(5, row)
+		f.SetCellStyle(authorSheet, pct, pct, percentStyle)
+	}
+	f.SetColWidth(authorSheet, "A", "B", 28)
+	f.SetColWidth(authorSheet, "C", "I", 14)
+	f.SetPanes(authorSheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+
+	commitSheet := "Commits"
+	if _, err := f.NewSheet(commitSheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %v", err)
+	}
+	f.SetSheetRow(commitSheet, "A1", &[]any{"Commit Hash", "Author", "Subject", "Score", "Violations"})
+	f.SetCellStyle(commitSheet, "A1", "E1", headerStyle)
+	for i, c := range commits {
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		f.SetSheetRow(commitSheet, cell, &[]any{c.Hash, c.Author, c.Message, results[i].Score, strings.Join(results[i].Violations, ", ")})
+	}
+	f.SetColWidth(commitSheet, "A", "A", 42)
+	f.SetColWidth(commitSheet, "B", "B", 24)
+	f.SetColWidth(commitSheet, "C", "C", 60)
+	f.SetColWidth(commitSheet, "E", "E", 36)
+	f.SetPanes(commitSheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to save lint report: %v", err)
+	}
+	return nil
+}
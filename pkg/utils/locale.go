@@ -0,0 +1,388 @@
+package utils
+
+import "strings"
+
+// DefaultLocale is used when the configured locale has no translation.
+const DefaultLocale = "en"
+
+// exportLabels holds every user-facing string written into the commits workbook.
+type exportLabels struct {
+	Headers map[string]string // keyed by column key
+
+	NoFiles          string
+	Totals           string
+	CommitsFmt       string
+	LinesChangedFmt  string
+	DistinctFilesFmt string
+
+	SummaryTitle  string
+	RepoName      string
+	TotalCommits  string
+	RepoPath      string
+	DistinctFiles string
+	LinesChanged  string
+	ByAuthor      string
+	Author        string
+	Email         string
+	Commits       string
+	ByType        string
+	Type          string
+	Breaking      string
+	SignedOff     string
+	DCOCompliance string
+
+	ActivitySheet string
+	ActivityTitle string
+	Weekday       string
+	Weekdays      [7]string // Monday first
+	AfterHoursFmt string    // takes the workday start and end hour
+
+	HotspotsSheet string
+	File          string
+	Authors       string
+
+	BusFactorSheet string
+	Directory      string
+	TopAuthor      string
+	TopShare       string
+	BusFactor      string
+
+	DirectoriesSheet string
+	AllAuthors       string
+
+	ByLanguage string
+	Language   string
+	Share      string
+
+	CommitsPerDay string
+	AvgCommitSize string
+	MedianGap     string
+	LongestStreak string
+	LongestGap    string
+	FirstCommit   string
+	LastCommit    string
+}
+
+var exportLocales = map[string]exportLabels{
+	"en": {
+		Headers: map[string]string{
+			ColumnHash:      "Commit Hash",
+			ColumnAuthor:    "Author Name",
+			ColumnEmail:     "Author Email",
+			ColumnDate:      "Commit Date",
+			ColumnMessage:   "Commit Message",
+			ColumnFiles:     "Files Changed",
+			ColumnAdditions: "Additions",
+			ColumnDeletions: "Deletions",
+			ColumnLines:     "Lines Changed",
+			ColumnBranch:    "Branch",
+			ColumnPR:        "Pull Request",
+			ColumnReview:    "Review",
+			ColumnChecks:    "Checks",
+			ColumnWorkItems: "Work Items",
+			ColumnIssues:    "Issues",
+			ColumnType:      "Type",
+			ColumnScope:     "Scope",
+			ColumnDCO:       "DCO",
+			ColumnUnmerged:  "Not Merged",
+			ColumnPeriod:    "Period",
+		},
+		NoFiles:          "No files changed",
+		Totals:           "Totals",
+		CommitsFmt:       "%d commits",
+		LinesChangedFmt:  "%d lines changed (+%d / -%d)",
+		DistinctFilesFmt: "%d distinct files",
+		SummaryTitle:     "Repository Summary",
+		RepoName:         "Repository Name:",
+		TotalCommits:     "Total Commits:",
+		RepoPath:         "Repository Path:",
+		DistinctFiles:    "Distinct Files:",
+		LinesChanged:     "Lines Changed:",
+		ByAuthor:         "Commits by Author",
+		Author:           "Author",
+		Email:            "Email",
+		Commits:          "Commits",
+		ByType:           "Commits by Type",
+		Type:             "Type",
+		Breaking:         "Breaking",
+		SignedOff:        "Signed Off",
+		DCOCompliance:    "DCO Compliance:",
+		ActivitySheet:    "Activity",
+		ActivityTitle:    "Commits by Weekday and Hour",
+		Weekday:          "Weekday",
+		Weekdays:         [7]string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"},
+		AfterHoursFmt:    "After hours (outside %02d:00–%02d:00 Mon–Fri):",
+		HotspotsSheet:    "Hotspots",
+		File:             "File",
+		Authors:          "Authors",
+		BusFactorSheet:   "Bus Factor",
+		Directory:        "Directory",
+		TopAuthor:        "Top Author",
+		TopShare:         "Top Share",
+		BusFactor:        "Bus Factor",
+		DirectoriesSheet: "Directories",
+		AllAuthors:       "All authors",
+		ByLanguage:       "Lines by Language",
+		Language:         "Language",
+		Share:            "Share",
+		CommitsPerDay:    "Commits per Day:",
+		AvgCommitSize:    "Avg. Commit Size (lines):",
+		MedianGap:        "Median Time Between Commits:",
+		LongestStreak:    "Longest Streak:",
+		LongestGap:       "Longest Gap:",
+		FirstCommit:      "First Commit",
+		LastCommit:       "Last Commit",
+	},
+	"pt-BR": {
+		Headers: map[string]string{
+			ColumnHash:      "Hash do Commit",
+			ColumnAuthor:    "Nome do Autor",
+			ColumnEmail:     "E-mail do Autor",
+			ColumnDate:      "Data do Commit",
+			ColumnMessage:   "Mensagem do Commit",
+			ColumnFiles:     "Arquivos Alterados",
+			ColumnAdditions: "Adições",
+			ColumnDeletions: "Remoções",
+			ColumnLines:     "Linhas Alteradas",
+			ColumnBranch:    "Branch",
+			ColumnPR:        "Pull Request",
+			ColumnReview:    "Revisão",
+			ColumnChecks:    "Verificações",
+			ColumnWorkItems: "Itens de Trabalho",
+			ColumnIssues:    "Issues",
+			ColumnType:      "Tipo",
+			ColumnScope:     "Escopo",
+			ColumnDCO:       "DCO",
+			ColumnUnmerged:  "Não Integrado",
+			ColumnPeriod:    "Período",
+		},
+		NoFiles:          "Nenhum arquivo alterado",
+		Totals:           "Totais",
+		CommitsFmt:       "%d commits",
+		LinesChangedFmt:  "%d linhas alteradas (+%d / -%d)",
+		DistinctFilesFmt: "%d arquivos distintos",
+		SummaryTitle:     "Resumo do Repositório",
+		RepoName:         "Nome do Repositório:",
+		TotalCommits:     "Total de Commits:",
+		RepoPath:         "Caminho do Repositório:",
+		DistinctFiles:    "Arquivos Distintos:",
+		LinesChanged:     "Linhas Alteradas:",
+		ByAuthor:         "Commits por Autor",
+		Author:           "Autor",
+		Email:            "E-mail",
+		Commits:          "Commits",
+		ByType:           "Commits por Tipo",
+		Type:             "Tipo",
+		Breaking:         "Incompatíveis",
+		SignedOff:        "Assinados",
+		DCOCompliance:    "Conformidade DCO:",
+		ActivitySheet:    "Atividade",
+		ActivityTitle:    "Commits por Dia da Semana e Hora",
+		Weekday:          "Dia",
+		Weekdays:         [7]string{"Segunda", "Terça", "Quarta", "Quinta", "Sexta", "Sábado", "Domingo"},
+		AfterHoursFmt:    "Fora do expediente (fora de %02d:00–%02d:00 seg–sex):",
+		HotspotsSheet:    "Pontos Críticos",
+		File:             "Arquivo",
+		Authors:          "Autores",
+		BusFactorSheet:   "Fator Ônibus",
+		Directory:        "Diretório",
+		TopAuthor:        "Principal Autor",
+		TopShare:         "Participação",
+		BusFactor:        "Fator Ônibus",
+		DirectoriesSheet: "Diretórios",
+		AllAuthors:       "Todos os autores",
+		ByLanguage:       "Linhas por Linguagem",
+		Language:         "Linguagem",
+		Share:            "Participação",
+		CommitsPerDay:    "Commits por Dia:",
+		AvgCommitSize:    "Tamanho Médio do Commit (linhas):",
+		MedianGap:        "Tempo Mediano entre Commits:",
+		LongestStreak:    "Maior Sequência:",
+		LongestGap:       "Maior Intervalo:",
+		FirstCommit:      "Primeiro Commit",
+		LastCommit:       "Último Commit",
+	},
+	"es": {
+		Headers: map[string]string{
+			ColumnHash:      "Hash del Commit",
+			ColumnAuthor:    "Nombre del Autor",
+			ColumnEmail:     "Correo del Autor",
+			ColumnDate:      "Fecha del Commit",
+			ColumnMessage:   "Mensaje del Commit",
+			ColumnFiles:     "Archivos Modificados",
+			ColumnAdditions: "Adiciones",
+			ColumnDeletions: "Eliminaciones",
+			ColumnLines:     "Líneas Modificadas",
+			ColumnBranch:    "Rama",
+			ColumnPR:        "Pull Request",
+			ColumnReview:    "Revisión",
+			ColumnChecks:    "Comprobaciones",
+			ColumnWorkItems: "Elementos de Trabajo",
+			ColumnIssues:    "Incidencias",
+			ColumnType:      "Tipo",
+			ColumnScope:     "Ámbito",
+			ColumnDCO:       "DCO",
+			ColumnUnmerged:  "Sin Integrar",
+			ColumnPeriod:    "Periodo",
+		},
+		NoFiles:          "Ningún archivo modificado",
+		Totals:           "Totales",
+		CommitsFmt:       "%d commits",
+		LinesChangedFmt:  "%d líneas modificadas (+%d / -%d)",
+		DistinctFilesFmt: "%d archivos distintos",
+		SummaryTitle:     "Resumen del Repositorio",
+		RepoName:         "Nombre del Repositorio:",
+		TotalCommits:     "Total de Commits:",
+		RepoPath:         "Ruta del Repositorio:",
+		DistinctFiles:    "Archivos Distintos:",
+		LinesChanged:     "Líneas Modificadas:",
+		ByAuthor:         "Commits por Autor",
+		Author:           "Autor",
+		Email:            "Correo",
+		Commits:          "Commits",
+		ByType:           "Commits por Tipo",
+		Type:             "Tipo",
+		Breaking:         "Incompatibles",
+		SignedOff:        "Firmados",
+		DCOCompliance:    "Cumplimiento DCO:",
+		ActivitySheet:    "Actividad",
+		ActivityTitle:    "Commits por Día de la Semana y Hora",
+		Weekday:          "Día",
+		Weekdays:         [7]string{"Lunes", "Martes", "Miércoles", "Jueves", "Viernes", "Sábado", "Domingo"},
+		AfterHoursFmt:    "Fuera de horario (fuera de %02d:00–%02d:00 lun–vie):",
+		HotspotsSheet:    "Puntos Calientes",
+		File:             "Archivo",
+		Authors:          "Autores",
+		BusFactorSheet:   "Factor Autobús",
+		Directory:        "Directorio",
+		TopAuthor:        "Autor Principal",
+		TopShare:         "Participación",
+		BusFactor:        "Factor Autobús",
+		DirectoriesSheet: "Directorios",
+		AllAuthors:       "Todos los autores",
+		ByLanguage:       "Líneas por Lenguaje",
+		Language:         "Lenguaje",
+		Share:            "Proporción",
+		CommitsPerDay:    "Commits por Día:",
+		AvgCommitSize:    "Tamaño Medio del Commit (líneas):",
+		MedianGap:        "Tiempo Mediano entre Commits:",
+		LongestStreak:    "Racha más Larga:",
+		LongestGap:       "Mayor Inactividad:",
+		FirstCommit:      "Primer Commit",
+		LastCommit:       "Último Commit",
+	},
+	"de": {
+		Headers: map[string]string{
+			ColumnHash:      "Commit-Hash",
+			ColumnAuthor:    "Autorname",
+			ColumnEmail:     "Autor-E-Mail",
+			ColumnDate:      "Commit-Datum",
+			ColumnMessage:   "Commit-Nachricht",
+			ColumnFiles:     "Geänderte Dateien",
+			ColumnAdditions: "Hinzugefügt",
+			ColumnDeletions: "Entfernt",
+			ColumnLines:     "Geänderte Zeilen",
+			ColumnBranch:    "Branch",
+			ColumnPR:        "Pull Request",
+			ColumnReview:    "Review",
+			ColumnChecks:    "Prüfungen",
+			ColumnWorkItems: "Arbeitselemente",
+			ColumnIssues:    "Vorgänge",
+			ColumnType:      "Typ",
+			ColumnScope:     "Bereich",
+			ColumnDCO:       "DCO",
+			ColumnUnmerged:  "Nicht gemergt",
+			ColumnPeriod:    "Zeitraum",
+		},
+		NoFiles:          "Keine Dateien geändert",
+		Totals:           "Summen",
+		CommitsFmt:       "%d Commits",
+		LinesChangedFmt:  "%d geänderte Zeilen (+%d / -%d)",
+		DistinctFilesFmt: "%d verschiedene Dateien",
+		SummaryTitle:     "Repository-Übersicht",
+		RepoName:         "Repository-Name:",
+		TotalCommits:     "Commits gesamt:",
+		RepoPath:         "Repository-Pfad:",
+		DistinctFiles:    "Verschiedene Dateien:",
+		LinesChanged:     "Geänderte Zeilen:",
+		ByAuthor:         "Commits nach Autor",
+		Author:           "Autor",
+		Email:            "E-Mail",
+		Commits:          "Commits",
+		ByType:           "Commits nach Typ",
+		Type:             "Typ",
+		Breaking:         "Inkompatibel",
+		SignedOff:        "Abgezeichnet",
+		DCOCompliance:    "DCO-Konformität:",
+		ActivitySheet:    "Aktivität",
+		ActivityTitle:    "Commits nach Wochentag und Stunde",
+		Weekday:          "Wochentag",
+		Weekdays:         [7]string{"Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag", "Sonntag"},
+		AfterHoursFmt:    "Außerhalb der Arbeitszeit (außerhalb %02d:00–%02d:00 Mo–Fr):",
+		HotspotsSheet:    "Hotspots",
+		File:             "Datei",
+		Authors:          "Autoren",
+		BusFactorSheet:   "Bus-Faktor",
+		Directory:        "Verzeichnis",
+		TopAuthor:        "Hauptautor",
+		TopShare:         "Anteil",
+		BusFactor:        "Bus-Faktor",
+		DirectoriesSheet: "Verzeichnisse",
+		AllAuthors:       "Alle Autoren",
+		ByLanguage:       "Zeilen nach Sprache",
+		Language:         "Sprache",
+		Share:            "Anteil",
+		CommitsPerDay:    "Commits pro Tag:",
+		AvgCommitSize:    "Ø Commit-Größe (Zeilen):",
+		MedianGap:        "Median-Abstand zwischen Commits:",
+		LongestStreak:    "Längste Serie:",
+		LongestGap:       "Längste Pause:",
+		FirstCommit:      "Erster Commit",
+		LastCommit:       "Letzter Commit",
+	},
+}
+
+// labelsFor resolves locale against the export translations, falling back to DefaultLocale.
+func labelsFor(locale string) exportLabels {
+	tags := make([]string, 0, len(exportLocales))
+	for tag := range exportLocales {
+		tags = append(tags, tag)
+	}
+	if tag := MatchLocale(locale, tags); tag != "" {
+		return exportLocales[tag]
+	}
+	return exportLocales[DefaultLocale]
+}
+
+// MatchLocale picks the tag for locale case-insensitively, then by its language
+// prefix ("pt", "pt-PT" and "pt_BR.UTF-8" all match pt-BR); "" when none matches.
+func MatchLocale(locale string, tags []string) string {
+	locale, _, _ = strings.Cut(strings.TrimSpace(locale), ".")
+	locale = strings.ReplaceAll(locale, "_", "-")
+	lang, _, _ := strings.Cut(locale, "-")
+
+	for _, tag := range tags {
+		if strings.EqualFold(tag, locale) {
+			return tag
+		}
+	}
+	for _, tag := range tags {
+		tagLang, _, _ := strings.Cut(tag, "-")
+		if strings.EqualFold(tagLang, lang) {
+			return tag
+		}
+	}
+	return ""
+}
+
+// columnKeyForHeader maps a header written in any supported locale back to its column key.
+func columnKeyForHeader(header string) string {
+	for _, labels := range exportLocales {
+		for key, h := range labels.Headers {
+			if h == header {
+				return key
+			}
+		}
+	}
+	return ""
+}
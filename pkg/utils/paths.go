@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveDir turns a directory typed or pasted by the user into an absolute path.
+// Surrounding quotes, which Explorer's "Copy as path" adds, are dropped, a leading ~
+// is the home directory, and a bare drive ("D:") or UNC share ("\\server\share")
+// means its root rather than the current directory on it. Empty is the current
+// directory.
+func ResolveDir(input string) (string, error) {
+	dir := strings.TrimSpace(input)
+	if len(dir) >= 2 && (dir[0] == '"' || dir[0] == '\'') && dir[len(dir)-1] == dir[0] {
+		dir = strings.TrimSpace(dir[1 : len(dir)-1])
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~/") || strings.HasPrefix(dir, `~\`) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, dir[1:])
+	}
+	if dir == "" {
+		dir = "."
+	}
+	if volume := filepath.VolumeName(dir); volume != "" && volume == dir {
+		dir += string(filepath.Separator)
+	}
+	return filepath.Abs(dir)
+}
+
+// ExportDir is where the exports of the repository at repoPath are written: the
+// repository itself, or the user's Documents folder when the checkout is read-only,
+// as network shares and container mounts often are.
+func ExportDir(repoPath string) string {
+	if writable(repoPath) {
+		return repoPath
+	}
+	if docs, err := documentsDir(); err == nil && writable(docs) {
+		return docs
+	}
+	return repoPath
+}
+
+// homeDocumentsDir is the Documents folder in the user's home directory, or the
+// home directory itself when there is none.
+func homeDocumentsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	docs := filepath.Join(home, "Documents")
+	if info, err := os.Stat(docs); err == nil && info.IsDir() {
+		return docs, nil
+	}
+	return home, nil
+}
+
+// writable reports whether files can be created in dir, by creating one.
+func writable(dir string) bool {
+	f, err := os.CreateTemp(dir, ".gommits-*")
+	if err != nil {
+		return false
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return true
+}
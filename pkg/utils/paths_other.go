@@ -0,0 +1,8 @@
+//go:build !windows
+
+package utils
+
+// documentsDir is ~/Documents, or the home directory when there is none.
+func documentsDir() (string, error) {
+	return homeDocumentsDir()
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestResolveDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", cwd},
+		{"  ", cwd},
+		{"repo", filepath.Join(cwd, "repo")},
+		{`"repo"`, filepath.Join(cwd, "repo")},
+		{"'repo' ", filepath.Join(cwd, "repo")},
+		{"~", home},
+		{"~/src/repo", filepath.Join(home, "src", "repo")},
+	}
+	if runtime.GOOS == "windows" {
+		tests = append(tests, []struct {
+			input string
+			want  string
+		}{
+			{`C:\repo`, `C:\repo`},
+			{`"C:\My Repo"`, `C:\My Repo`},
+			{`C:/repo/sub`, `C:\repo\sub`},
+			{`C:`, `C:\`},
+			{`~\src`, filepath.Join(home, "src")},
+			{`\\server\share\repo`, `\\server\share\repo`},
+			{`\\server\share`, `\\server\share\`},
+			{`//server/share/repo`, `\\server\share\repo`},
+		}...)
+	} else {
+		tests = append(tests, []struct {
+			input string
+			want  string
+		}{
+			{"/srv/repo/", "/srv/repo"},
+			{`"/srv/my repo"`, "/srv/my repo"},
+		}...)
+	}
+	for _, tt := range tests {
+		got, err := ResolveDir(tt.input)
+		if err != nil {
+			t.Errorf("ResolveDir(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveDir(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// A drive-relative path ("C:repo") is relative to the current directory on that
+// drive, not to the drive's root.
+func TestResolveDirDriveRelative(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("drive letters only exist on Windows")
+	}
+	got, err := ResolveDir("C:repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.VolumeName(got) != "C:" || !filepath.IsAbs(got) || filepath.Base(got) != "repo" {
+		t.Errorf(`ResolveDir("C:repo") = %q, want an absolute path on C: ending in repo`, got)
+	}
+}
+
+func TestExportPathJoining(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{filepath.Join("srv", "repo"), filepath.Join("srv", "repo", "repo_commits.xlsx")},
+	}
+	if runtime.GOOS == "windows" {
+		tests = append(tests, []struct {
+			dir  string
+			want string
+		}{
+			{`C:\repo`, `C:\repo\repo_commits.xlsx`},
+			{`C:\`, `C:\repo_commits.xlsx`},
+			{`\\server\share\repo`, `\\server\share\repo\repo_commits.xlsx`},
+			{`\\server\share\`, `\\server\share\repo_commits.xlsx`},
+		}...)
+	}
+	for _, tt := range tests {
+		if got := ExcelPath(tt.dir, "repo"); got != tt.want {
+			t.Errorf("ExcelPath(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestExportDir(t *testing.T) {
+	home := t.TempDir()
+	docs := filepath.Join(home, "Documents")
+	if err := os.Mkdir(docs, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	wantDocs, err := documentsDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && wantDocs != docs {
+		t.Fatalf("documentsDir() = %q, want %q", wantDocs, docs)
+	}
+
+	writable := t.TempDir()
+	if got := ExportDir(writable); got != writable {
+		t.Errorf("ExportDir(writable) = %q, want the repository", got)
+	}
+	if entries, _ := os.ReadDir(writable); len(entries) != 0 {
+		t.Errorf("ExportDir left %d files behind", len(entries))
+	}
+
+	// A directory that can't be created in stands in for a read-only mount.
+	missing := filepath.Join(t.TempDir(), "missing")
+	if got := ExportDir(missing); got != wantDocs {
+		t.Errorf("ExportDir(unwritable) = %q, want %q", got, wantDocs)
+	}
+
+	if runtime.GOOS != "windows" && os.Geteuid() != 0 {
+		readOnly := t.TempDir()
+		if err := os.Chmod(readOnly, 0o555); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.Chmod(readOnly, 0o755) })
+		if got := ExportDir(readOnly); got != docs {
+			t.Errorf("ExportDir(read-only) = %q, want %q", got, docs)
+		}
+	}
+}
+
+func TestExportDirWithoutDocuments(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the Documents known folder does not follow USERPROFILE")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	missing := filepath.Join(t.TempDir(), "missing")
+	if got := ExportDir(missing); got != home {
+		t.Errorf("ExportDir = %q, want the home directory %q", got, home)
+	}
+
+	t.Setenv("HOME", filepath.Join(home, "gone"))
+	if got := ExportDir(missing); got != missing {
+		t.Errorf("ExportDir with nowhere to write = %q, want the repository", got)
+	}
+}
@@ -0,0 +1,12 @@
+package utils
+
+import "golang.org/x/sys/windows"
+
+// documentsDir is the user's Documents known folder, which OneDrive or a folder
+// redirection policy may have moved out of the profile.
+func documentsDir() (string, error) {
+	if docs, err := windows.KnownFolderPath(windows.FOLDERID_Documents, windows.KF_FLAG_DEFAULT); err == nil && docs != "" {
+		return docs, nil
+	}
+	return homeDocumentsDir()
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// Calendar period presets for CalendarPeriods.
+const (
+	PeriodWeek    = "week"
+	PeriodMonth   = "month"
+	PeriodQuarter = "quarter"
+)
+
+// Period is the half-open time range [From, To).
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// Preceding returns the period of the same length that ends where p starts.
+func (p Period) Preceding() Period {
+	return Period{From: p.From.Add(-p.To.Sub(p.From)), To: p.From}
+}
+
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.From) && t.Before(p.To)
+}
+
+func (p Period) String() string {
+	return p.From.Format(time.DateOnly) + " – " + p.To.Add(-time.Nanosecond).Format(time.DateOnly)
+}
+
+// CalendarPeriods returns the calendar week (from Monday), month or quarter containing
+// now, and the one before it.
+func CalendarPeriods(name string, now time.Time) (current, previous Period, err error) {
+	day := truncateToDay(now)
+	switch name {
+	case PeriodWeek:
+		current.From = day.AddDate(0, 0, -isoWeekday(day))
+		current.To = current.From.AddDate(0, 0, 7)
+		previous = Period{From: current.From.AddDate(0, 0, -7), To: current.From}
+	case PeriodMonth:
+		current.From = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		current.To = current.From.AddDate(0, 1, 0)
+		previous = Period{From: current.From.AddDate(0, -1, 0), To: current.From}
+	case PeriodQuarter:
+		month := time.Month((int(day.Month())-1)/3*3 + 1)
+		current.From = time.Date(day.Year(), month, 1, 0, 0, 0, 0, day.Location())
+		current.To = current.From.AddDate(0, 3, 0)
+		previous = Period{From: current.From.AddDate(0, -3, 0), To: current.From}
+	default:
+		return Period{}, Period{}, fmt.Errorf("unknown period %q: use week, month or quarter", name)
+	}
+	return current, previous, nil
+}
+
+// PeriodStats sums the commits that fall in one period.
+type PeriodStats struct {
+	Period
+	Commits    int
+	Additions  int
+	Deletions  int
+	ActiveDays int
+	Authors    int
+}
+
+func (s PeriodStats) Churn() int {
+	return s.Additions + s.Deletions
+}
+
+// StatsForPeriod aggregates the commits whose date falls in p.
+func StatsForPeriod(commits []models.CommitInfo, p Period) PeriodStats {
+	s := PeriodStats{Period: p}
+	var inside []models.CommitInfo
+	for _, c := range commits {
+		if c.When.IsZero() || !p.Contains(c.When) {
+			continue
+		}
+		inside = append(inside, c)
+		s.Commits++
+		s.Additions += c.Additions
+		s.Deletions += c.Deletions
+	}
+	s.ActiveDays = len(DailyCounts(inside))
+	s.Authors = len(CollectContributors(inside))
+	return s
+}
+
+// FormatDelta renders the change from prev to cur as "+6 (+50%)"; the percentage is
+// left out when prev is zero.
+func FormatDelta(prev, cur int) string {
+	delta := fmt.Sprintf("%+d", cur-prev)
+	if prev == 0 {
+		return delta
+	}
+	return fmt.Sprintf("%s (%+.0f%%)", delta, float64(cur-prev)/float64(prev)*100)
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/leeozaka/gommits/internal/config"
+)
+
+// PluginPrefix is prepended to a format name when looking for a plugin on PATH,
+// so format "pdf" runs gommits-export-pdf.
+const PluginPrefix = "gommits-export-"
+
+// execPlugin runs an external command that receives the JSON export (see JSONSchema)
+// on stdin and writes the finished artifact to stdout.
+type execPlugin struct {
+	format    string
+	command   []string
+	extension string
+}
+
+func findPlugin(format string, cfg config.ExportConfig) (execPlugin, bool) {
+	if p, ok := cfg.Plugins[format]; ok && len(p.Command) > 0 {
+		return execPlugin{format: format, command: p.Command, extension: p.Extension}, true
+	}
+	if path, err := exec.LookPath(PluginPrefix + format); err == nil {
+		return execPlugin{format: format, command: []string{path}}, true
+	}
+	return execPlugin{}, false
+}
+
+func (p execPlugin) Export(req ExportRequest) ([]string, error) {
+	payload, err := EncodeJSONExport(req.Commits, req.RepoName)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := p.extension
+	if ext == "" {
+		ext = p.format
+	}
+	outPath := filepath.Join(req.Dir, req.RepoName+"_commits."+strings.TrimPrefix(ext, "."))
+
+	cmd := exec.Command(p.command[0], p.command[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"GOMMITS_FORMAT="+p.format,
+		"GOMMITS_REPO_PATH="+req.RepoPath,
+		"GOMMITS_REPO_NAME="+req.RepoName,
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("export plugin %q failed: %s", p.format, msg)
+	}
+
+	if err := os.WriteFile(outPath, stdout.Bytes(), 0o644); err != nil {
+		return nil, err
+	}
+	return []string{outPath}, nil
+}
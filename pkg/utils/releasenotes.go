@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// ReleaseNoteTypes orders every section of release notes; unlike the changelog,
+// release notes list all commits.
+var ReleaseNoteTypes = []string{"feat", "fix", "perf", "refactor", "revert", "docs", "test", "build", "ci", "style", "chore", OtherType}
+
+// ReleaseNotes describes the changes between two revisions.
+type ReleaseNotes struct {
+	Repository   string
+	From         string // empty when the range starts at the first commit
+	To           string
+	Date         time.Time
+	Sections     []ChangeSection
+	Contributors []AuthorCount
+	Totals       CommitTotals
+}
+
+func BuildReleaseNotes(commits []models.CommitInfo, repoName, from, to string, date time.Time) ReleaseNotes {
+	return ReleaseNotes{
+		Repository:   repoName,
+		From:         from,
+		To:           to,
+		Date:         date,
+		Sections:     groupChanges(commits, ReleaseNoteTypes),
+		Contributors: CountByAuthor(commits),
+		Totals:       SummarizeCommits(commits),
+	}
+}
+
+// ReleaseNotesPath names the file after the range, e.g. api_release_v1.0.0_v1.1.0.md.
+func ReleaseNotesPath(dir, repoName, from, to, ext string) string {
+	safe := strings.NewReplacer("/", "-", "\\", "-", ":", "-")
+	name := repoName + "_release_"
+	if from != "" {
+		name += safe.Replace(from) + "_"
+	}
+	return filepath.Join(dir, name+safe.Replace(to)+"."+ext)
+}
+
+func (n ReleaseNotes) Title() string {
+	if n.From == "" {
+		return fmt.Sprintf("%s %s", n.Repository, n.To)
+	}
+	return fmt.Sprintf("%s %s → %s", n.Repository, n.From, n.To)
+}
+
+func (n ReleaseNotes) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", n.Title())
+	fmt.Fprintf(&b, "_%s · %d commits · %d contributors · +%d / -%d lines_\n",
+		n.Date.Format(time.DateOnly), n.Totals.Commits, len(n.Contributors), n.Totals.Additions, n.Totals.Deletions)
+
+	for _, section := range n.Sections {
+		fmt.Fprintf(&b, "\n## %s\n\n", section.Heading)
+		for _, e := range section.Entries {
+			fmt.Fprintf(&b, "%s — %s\n", markdownEntry(e), e.Author)
+		}
+	}
+
+	if len(n.Contributors) > 0 {
+		b.WriteString("\n## Contributors\n\n")
+		for _, a := range n.Contributors {
+			fmt.Fprintf(&b, "- %s (%d)\n", a.Author, a.Commits)
+		}
+	}
+	return b.String()
+}
+
+var releaseNotesHTML = template.Must(template.New("release").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 50rem; margin: 2rem auto; padding: 0 1rem; line-height: 1.5; }
+.meta { color: #666; }
+code { background: #f3f3f3; padding: 0 .2rem; }
+.author { color: #666; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">{{.Date.Format "2006-01-02"}} · {{.Totals.Commits}} commits · {{len .Contributors}} contributors · +{{.Totals.Additions}} / -{{.Totals.Deletions}} lines</p>
+{{range .Sections}}
+<h2>{{.Heading}}</h2>
+<ul>
+{{- range .Entries}}
+<li>{{if .Scope}}<strong>{{.Scope}}:</strong> {{end}}{{.Description}} ({{if .URL}}<a href="{{.URL}}"><code>{{.Hash}}</code></a>{{else}}<code>{{.Hash}}</code>{{end}}) <span class="author">— {{.Author}}</span></li>
+{{- end}}
+</ul>
+{{end}}
+{{- if .Contributors}}
+<h2>Contributors</h2>
+<ul>
+{{- range .Contributors}}
+<li>{{.Author}} ({{.Commits}})</li>
+{{- end}}
+</ul>
+{{end -}}
+</body>
+</html>
+`))
+
+func (n ReleaseNotes) HTML() (string, error) {
+	var b strings.Builder
+	if err := releaseNotesHTML.Execute(&b, n); err != nil {
+		return "", fmt.Errorf("failed to render release notes: %v", err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// SprintAuthor is one author's commits over a sprint, bucketed by calendar day.
+type SprintAuthor struct {
+	Name    string
+	Email   string
+	Commits []models.CommitInfo // oldest first
+	Days    map[string][]models.CommitInfo
+}
+
+// SprintByAuthor groups commits by author (through .mailmap) and day, most commits first.
+func SprintByAuthor(commits []models.CommitInfo) []SprintAuthor {
+	index := make(map[string]int)
+	var authors []SprintAuthor
+	for _, c := range commits {
+		name, email := canonicalIdentity(c)
+		key := strings.ToLower(email)
+		i, ok := index[key]
+		if !ok {
+			i = len(authors)
+			index[key] = i
+			authors = append(authors, SprintAuthor{Name: name, Email: email, Days: make(map[string][]models.CommitInfo)})
+		}
+		authors[i].Commits = append(authors[i].Commits, c)
+	}
+
+	for i := range authors {
+		a := &authors[i]
+		sort.SliceStable(a.Commits, func(i, j int) bool { return a.Commits[i].When.Before(a.Commits[j].When) })
+		for _, c := range a.Commits {
+			day := c.When.Format(time.DateOnly)
+			a.Days[day] = append(a.Days[day], c)
+		}
+	}
+	sort.SliceStable(authors, func(i, j int) bool {
+		if len(authors[i].Commits) != len(authors[j].Commits) {
+			return len(authors[i].Commits) > len(authors[j].Commits)
+		}
+		return authors[i].Name < authors[j].Name
+	})
+	return authors
+}
+
+// SprintDays lists every calendar day from from to to inclusive (time.DateOnly).
+func SprintDays(from, to time.Time) []string {
+	var days []string
+	for d := truncateToDay(from); !d.After(to); d = d.AddDate(0, 0, 1) {
+		days = append(days, d.Format(time.DateOnly))
+	}
+	return days
+}
+
+func truncateToDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func SprintPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_sprint.xlsx")
+}
+
+// ExportSprintReport writes the sprint workbook: commits per author and day over
+// [from, to], then each author's commits grouped by day.
+func ExportSprintReport(commits []models.CommitInfo, path string, from, to time.Time) error {
+	authors := SprintByAuthor(commits)
+	days := SprintDays(from, to)
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "#FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %v", err)
+	}
+	groupStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#D9E1F2"}, Pattern: 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create group style: %v", err)
+	}
+
+	sheet := "Sprint"
+	f.SetSheetName("Sheet1", sheet)
+	header := []any{"Author", "Commits", "Lines Changed", "Active Days"}
+	for _, d := range days {
+		header = append(header, d[5:])
+	}
+	f.SetSheetRow(sheet, "A1", &header)
+	last, _ := excelize.CoordinatesToCellName(len(header), 1)
+	f.SetCellStyle(sheet, "A1", last, headerStyle)
+
+	totals := make([]int, len(days))
+	for i, a := range authors {
+		lines := 0
+		for _, c := range a.Commits {
+			lines += c.Additions + c.Deletions
+		}
+		row := []any{a.Name, len(a.Commits), lines, len(a.Days)}
+		for j, d := range days {
+			n := len(a.Days[d])
+			totals[j] += n
+			row = append(row, n)
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		f.SetSheetRow(sheet, cell, &row)
+	}
+	totalRow := []any{"Total", len(commits), nil, nil}
+	for _, n := range totals {
+		totalRow = append(totalRow, n)
+	}
+	cell, _ := excelize.CoordinatesToCellName(1, len(authors)+2)
+	f.SetSheetRow(sheet, cell, &totalRow)
+	end, _ := excelize.CoordinatesToCellName(len(totalRow), len(authors)+2)
+	f.SetCellStyle(sheet, cell, end, groupStyle)
+
+	f.SetColWidth(sheet, "A", "A", 28)
+	f.SetColWidth(sheet, "B", "D", 14)
+	if len(days) > 0 {
+		first, _ := excelize.ColumnNumberToName(5)
+		lastCol, _ := excelize.ColumnNumberToName(len(header))
+		f.SetColWidth(sheet, first, lastCol, 7)
+	}
+	f.SetPanes(sheet, &excelize.Panes{Freeze: true, XSplit: 1, YSplit: 1, TopLeftCell: "B2", ActivePane: "bottomRight"})
+
+	log := "By Author"
+	if _, err := f.NewSheet(log); err != nil {
+		return fmt.Errorf("failed to create sheet: %v", err)
+	}
+	f.SetSheetRow(log, "A1", &[]any{"Date", "Time", "Hash", "Message", "Files", "Additions", "Deletions"})
+	f.SetCellStyle(log, "A1", "G1", headerStyle)
+	row := 2
+	for _, a := range authors {
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		end, _ := excelize.CoordinatesToCellName(7, row)
+		f.SetCellValue(log, cell, fmt.Sprintf("%s <%s> — %d commits on %d days", a.Name, a.Email, len(a.Commits), len(a.Days)))
+		f.SetCellStyle(log, cell, end, groupStyle)
+		row++
+		for _, d := range days {
+			for _, c := range a.Days[d] {
+				short := c.Hash
+				if len(short) > 7 {
+					short = short[:7]
+				}
+				cell, _ := excelize.CoordinatesToCellName(1, row)
+				f.SetSheetRow(log, cell, &[]any{d, c.When.Format("15:04"), short, c.Message, len(touchedFiles(c)), c.Additions, c.Deletions})
+				row++
+			}
+		}
+		row++
+	}
+	f.SetColWidth(log, "A", "A", 12)
+	f.SetColWidth(log, "B", "C", 10)
+	f.SetColWidth(log, "D", "D", 70)
+	f.SetColWidth(log, "E", "G", 10)
+	f.SetPanes(log, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to save sprint report: %v", err)
+	}
+	return nil
+}
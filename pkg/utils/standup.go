@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// StandupSince returns the start of the previous working day before now, so a
+// Monday standup covers Friday and the weekend.
+func StandupSince(now time.Time) time.Time {
+	day := truncateToDay(now).AddDate(0, 0, -1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// RenderStandup lists commit subjects as Markdown bullets under one heading per day,
+// oldest day first, ready to paste into a standup channel.
+func RenderStandup(commits []models.CommitInfo, now time.Time) string {
+	sorted := make([]models.CommitInfo, len(commits))
+	copy(sorted, commits)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].When.Before(sorted[j].When) })
+
+	today := truncateToDay(now)
+	var b strings.Builder
+	current := ""
+	for _, c := range sorted {
+		day := c.When.Format(time.DateOnly)
+		if day != current {
+			if current != "" {
+				b.WriteString("\n")
+			}
+			current = day
+			fmt.Fprintf(&b, "**%s**\n", standupDayLabel(truncateToDay(c.When), today))
+		}
+		fmt.Fprintf(&b, "- %s\n", c.Message)
+	}
+	if current == "" {
+		b.WriteString("No commits since the last working day.\n")
+	}
+	return b.String()
+}
+
+func standupDayLabel(day, today time.Time) string {
+	switch {
+	case day.Equal(today):
+		return "Today"
+	case day.Equal(today.AddDate(0, 0, -1)):
+		return "Yesterday"
+	default:
+		return day.Format("Monday, Jan 2")
+	}
+}
@@ -0,0 +1,242 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// AllAuthors names the aggregate row returned by RangeStats.
+const AllAuthors = "All authors"
+
+// AuthorStats aggregates one author's commits (or the whole range, see RangeStats).
+type AuthorStats struct {
+	Name      string
+	Email     string
+	Commits   int
+	Files     int // distinct files touched
+	Additions int
+	Deletions int
+
+	// BusiestDay is the date ("2006-01-02") with the most commits; empty when no
+	// commit has a parseable date.
+	BusiestDay        string
+	BusiestDayCommits int
+
+	Languages []LanguageShare
+
+	// First and Last are the oldest and newest author dates; zero without dated commits.
+	First time.Time
+	Last  time.Time
+	// CommitsPerDay averages commits over the calendar days from First to Last.
+	CommitsPerDay float64
+	// MedianGap is the median time between consecutive commits; zero with fewer than two.
+	MedianGap time.Duration
+
+	// LongestStreak is the longest run of consecutive days with commits.
+	LongestStreak DayRange
+	// LongestGaps are the longest runs of days without commits between active days,
+	// longest first (at most maxGaps).
+	LongestGaps []DayRange
+}
+
+// DayRange is an inclusive span of calendar days (time.DateOnly).
+type DayRange struct {
+	Start string
+	End   string
+	Days  int
+}
+
+func (s AuthorStats) LinesChanged() int {
+	return s.Additions + s.Deletions
+}
+
+// AvgCommitSize is the mean number of lines changed per commit.
+func (s AuthorStats) AvgCommitSize() float64 {
+	if s.Commits == 0 {
+		return 0
+	}
+	return float64(s.LinesChanged()) / float64(s.Commits)
+}
+
+// ComputeAuthorStats returns stats per .mailmap-resolved author, most commits first.
+func ComputeAuthorStats(commits []models.CommitInfo) []AuthorStats {
+	index := make(map[string]int)
+	var groups [][]models.CommitInfo
+	for _, c := range commits {
+		_, email := canonicalIdentity(c)
+		key := strings.ToLower(email)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], c)
+	}
+
+	stats := make([]AuthorStats, len(groups))
+	for i, g := range groups {
+		stats[i] = computeStats(g)
+		stats[i].Name, stats[i].Email = canonicalIdentity(g[0])
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Commits != stats[j].Commits {
+			return stats[i].Commits > stats[j].Commits
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+// RangeStats aggregates every commit into a single AllAuthors entry.
+func RangeStats(commits []models.CommitInfo) AuthorStats {
+	s := computeStats(commits)
+	s.Name = AllAuthors
+	return s
+}
+
+func computeStats(commits []models.CommitInfo) AuthorStats {
+	var s AuthorStats
+	files := make(map[string]bool)
+	for _, c := range commits {
+		s.Commits++
+		s.Additions += c.Additions
+		s.Deletions += c.Deletions
+		for _, f := range touchedFiles(c) {
+			files[f] = true
+		}
+	}
+	s.Files = len(files)
+	s.Languages = LanguageBreakdown(commits)
+	s.First, s.Last, s.CommitsPerDay, s.MedianGap = cadence(commits)
+	s.LongestStreak, s.LongestGaps = streaks(DailyCounts(commits))
+	for day, n := range DailyCounts(commits) {
+		// Ties go to the earliest day so the result is stable.
+		if n > s.BusiestDayCommits || (n == s.BusiestDayCommits && day < s.BusiestDay) {
+			s.BusiestDay, s.BusiestDayCommits = day, n
+		}
+	}
+	return s
+}
+
+// DailyCounts counts commits per calendar day (time.DateOnly) in each commit's own
+// timezone, so convert first (see ConvertTimezone) for a consistent view.
+func DailyCounts(commits []models.CommitInfo) map[string]int {
+	counts := make(map[string]int)
+	for _, c := range commits {
+		if !c.When.IsZero() {
+			counts[c.When.Format(time.DateOnly)]++
+		}
+	}
+	return counts
+}
+
+// cadence measures how regularly commits land.
+func cadence(commits []models.CommitInfo) (first, last time.Time, perDay float64, medianGap time.Duration) {
+	var times []time.Time
+	for _, c := range commits {
+		if !c.When.IsZero() {
+			times = append(times, c.When)
+		}
+	}
+	if len(times) == 0 {
+		return
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	first, last = times[0], times[len(times)-1]
+
+	firstDay := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+	lastDay := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(lastDay.Sub(firstDay).Hours()/24) + 1
+	perDay = float64(len(times)) / float64(days)
+
+	if len(times) < 2 {
+		return
+	}
+	gaps := make([]time.Duration, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		gaps[i-1] = times[i].Sub(times[i-1])
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	if n := len(gaps); n%2 == 1 {
+		medianGap = gaps[n/2]
+	} else {
+		medianGap = (gaps[n/2-1] + gaps[n/2]) / 2
+	}
+	return
+}
+
+// FormatGap renders a duration coarsely for reports: "2d 4h", "3h 12m" or "45m".
+func FormatGap(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// maxGaps caps AuthorStats.LongestGaps.
+const maxGaps = 3
+
+// streaks finds the longest run of active days and the longest idle spans between
+// active days.
+func streaks(counts map[string]int) (longest DayRange, gaps []DayRange) {
+	days := make([]time.Time, 0, len(counts))
+	for day := range counts {
+		if t, err := time.Parse(time.DateOnly, day); err == nil {
+			days = append(days, t)
+		}
+	}
+	if len(days) == 0 {
+		return
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	runStart := days[0]
+	for i := 1; i <= len(days); i++ {
+		if i < len(days) {
+			idle := int(days[i].Sub(days[i-1]).Hours()/24) - 1
+			if idle == 0 {
+				continue
+			}
+			gaps = append(gaps, DayRange{
+				Start: days[i-1].AddDate(0, 0, 1).Format(time.DateOnly),
+				End:   days[i].AddDate(0, 0, -1).Format(time.DateOnly),
+				Days:  idle,
+			})
+		}
+		if run := int(days[i-1].Sub(runStart).Hours()/24) + 1; run > longest.Days {
+			longest = DayRange{Start: runStart.Format(time.DateOnly), End: days[i-1].Format(time.DateOnly), Days: run}
+		}
+		if i < len(days) {
+			runStart = days[i]
+		}
+	}
+
+	sort.SliceStable(gaps, func(i, j int) bool { return gaps[i].Days > gaps[j].Days })
+	if len(gaps) > maxGaps {
+		gaps = gaps[:maxGaps]
+	}
+	return longest, gaps
+}
+
+// String renders "2024-03-04 – 2024-03-08 (5 days)", or a single date for one day.
+func (r DayRange) String() string {
+	if r.Days == 0 {
+		return ""
+	}
+	if r.Start == r.End {
+		return fmt.Sprintf("%s (1 day)", r.Start)
+	}
+	return fmt.Sprintf("%s – %s (%d days)", r.Start, r.End, r.Days)
+}
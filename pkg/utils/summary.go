@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// CommitTotals aggregates counts across a set of commits.
+type CommitTotals struct {
+	Commits       int
+	DistinctFiles int
+	Additions     int
+	Deletions     int
+}
+
+func (t CommitTotals) LinesChanged() int {
+	return t.Additions + t.Deletions
+}
+
+func SummarizeCommits(commits []models.CommitInfo) CommitTotals {
+	files := make(map[string]bool)
+	totals := CommitTotals{Commits: len(commits)}
+	for _, c := range commits {
+		for _, f := range c.Files {
+			files[f] = true
+		}
+		totals.Additions += c.Additions
+		totals.Deletions += c.Deletions
+	}
+	totals.DistinctFiles = len(files)
+	return totals
+}
+
+type AuthorCount struct {
+	Author    string
+	Email     string
+	Commits   int
+	SignedOff int // commits carrying the author's own Signed-off-by
+
+	// First and Last are the author's oldest and newest commit dates in the range.
+	First time.Time
+	Last  time.Time
+}
+
+// CountByAuthor tallies commits per author email (case-insensitive), most active first.
+func CountByAuthor(commits []models.CommitInfo) []AuthorCount {
+	index := make(map[string]int)
+	var counts []AuthorCount
+	for _, c := range commits {
+		key := strings.ToLower(c.Email)
+		i, ok := index[key]
+		if !ok {
+			i = len(counts)
+			index[key] = i
+			counts = append(counts, AuthorCount{Author: c.Author, Email: c.Email})
+		}
+		counts[i].Commits++
+		if DCOStatus(c) == DCOSigned {
+			counts[i].SignedOff++
+		}
+		if !c.When.IsZero() {
+			if counts[i].First.IsZero() || c.When.Before(counts[i].First) {
+				counts[i].First = c.When
+			}
+			if c.When.After(counts[i].Last) {
+				counts[i].Last = c.When
+			}
+		}
+	}
+
+	sort.SliceStable(counts, func(i, j int) bool {
+		if counts[i].Commits != counts[j].Commits {
+			return counts[i].Commits > counts[j].Commits
+		}
+		return counts[i].Author < counts[j].Author
+	})
+	return counts
+}
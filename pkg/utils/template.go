@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/models"
+)
+
+// TemplateData is what a user template receives as its root value (".").
+type TemplateData struct {
+	Repository  string
+	GeneratedAt time.Time
+	Commits     []models.CommitInfo
+	Totals      CommitTotals
+	Authors     []AuthorCount
+}
+
+// TemplatePath names the rendered file after the template, dropping a trailing
+// ".tmpl": report.adoc.tmpl becomes <repo>_commits.adoc.
+func TemplatePath(dir, repoName, templateFile string) string {
+	ext := filepath.Ext(strings.TrimSuffix(filepath.Base(templateFile), ".tmpl"))
+	if ext == "" {
+		ext = ".txt"
+	}
+	return filepath.Join(dir, repoName+"_commits"+ext)
+}
+
+// ExportWithTemplate renders commits through the Go text/template at templateFile.
+// Besides the builtins, templates can call join, lower, upper, trim and
+// date (which formats a time with the configured export date layout).
+func ExportWithTemplate(commits []models.CommitInfo, repoName, templateFile, outPath, dateLayout string) error {
+	funcs := template.FuncMap{
+		"join":  strings.Join,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"trim":  strings.TrimSpace,
+		"date": func(t time.Time) string {
+			if dateLayout == DateFormatGit {
+				return t.Format(gitDateLayout)
+			}
+			return t.Format(dateLayout)
+		},
+	}
+
+	tmpl, err := template.New(filepath.Base(templateFile)).Funcs(funcs).ParseFiles(templateFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %v", err)
+	}
+
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	data := TemplateData{
+		Repository:  repoName,
+		GeneratedAt: time.Now(),
+		Commits:     commits,
+		Totals:      SummarizeCommits(commits),
+		Authors:     CountByAuthor(commits),
+	}
+	if err := tmpl.Execute(out, data); err != nil {
+		return fmt.Errorf("failed to render template: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeozaka/gommits/internal/config"
+	"github.com/leeozaka/gommits/internal/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// FormatTimesheet writes the per-day worklog workbook.
+const FormatTimesheet = "timesheet"
+
+// WorklogDay is one author's estimated work on one calendar day.
+type WorklogDay struct {
+	Date     string // time.DateOnly
+	Author   string
+	Email    string
+	Commits  []models.CommitInfo // oldest first
+	Sessions int
+	Start    time.Time
+	End      time.Time
+	Hours    float64
+}
+
+// Messages returns the day's commit subjects, oldest first.
+func (d WorklogDay) Messages() []string {
+	msgs := make([]string, len(d.Commits))
+	for i, c := range d.Commits {
+		msgs[i] = c.Message
+	}
+	return msgs
+}
+
+// BuildTimesheet buckets commits into author days and estimates hours with the
+// session heuristic in cfg. Days are ordered by date, then author.
+func BuildTimesheet(commits []models.CommitInfo, cfg config.TimesheetConfig) []WorklogDay {
+	index := make(map[[2]string]int)
+	var days []WorklogDay
+	for _, c := range commits {
+		if c.When.IsZero() {
+			continue
+		}
+		name, email := canonicalIdentity(c)
+		date := c.When.Format(time.DateOnly)
+		key := [2]string{date, strings.ToLower(email)}
+		i, ok := index[key]
+		if !ok {
+			i = len(days)
+			index[key] = i
+			days = append(days, WorklogDay{Date: date, Author: name, Email: email})
+		}
+		days[i].Commits = append(days[i].Commits, c)
+	}
+
+	for i := range days {
+		estimateDay(&days[i], cfg)
+	}
+	sort.SliceStable(days, func(i, j int) bool {
+		if days[i].Date != days[j].Date {
+			return days[i].Date < days[j].Date
+		}
+		return days[i].Author < days[j].Author
+	})
+	return days
+}
+
+func estimateDay(d *WorklogDay, cfg config.TimesheetConfig) {
+	sort.SliceStable(d.Commits, func(i, j int) bool { return d.Commits[i].When.Before(d.Commits[j].When) })
+	d.Start = d.Commits[0].When
+	d.End = d.Commits[len(d.Commits)-1].When
+
+	gap := time.Duration(cfg.SessionGapMinutes) * time.Minute
+	lead := time.Duration(cfg.FirstCommitMinutes) * time.Minute
+	var worked time.Duration
+	sessionStart := d.Commits[0].When
+	for i := 1; i <= len(d.Commits); i++ {
+		if i < len(d.Commits) && (gap <= 0 || d.Commits[i].When.Sub(d.Commits[i-1].When) <= gap) {
+			continue
+		}
+		worked += d.Commits[i-1].When.Sub(sessionStart) + lead
+		d.Sessions++
+		if i < len(d.Commits) {
+			sessionStart = d.Commits[i].When
+		}
+	}
+
+	hours := worked.Hours()
+	if cfg.MinHoursPerDay > 0 {
+		hours = math.Max(hours, cfg.MinHoursPerDay)
+	}
+	if cfg.MaxHoursPerDay > 0 {
+		hours = math.Min(hours, cfg.MaxHoursPerDay)
+	}
+	if cfg.RoundMinutes > 0 {
+		step := float64(cfg.RoundMinutes) / 60
+		hours = math.Ceil(hours/step-1e-9) * step
+	}
+	d.Hours = hours
+}
+
+// TotalHours sums the estimated hours of days.
+func TotalHours(days []WorklogDay) float64 {
+	total := 0.0
+	for _, d := range days {
+		total += d.Hours
+	}
+	return total
+}
+
+func TimesheetPath(dir, repoName string) string {
+	return filepath.Join(dir, repoName+"_timesheet.xlsx")
+}
+
+// ExportTimesheet writes the worklog, one row per author and day with the estimated
+// hours and what was committed, and an invoice sheet pricing those hours.
+func ExportTimesheet(commits []models.CommitInfo, path string, cfg config.Config) error {
+	days := BuildTimesheet(commits, cfg.Timesheet)
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "#FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %v", err)
+	}
+	wrapStyle, err := f.NewStyle(&excelize.Style{Alignment: &excelize.Alignment{WrapText: true, Vertical: "top"}})
+	if err != nil {
+		return fmt.Errorf("failed to create wrap style: %v", err)
+	}
+	hoursStyle, err := f.NewStyle(&excelize.Style{NumFmt: 2})
+	if err != nil {
+		return fmt.Errorf("failed to create hours style: %v", err)
+	}
+	totalStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}, NumFmt: 2})
+	if err != nil {
+		return fmt.Errorf("failed to create totals style: %v", err)
+	}
+
+	sheet := "Worklog"
+	f.SetSheetName("Sheet1", sheet)
+	f.SetSheetRow(sheet, "A1", &[]any{"Date", "Author", "Email", "Commits", "Sessions", "Start", "End", "Hours", "Work Done"})
+	f.SetCellStyle(sheet, "A1", "I1", headerStyle)
+
+	for i, d := range days {
+		row := i + 2
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		f.SetSheetRow(sheet, cell, &[]any{d.Date, d.Author, d.Email, len(d.Commits), d.Sessions,
+			d.Start.Format("15:04"), d.End.Format("15:04"), d.Hours, strings.Join(d.Messages(), "\n")})
+		hours, _ := excelize.CoordinatesToCellName(8, row)
+		f.SetCellStyle(sheet, hours, hours, hoursStyle)
+		work, _ := excelize.CoordinatesToCellName(9, row)
+		f.SetCellStyle(sheet, work, work, wrapStyle)
+	}
+
+	totalRow := len(days) + 2
+	label, _ := excelize.CoordinatesToCellName(1, totalRow)
+	total, _ := excelize.CoordinatesToCellName(8, totalRow)
+	f.SetCellValue(sheet, label, "Total")
+	if len(days) > 0 {
+		f.SetCellFormula(sheet, total, fmt.Sprintf("SUM(H2:H%d)", totalRow-1))
+	}
+	f.SetCellStyle(sheet, label, total, totalStyle)
+
+	f.SetColWidth(sheet, "A", "A", 12)
+	f.SetColWidth(sheet, "B", "C", 24)
+	f.SetColWidth(sheet, "D", "H", 10)
+	f.SetColWidth(sheet, "I", "I", 80)
+	f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+
+	if err := writeInvoiceSheet(f, Billing(days, cfg.Timesheet), cfg.Timesheet.Currency, headerStyle, totalStyle); err != nil {
+		return err
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to save timesheet: %v", err)
+	}
+	return nil
+}
+
+// writeInvoiceSheet lists billed hours per week, project and author with a subtotal
+// after each week, and the totals per project beside them.
+func writeInvoiceSheet(f *excelize.File, lines []BillingLine, currency string, headerStyle, totalStyle int) error {
+	sheet := "Invoice"
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create invoice sheet: %v", err)
+	}
+	moneyStyle, err := f.NewStyle(&excelize.Style{NumFmt: 4})
+	if err != nil {
+		return fmt.Errorf("failed to create amount style: %v", err)
+	}
+	amount := "Amount"
+	if currency != "" {
+		amount += " (" + currency + ")"
+	}
+
+	f.SetSheetRow(sheet, "A1", &[]any{"Week", "Project", "Author", "Hours", "Rate", amount})
+	f.SetCellStyle(sheet, "A1", "F1", headerStyle)
+
+	row := 2
+	var weekHours, weekAmount, hours, total float64
+	flushWeek := func(week string) {
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		end, _ := excelize.CoordinatesToCellName(6, row)
+		f.SetSheetRow(sheet, cell, &[]any{"Week of " + week, nil, nil, weekHours, nil, weekAmount})
+		f.SetCellStyle(sheet, cell, end, totalStyle)
+		row += 2
+		weekHours, weekAmount = 0, 0
+	}
+	for i, l := range lines {
+		if i > 0 && lines[i-1].Week != l.Week {
+			flushWeek(lines[i-1].Week)
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		f.SetSheetRow(sheet, cell, &[]any{l.Week, l.Project, l.Author, l.Hours, l.Rate, l.Amount()})
+		hoursCell, _ := excelize.CoordinatesToCellName(4, row)
+		amountCell, _ := excelize.CoordinatesToCellName(6, row)
+		f.SetCellStyle(sheet, hoursCell, amountCell, moneyStyle)
+		weekHours += l.Hours
+		weekAmount += l.Amount()
+		hours += l.Hours
+		total += l.Amount()
+		row++
+	}
+	if len(lines) > 0 {
+		flushWeek(lines[len(lines)-1].Week)
+	}
+	cell, _ := excelize.CoordinatesToCellName(1, row)
+	end, _ := excelize.CoordinatesToCellName(6, row)
+	f.SetSheetRow(sheet, cell, &[]any{"Total", nil, nil, hours, nil, total})
+	f.SetCellStyle(sheet, cell, end, totalStyle)
+
+	f.SetSheetRow(sheet, "H1", &[]any{"Project", "Hours", amount})
+	f.SetCellStyle(sheet, "H1", "J1", headerStyle)
+	for i, p := range ProjectTotals(lines) {
+		cell, _ := excelize.CoordinatesToCellName(8, i+2)
+		f.SetSheetRow(sheet, cell, &[]any{p.Project, p.Hours, p.Amount})
+		hoursCell, _ := excelize.CoordinatesToCellName(9, i+2)
+		amountCell, _ := excelize.CoordinatesToCellName(10, i+2)
+		f.SetCellStyle(sheet, hoursCell, amountCell, moneyStyle)
+	}
+
+	f.SetColWidth(sheet, "A", "A", 18)
+	f.SetColWidth(sheet, "B", "C", 24)
+	f.SetColWidth(sheet, "D", "F", 14)
+	f.SetColWidth(sheet, "H", "H", 24)
+	f.SetColWidth(sheet, "I", "J", 14)
+	return nil
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// BundleArtifacts zips files into <dir>/<repoName>_export_<timestamp>.zip and returns its path.
+// The originals are left in place.
+func BundleArtifacts(files []string, dir, repoName string, now time.Time) (string, error) {
+	zipPath := filepath.Join(dir, fmt.Sprintf("%s_export_%s.zip", repoName, now.Format("20060102_150405")))
+
+	out, err := os.Create(zipPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create zip: %v", err)
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+	for _, path := range files {
+		if err := addToZip(zw, path, now); err != nil {
+			zw.Close()
+			return "", fmt.Errorf("failed to add %s to zip: %v", filepath.Base(path), err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("failed to finalize zip: %v", err)
+	}
+	return zipPath, nil
+}
+
+func addToZip(zw *zip.Writer, path string, modified time.Time) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     filepath.Base(path),
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, in)
+	return err
+}